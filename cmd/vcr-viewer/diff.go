@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+func runDiff(args []string) error {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	maxGrowth := flags.Float64("max-growth", 10, "fail if the new cassette is this many times bigger than the old one, 0 to disable")
+	summary := flags.Bool("summary", false, "only print the size of the cassettes")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() != 2 {
+		return fmt.Errorf("diff expects two cassettes, got %d", flags.NArg())
+	}
+
+	diff, err := acctest.DiffCassettes(flags.Arg(0), flags.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	if *summary {
+		diff.Interactions = nil
+	}
+
+	diff.Print(os.Stdout)
+
+	if *maxGrowth > 0 && diff.Growth() >= *maxGrowth {
+		return fmt.Errorf("%s grew x%.2f, more than the allowed x%.2f", diff.NewPath, diff.Growth(), *maxGrowth)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+func runLint(args []string) error {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	strict := flags.Bool("strict", false, "fail on warnings too")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	paths, err := cassettePaths(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	if len(paths) == 0 {
+		return errors.New("lint expects at least one cassette or directory")
+	}
+
+	issues := []acctest.LintIssue(nil)
+
+	for _, path := range paths {
+		cassetteIssues, err := acctest.LintCassette(path)
+		if err != nil {
+			return err
+		}
+
+		issues = append(issues, cassetteIssues...)
+	}
+
+	for _, issue := range issues {
+		_, _ = fmt.Fprintln(os.Stdout, issue)
+	}
+
+	if acctest.HasLintErrors(issues) || (*strict && len(issues) > 0) {
+		return fmt.Errorf("found %d issues in %d cassettes", len(issues), len(paths))
+	}
+
+	return nil
+}
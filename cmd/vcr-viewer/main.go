@@ -1,55 +1,97 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
 	"log"
 	"os"
-
-	"gopkg.in/dnaeon/go-vcr.v3/cassette"
+	"path/filepath"
+	"strings"
 )
 
+const usage = `Usage: %[1]s <command> [arguments]
+
+Commands:
+  show <cassette>                           print the interactions of a cassette
+  diff [-max-growth N] <old> <new>          compare two cassettes interaction by interaction
+  lint [-strict] <cassette|directory>...    look for leaked secrets and uncompressed wait loops
+  stats <cassette|directory>...             count the recorded requests per API
+
+Running %[1]s <cassette> is the same as %[1]s show <cassette>.
+`
+
 func main() {
 	if len(os.Args) < 2 {
-		log.Fatalf("Usage: %s <cassette_file_name_without_yaml>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, usage, os.Args[0])
+		os.Exit(2)
 	}
 
-	path := os.Args[1]
+	command, args := os.Args[1], os.Args[2:]
 
-	data, err := cassette.Load(path)
-	if err != nil {
-		log.Fatalf("Error while reading file: %v\n", err)
+	var err error
+
+	switch command {
+	case "show":
+		err = runShow(args)
+	case "diff":
+		err = runDiff(args)
+	case "lint":
+		err = runLint(args)
+	case "stats":
+		err = runStats(args)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprintf(os.Stdout, usage, os.Args[0])
+	default:
+		err = runShow(os.Args[1:])
 	}
 
-	for i := range len(data.Interactions) {
-		interaction := data.Interactions[i]
+	// The usage of the flags has already been printed
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 
-		log.Println("--------------")
-		log.Printf("Interaction %d:\n", i+1)
-		log.Printf("  Request:\n")
-		log.Printf("    Method: %s\n", interaction.Request.Method)
-		log.Printf("    URL: %s\n", interaction.Request.URL)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
 
-		if interaction.Request.Body != "" {
-			log.Printf("    Body: %s\n", interaction.Request.Body)
+// cassettePaths expands the given directories into the cassettes they contain
+func cassettePaths(args []string) ([]string, error) {
+	paths := []string(nil)
+
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil && !strings.HasSuffix(arg, ".yaml") {
+			info, err = os.Stat(arg + ".yaml")
 		}
 
-		log.Printf("  Response:\n")
-		log.Printf("    Status: %s\n", interaction.Response.Status)
-		log.Printf("    Body: %s\n", interaction.Response.Body)
+		if err != nil {
+			return nil, err
+		}
 
-		var m map[string]any
+		if !info.IsDir() {
+			paths = append(paths, arg)
 
-		err := json.Unmarshal([]byte(interaction.Response.Body), &m)
-		if err != nil {
 			continue
 		}
 
-		if m["status"] != nil {
-			log.Println("++++++++++++++++")
-			log.Printf("status: %s\n", m["status"])
-			log.Println("++++++++++++++++")
-		}
+		err = filepath.WalkDir(arg, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
 
-		log.Println("--------------")
+			if !entry.IsDir() && strings.HasSuffix(path, ".cassette.yaml") {
+				paths = append(paths, path)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
+
+	return paths, nil
 }
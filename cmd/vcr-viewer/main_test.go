@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/dnaeon/go-vcr.v3/cassette"
+)
+
+// mainArgsEnv holds the arguments of main when the test binary is run as vcr-viewer
+const mainArgsEnv = "VCR_VIEWER_TEST_ARGS"
+
+func TestMain(m *testing.M) {
+	if args, ok := os.LookupEnv(mainArgsEnv); ok {
+		os.Args = append([]string{"vcr-viewer"}, strings.Fields(args)...)
+		main()
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+// saveCassette saves a cassette polling a server the given number of times and returns its path
+func saveCassette(t *testing.T, name string, polls int, status string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name+".cassette")
+
+	c := cassette.New(path)
+	for range polls {
+		c.AddInteraction(&cassette.Interaction{
+			Request: cassette.Request{
+				Method:  http.MethodGet,
+				URL:     "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers/11111111-1111-1111-1111-111111111111",
+				Headers: http.Header{},
+			},
+			Response: cassette.Response{
+				Code: http.StatusOK,
+				Body: fmt.Sprintf(`{"status":%q}`, status),
+			},
+		})
+	}
+
+	require.NoError(t, c.Save())
+
+	return path + ".yaml"
+}
+
+// runMain runs the test binary as vcr-viewer with the given arguments and returns its exit code
+func runMain(t *testing.T, args ...string) int {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	cmd.Env = append(os.Environ(), mainArgsEnv+"="+strings.Join(args, " "))
+
+	err := cmd.Run()
+	if exitErr := (*exec.ExitError)(nil); errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+
+	require.NoError(t, err)
+
+	return 0
+}
+
+func TestRunDiffFlags(t *testing.T) {
+	old := saveCassette(t, "old", 1, "running")
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "unknown flag", args: []string{"-growth", "2", old, old}, want: "flag provided but not defined: -growth"},
+		{name: "invalid max growth", args: []string{"-max-growth", "ten", old, old}, want: `invalid value "ten" for flag -max-growth`},
+		{name: "missing cassette", args: []string{"-max-growth", "2", old}, want: "diff expects two cassettes, got 1"},
+		{name: "flag after the cassettes", args: []string{old, old, "-summary"}, want: "diff expects two cassettes, got 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, runDiff(tt.args), tt.want)
+		})
+	}
+
+	require.ErrorIs(t, runDiff([]string{"-h"}), flag.ErrHelp)
+}
+
+func TestRunDiffMaxGrowth(t *testing.T) {
+	old := saveCassette(t, "old", 1, "running")
+	grown := saveCassette(t, "new", 20, "running")
+
+	require.ErrorContains(t, runDiff([]string{old, grown}), "more than the allowed x10.00")
+	require.ErrorContains(t, runDiff([]string{"-summary", "-max-growth", "5", old, grown}), "more than the allowed x5.00")
+	require.NoError(t, runDiff([]string{"-max-growth", "25", old, grown}))
+	require.NoError(t, runDiff([]string{"-max-growth", "0", old, grown}))
+
+	// The exit code is what the cassettes workflow checks
+	assert.Equal(t, 1, runMain(t, "diff", "-summary", "-max-growth", "10", old, grown))
+	assert.Equal(t, 0, runMain(t, "diff", "-summary", "-max-growth", "25", old, grown))
+	assert.Equal(t, 0, runMain(t, "diff", "-h"))
+	assert.Equal(t, 1, runMain(t, "diff", "-max-growth"))
+}
+
+func TestRunLintFlags(t *testing.T) {
+	// Reading a running server three times in a row is only a warning
+	polling := saveCassette(t, "polling", 3, "running")
+
+	require.NoError(t, runLint([]string{polling}))
+	require.ErrorContains(t, runLint([]string{"-strict", polling}), "found 1 issues in 1 cassettes")
+	require.ErrorContains(t, runLint([]string{"-strict"}), "lint expects at least one cassette or directory")
+	require.ErrorContains(t, runLint([]string{"-fix", polling}), "flag provided but not defined: -fix")
+
+	assert.Equal(t, 0, runMain(t, "lint", filepath.Dir(polling)))
+	assert.Equal(t, 1, runMain(t, "lint", "-strict", filepath.Dir(polling)))
+
+	// A leaked access key is an error even without -strict
+	leaked := saveCassette(t, "leaked", 1, "SCW1A2B3C4D5E6F7G8H9")
+	require.ErrorContains(t, runLint([]string{leaked}), "found 1 issues in 1 cassettes")
+	assert.Equal(t, 1, runMain(t, "lint", leaked))
+}
+
+func TestRunStats(t *testing.T) {
+	path := saveCassette(t, "stats", 2, "running")
+
+	require.NoError(t, runStats([]string{filepath.Dir(path)}))
+	require.NoError(t, runStats([]string{strings.TrimSuffix(path, ".yaml")}))
+	require.ErrorContains(t, runStats([]string{t.TempDir()}), "stats expects at least one cassette or directory")
+	require.Error(t, runStats([]string{filepath.Join(t.TempDir(), "missing")}))
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"gopkg.in/dnaeon/go-vcr.v3/cassette"
+)
+
+func runShow(args []string) error {
+	if len(args) != 1 {
+		return errors.New("show expects a single cassette")
+	}
+
+	data, err := cassette.Load(strings.TrimSuffix(args[0], ".yaml"))
+	if err != nil {
+		return fmt.Errorf("error while reading file: %w", err)
+	}
+
+	for i := range len(data.Interactions) {
+		interaction := data.Interactions[i]
+
+		log.Println("--------------")
+		log.Printf("Interaction %d:\n", i+1)
+		log.Printf("  Request:\n")
+		log.Printf("    Method: %s\n", interaction.Request.Method)
+		log.Printf("    URL: %s\n", interaction.Request.URL)
+
+		if interaction.Request.Body != "" {
+			log.Printf("    Body: %s\n", interaction.Request.Body)
+		}
+
+		log.Printf("  Response:\n")
+		log.Printf("    Status: %s\n", interaction.Response.Status)
+		log.Printf("    Body: %s\n", interaction.Response.Body)
+
+		var m map[string]any
+
+		err := json.Unmarshal([]byte(interaction.Response.Body), &m)
+		if err != nil {
+			continue
+		}
+
+		if m["status"] != nil {
+			log.Println("++++++++++++++++")
+			log.Printf("status: %s\n", m["status"])
+			log.Println("++++++++++++++++")
+		}
+
+		log.Println("--------------")
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+func runStats(args []string) error {
+	paths, err := cassettePaths(args)
+	if err != nil {
+		return err
+	}
+
+	if len(paths) == 0 {
+		return errors.New("stats expects at least one cassette or directory")
+	}
+
+	stats := acctest.NewCassetteStats()
+
+	for _, path := range paths {
+		err := stats.AddCassette(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	stats.Print(os.Stdout)
+
+	return nil
+}
@@ -0,0 +1,17 @@
+# Use the instances backing the ready nodes of a cluster
+data "scaleway_k8s_nodes" "ready" {
+  cluster_id = scaleway_k8s_cluster.main.id
+  status     = "ready"
+}
+
+data "scaleway_instance_server" "nodes" {
+  for_each  = { for node in data.scaleway_k8s_nodes.ready.nodes : node.name => node }
+  server_id = each.value.instance_server_id
+}
+
+resource "scaleway_lb_backend" "main" {
+  lb_id            = scaleway_lb.main.id
+  forward_protocol = "tcp"
+  forward_port     = 30080
+  server_ips       = [for server in data.scaleway_instance_server.nodes : server.private_ips[0].address]
+}
@@ -0,0 +1,5 @@
+# List the nodes of a pool
+data "scaleway_k8s_nodes" "main" {
+  cluster_id = scaleway_k8s_cluster.main.id
+  pool_id    = scaleway_k8s_pool.main.id
+}
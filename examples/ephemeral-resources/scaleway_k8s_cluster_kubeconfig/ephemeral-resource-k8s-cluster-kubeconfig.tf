@@ -0,0 +1,24 @@
+### Configure the kubernetes provider with a short-lived IAM token without ever persisting credentials in the state file
+
+resource "scaleway_vpc_private_network" "main" {}
+
+resource "scaleway_k8s_cluster" "main" {
+  name                        = "main"
+  version                     = "1.32"
+  cni                         = "cilium"
+  private_network_id          = scaleway_vpc_private_network.main.id
+  delete_additional_resources = false
+  skip_kubeconfig             = true
+}
+
+ephemeral "scaleway_k8s_cluster_kubeconfig" "main" {
+  cluster_id    = scaleway_k8s_cluster.main.id
+  use_iam_token = true
+  token_ttl     = "30m"
+}
+
+provider "kubernetes" {
+  host                   = ephemeral.scaleway_k8s_cluster_kubeconfig.main.host
+  token                  = ephemeral.scaleway_k8s_cluster_kubeconfig.main.token
+  cluster_ca_certificate = base64decode(ephemeral.scaleway_k8s_cluster_kubeconfig.main.cluster_ca_certificate)
+}
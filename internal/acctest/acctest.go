@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/scaleway-sdk-go/vcr"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest/mockapi"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/env"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
@@ -122,20 +123,28 @@ func NewTestTools(t *testing.T) *TestTools {
 		cleanup    func()
 	)
 
-	if FolderUsesVCRv4(folder) {
+	metaConfig := &meta.Config{
+		ProviderSchema:   nil,
+		TerraformVersion: "terraform-tests",
+	}
+
+	switch {
+	case *MockAPI:
+		httpClient, cleanup = NewMockedClient(t)
+		// Resources created against the mock API all belong to the same fake project
+		metaConfig.ForceProjectID = mockapi.DefaultProjectID
+	case FolderUsesVCRv4(folder):
 		httpClient, cleanup, err = NewRecordedClient(t, folder, *UpdateCassettes)
-	} else {
+	default:
 		httpClient, cleanup, err = getHTTPRecoder(t, folder, *UpdateCassettes)
 	}
 
 	require.NoError(t, err)
 
+	metaConfig.HTTPClient = httpClient
+
 	// Create meta that will be passed in the provider config
-	m, err := meta.NewMeta(ctx, &meta.Config{
-		ProviderSchema:   nil,
-		TerraformVersion: "terraform-tests",
-		HTTPClient:       httpClient,
-	})
+	m, err := meta.NewMeta(ctx, metaConfig)
 	require.NoError(t, err)
 
 	if !*UpdateCassettes {
@@ -0,0 +1,57 @@
+package acctest
+
+import (
+	"flag"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest/mockapi"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/env"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAPI will run the tests against an in-process mock of the Scaleway API instead of cassettes
+var MockAPI = flag.Bool("mock-api", os.Getenv(env.MockAPI) == "true", "Run tests against the mock API")
+
+// NewMockedClient returns an HTTP client sending every request to a new mock API server.
+// The server only implements a subset of the APIs, other requests fail with a 501 error.
+func NewMockedClient(t *testing.T) (client *http.Client, cleanup func()) {
+	t.Helper()
+
+	server := mockapi.NewServer()
+
+	return &http.Client{
+		Transport: transport.NewRetryableTransportWithOptions(server.Client().Transport, transport.RetryableTransportOptions{
+			RetryWaitMax: scw.TimeDurationPtr(0),
+		}),
+	}, server.Close
+}
+
+// NewMockedMeta returns a meta whose client sends every request to a new mock API server.
+// It is meant to call the CRUD functions of a resource directly and check their behavior against the mock.
+func NewMockedMeta(t *testing.T) *meta.Meta {
+	t.Helper()
+
+	httpClient, cleanup := NewMockedClient(t)
+	t.Cleanup(cleanup)
+
+	m, err := meta.NewMeta(t.Context(), &meta.Config{
+		HTTPClient:       httpClient,
+		TerraformVersion: "terraform-tests",
+		ForceProjectID:   mockapi.DefaultProjectID,
+		ForceZone:        scw.ZoneFrPar1,
+		ForceAccessKey:   "SCWXXXXXXXXXXXXXXXXX",
+		ForceSecretKey:   "00000000-0000-0000-0000-000000000000",
+	})
+	require.NoError(t, err)
+
+	retryInterval := time.Duration(0)
+	transport.DefaultWaitRetryInterval = &retryInterval
+
+	return m
+}
@@ -0,0 +1,376 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const blockPrefix = "/block/v1alpha1/zones/{zone}"
+
+// volumePerfIops are the IOPS of the performance classes of the volumes
+var volumePerfIops = []uint32{5000, 15000}
+
+// blockServerResourceType is the product resource type of the references of the volumes attached to instance servers
+const blockServerResourceType = "instance_server"
+
+type blockAPI struct {
+	s *Server
+
+	volumes   *store[*block.Volume]
+	snapshots *store[*block.Snapshot]
+}
+
+func newBlockAPI(s *Server) *blockAPI {
+	return &blockAPI{
+		s:         s,
+		volumes:   newStore[*block.Volume](),
+		snapshots: newStore[*block.Snapshot](),
+	}
+}
+
+func (api *blockAPI) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+blockPrefix+"/volumes", api.listVolumes)
+	mux.HandleFunc("POST "+blockPrefix+"/volumes", api.createVolume)
+	mux.HandleFunc("GET "+blockPrefix+"/volumes/{volume_id}", api.getVolume)
+	mux.HandleFunc("DELETE "+blockPrefix+"/volumes/{volume_id}", api.deleteVolume)
+
+	mux.HandleFunc("GET "+blockPrefix+"/snapshots", api.listSnapshots)
+	mux.HandleFunc("POST "+blockPrefix+"/snapshots", api.createSnapshot)
+	mux.HandleFunc("GET "+blockPrefix+"/snapshots/{snapshot_id}", api.getSnapshot)
+	mux.HandleFunc("DELETE "+blockPrefix+"/snapshots/{snapshot_id}", api.deleteSnapshot)
+}
+
+//
+// Volumes
+//
+
+// newVolume adds an available volume to the store, it is used by the instance API to create the volumes of a server
+func (api *blockAPI) newVolume(zone scw.Zone, projectID string, name string, size scw.Size) *block.Volume {
+	volume := &block.Volume{
+		ID:         api.s.newID(),
+		Name:       name,
+		Type:       "sbs_5k",
+		Size:       size,
+		ProjectID:  projectID,
+		CreatedAt:  now(),
+		UpdatedAt:  now(),
+		References: []*block.Reference{},
+		Status:     block.VolumeStatusAvailable,
+		Tags:       []string{},
+		Zone:       zone,
+		Specs: &block.VolumeSpecifications{
+			PerfIops: scw.Uint32Ptr(5000),
+			Class:    block.StorageClassSbs,
+		},
+	}
+	api.volumes.put(volume.ID, volume)
+
+	return volume
+}
+
+// attachVolume references a volume as attached to an instance server
+func (api *blockAPI) attachVolume(volume *block.Volume, serverID string) {
+	volume.References = append(volume.References, &block.Reference{
+		ID:                  api.s.newID(),
+		ProductResourceType: blockServerResourceType,
+		ProductResourceID:   serverID,
+		CreatedAt:           now(),
+		Type:                block.ReferenceTypeExclusive,
+		Status:              block.ReferenceStatusAttached,
+	})
+	volume.Status = block.VolumeStatusInUse
+	volume.UpdatedAt = now()
+}
+
+// detachVolume removes the reference of an instance server from a volume
+func (api *blockAPI) detachVolume(volume *block.Volume, serverID string) {
+	references := []*block.Reference{}
+
+	for _, ref := range volume.References {
+		if ref.ProductResourceType != blockServerResourceType || ref.ProductResourceID != serverID {
+			references = append(references, ref)
+		}
+	}
+
+	volume.References = references
+	volume.UpdatedAt = now()
+	volume.LastDetachedAt = now()
+
+	if len(references) == 0 {
+		volume.Status = block.VolumeStatusAvailable
+	}
+}
+
+// detachServer removes the references of an instance server from every volume
+func (api *blockAPI) detachServer(serverID string) {
+	for _, volume := range api.volumes.list(func(v *block.Volume) bool { return volumeAttachedTo(v, serverID) }) {
+		api.detachVolume(volume, serverID)
+	}
+}
+
+func volumeAttachedTo(volume *block.Volume, serverID string) bool {
+	for _, ref := range volume.References {
+		if ref.ProductResourceType == blockServerResourceType && ref.ProductResourceID == serverID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (api *blockAPI) findVolume(w http.ResponseWriter, r *http.Request) (*block.Volume, bool) {
+	id := r.PathValue("volume_id")
+
+	volume, ok := api.volumes.get(id)
+	if !ok || volume.Zone != zoneOf(r) {
+		writeNotFound(w, "volume", id)
+
+		return nil, false
+	}
+
+	return volume, true
+}
+
+func (api *blockAPI) listVolumes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	zone := zoneOf(r)
+
+	volumes := api.volumes.list(func(volume *block.Volume) bool {
+		switch {
+		case volume.Zone != zone:
+			return false
+		case query.Get("project_id") != "" && volume.ProjectID != query.Get("project_id"):
+			return false
+		case query.Get("name") != "" && !strings.Contains(volume.Name, query.Get("name")):
+			return false
+		case query.Get("product_resource_id") != "" && !volumeAttachedTo(volume, query.Get("product_resource_id")):
+			return false
+		case !hasTags(volume.Tags, query["tags"]):
+			return false
+		}
+
+		return true
+	})
+
+	page, total := paginate(r, volumes)
+
+	writeJSON(w, http.StatusOK, &block.ListVolumesResponse{
+		TotalCount: uint64(total),
+		Volumes:    page,
+	})
+}
+
+func (api *blockAPI) createVolume(w http.ResponseWriter, r *http.Request) {
+	req := &block.CreateVolumeRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	var (
+		size           scw.Size
+		parentSnapshot *string
+	)
+
+	switch {
+	case req.FromEmpty != nil:
+		size = req.FromEmpty.Size
+	case req.FromSnapshot != nil:
+		snapshot, ok := api.snapshots.peek(req.FromSnapshot.SnapshotID)
+		if !ok || snapshot.Zone != zoneOf(r) {
+			writeNotFound(w, "snapshot", req.FromSnapshot.SnapshotID)
+
+			return
+		}
+
+		size = snapshot.Size
+		if req.FromSnapshot.Size != nil {
+			size = *req.FromSnapshot.Size
+		}
+
+		parentSnapshot = &snapshot.ID
+	default:
+		writeError(w, http.StatusBadRequest, "invalid_arguments", "one of from_empty or from_snapshot must be set", nil)
+
+		return
+	}
+
+	if req.PerfIops != nil && !slices.Contains(volumePerfIops, *req.PerfIops) {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", fmt.Sprintf("perf_iops must be one of %v", volumePerfIops), nil)
+
+		return
+	}
+
+	volume := api.newVolume(zoneOf(r), projectOrDefault(&req.ProjectID), req.Name, size)
+	volume.ParentSnapshotID = parentSnapshot
+
+	if req.PerfIops != nil {
+		volume.Specs.PerfIops = req.PerfIops
+	}
+
+	if req.Tags != nil {
+		volume.Tags = req.Tags
+	}
+
+	volume.Status = block.VolumeStatusCreating
+	api.volumes.schedule(volume.ID, func(volume *block.Volume) bool {
+		volume.Status = block.VolumeStatusAvailable
+
+		return true
+	})
+
+	writeJSON(w, http.StatusOK, volume)
+}
+
+func (api *blockAPI) getVolume(w http.ResponseWriter, r *http.Request) {
+	volume, ok := api.findVolume(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, volume)
+}
+
+func (api *blockAPI) deleteVolume(w http.ResponseWriter, r *http.Request) {
+	volume, ok := api.findVolume(w, r)
+	if !ok {
+		return
+	}
+
+	if len(volume.References) > 0 {
+		writeError(w, http.StatusPreconditionFailed, "precondition_failed", "a volume in use cannot be deleted", map[string]any{
+			"precondition": "resource_still_in_use",
+		})
+
+		return
+	}
+
+	api.volumes.delete(volume.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//
+// Snapshots
+//
+
+func (api *blockAPI) findSnapshot(w http.ResponseWriter, r *http.Request) (*block.Snapshot, bool) {
+	id := r.PathValue("snapshot_id")
+
+	snapshot, ok := api.snapshots.get(id)
+	if !ok || snapshot.Zone != zoneOf(r) {
+		writeNotFound(w, "snapshot", id)
+
+		return nil, false
+	}
+
+	return snapshot, true
+}
+
+func (api *blockAPI) listSnapshots(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	zone := zoneOf(r)
+
+	snapshots := api.snapshots.list(func(snapshot *block.Snapshot) bool {
+		switch {
+		case snapshot.Zone != zone:
+			return false
+		case query.Get("project_id") != "" && snapshot.ProjectID != query.Get("project_id"):
+			return false
+		case query.Get("volume_id") != "" && (snapshot.ParentVolume == nil || snapshot.ParentVolume.ID != query.Get("volume_id")):
+			return false
+		case query.Get("name") != "" && !strings.Contains(snapshot.Name, query.Get("name")):
+			return false
+		case !hasTags(snapshot.Tags, query["tags"]):
+			return false
+		}
+
+		return true
+	})
+
+	page, total := paginate(r, snapshots)
+
+	writeJSON(w, http.StatusOK, &block.ListSnapshotsResponse{
+		TotalCount: uint64(total),
+		Snapshots:  page,
+	})
+}
+
+func (api *blockAPI) createSnapshot(w http.ResponseWriter, r *http.Request) {
+	req := &block.CreateSnapshotRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	volume, ok := api.volumes.peek(req.VolumeID)
+	if !ok || volume.Zone != zoneOf(r) {
+		writeNotFound(w, "volume", req.VolumeID)
+
+		return
+	}
+
+	snapshot := &block.Snapshot{
+		ID:   api.s.newID(),
+		Name: req.Name,
+		ParentVolume: &block.SnapshotParentVolume{
+			ID:     volume.ID,
+			Name:   volume.Name,
+			Type:   volume.Type,
+			Status: volume.Status,
+		},
+		Size:       volume.Size,
+		ProjectID:  projectOrDefault(&req.ProjectID),
+		CreatedAt:  now(),
+		UpdatedAt:  now(),
+		References: []*block.Reference{},
+		Status:     block.SnapshotStatusCreating,
+		Tags:       req.Tags,
+		Zone:       volume.Zone,
+		Class:      block.StorageClassSbs,
+	}
+
+	if snapshot.Tags == nil {
+		snapshot.Tags = []string{}
+	}
+
+	api.snapshots.put(snapshot.ID, snapshot)
+	api.snapshots.schedule(snapshot.ID, func(snapshot *block.Snapshot) bool {
+		snapshot.Status = block.SnapshotStatusAvailable
+
+		return true
+	})
+
+	writeJSON(w, http.StatusOK, snapshot)
+}
+
+func (api *blockAPI) getSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, ok := api.findSnapshot(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, snapshot)
+}
+
+func (api *blockAPI) deleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, ok := api.findSnapshot(w, r)
+	if !ok {
+		return
+	}
+
+	if api.s.instance.snapshotInUse(snapshot.ID) {
+		writeError(w, http.StatusPreconditionFailed, "precondition_failed", "a snapshot used by an image cannot be deleted", map[string]any{
+			"precondition": "resource_still_in_use",
+		})
+
+		return
+	}
+
+	api.snapshots.delete(snapshot.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
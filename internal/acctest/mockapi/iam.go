@@ -0,0 +1,146 @@
+package mockapi
+
+import (
+	"net/http"
+	"strings"
+
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"golang.org/x/crypto/ssh"
+)
+
+const iamPrefix = "/iam/v1alpha1"
+
+type iamAPI struct {
+	s *Server
+
+	sshKeys *store[*iam.SSHKey]
+}
+
+func newIAMAPI(s *Server) *iamAPI {
+	return &iamAPI{
+		s:       s,
+		sshKeys: newStore[*iam.SSHKey](),
+	}
+}
+
+func (api *iamAPI) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+iamPrefix+"/ssh-keys", api.listSSHKeys)
+	mux.HandleFunc("POST "+iamPrefix+"/ssh-keys", api.createSSHKey)
+	mux.HandleFunc("GET "+iamPrefix+"/ssh-keys/{ssh_key_id}", api.getSSHKey)
+	mux.HandleFunc("PATCH "+iamPrefix+"/ssh-keys/{ssh_key_id}", api.updateSSHKey)
+	mux.HandleFunc("DELETE "+iamPrefix+"/ssh-keys/{ssh_key_id}", api.deleteSSHKey)
+}
+
+func (api *iamAPI) findSSHKey(w http.ResponseWriter, r *http.Request) (*iam.SSHKey, bool) {
+	id := r.PathValue("ssh_key_id")
+
+	key, ok := api.sshKeys.get(id)
+	if !ok {
+		writeNotFound(w, "ssh_key", id)
+
+		return nil, false
+	}
+
+	return key, true
+}
+
+func (api *iamAPI) listSSHKeys(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	keys := api.sshKeys.list(func(key *iam.SSHKey) bool {
+		switch {
+		case query.Get("name") != "" && !strings.Contains(key.Name, query.Get("name")):
+			return false
+		case query.Get("project_id") != "" && key.ProjectID != query.Get("project_id"):
+			return false
+		case query.Get("disabled") == "true" && !key.Disabled:
+			return false
+		}
+
+		return true
+	})
+
+	page, total := paginate(r, keys)
+
+	writeJSON(w, http.StatusOK, &iam.ListSSHKeysResponse{
+		SSHKeys:    page,
+		TotalCount: uint32(total),
+	})
+}
+
+func (api *iamAPI) createSSHKey(w http.ResponseWriter, r *http.Request) {
+	req := &iam.CreateSSHKeyRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	publicKey, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(req.PublicKey))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", "invalid public key: "+err.Error(), nil)
+
+		return
+	}
+
+	fingerprint := "MD5:" + ssh.FingerprintLegacyMD5(publicKey)
+	if comment != "" {
+		fingerprint += " " + comment
+	}
+
+	key := &iam.SSHKey{
+		ID:             api.s.newID(),
+		Name:           req.Name,
+		PublicKey:      req.PublicKey,
+		Fingerprint:    fingerprint + " (" + publicKey.Type() + ")",
+		CreatedAt:      now(),
+		UpdatedAt:      now(),
+		OrganizationID: DefaultOrganizationID,
+		ProjectID:      projectOrDefault(&req.ProjectID),
+	}
+	api.sshKeys.put(key.ID, key)
+
+	writeJSON(w, http.StatusOK, key)
+}
+
+func (api *iamAPI) getSSHKey(w http.ResponseWriter, r *http.Request) {
+	key, ok := api.findSSHKey(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, key)
+}
+
+func (api *iamAPI) updateSSHKey(w http.ResponseWriter, r *http.Request) {
+	key, ok := api.findSSHKey(w, r)
+	if !ok {
+		return
+	}
+
+	req := &iam.UpdateSSHKeyRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if req.Name != nil {
+		key.Name = *req.Name
+	}
+
+	if req.Disabled != nil {
+		key.Disabled = *req.Disabled
+	}
+
+	key.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, key)
+}
+
+func (api *iamAPI) deleteSSHKey(w http.ResponseWriter, r *http.Request) {
+	key, ok := api.findSSHKey(w, r)
+	if !ok {
+		return
+	}
+
+	api.sshKeys.delete(key.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
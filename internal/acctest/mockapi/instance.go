@@ -0,0 +1,2157 @@
+package mockapi
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const instancePrefix = "/instance/v1/zones/{zone}"
+
+// imageQuota is the number of images a project can own, it is low so that tests can reach it
+const imageQuota = 1
+
+// placementGroupHypervisors is the number of hypervisors the servers of a max_availability placement group can be spread on,
+// the policy is not respected for the servers beyond it
+const placementGroupHypervisors = 2
+
+// metadataUserDataRegexp matches the user data URLs of the metadata API reachable from a server
+var metadataUserDataRegexp = regexp.MustCompile(`http://169\.254\.42\.42/user_data/([a-zA-Z0-9_-]+)`)
+
+// serverTypes is the catalog of commercial types accepted by the mock instance API
+var serverTypes = map[string]*instance.ServerType{
+	"DEV1-S":     newServerType(2, 2, 20),
+	"DEV1-M":     newServerType(3, 4, 40),
+	"DEV1-L":     newServerType(4, 8, 80),
+	"PLAY2-PICO": newServerType(1, 2, 0),
+	"PLAY2-NANO": newServerType(2, 4, 0),
+	"PRO2-XXS":   newServerType(2, 8, 0),
+	"PRO2-XS":    newServerType(4, 16, 0),
+}
+
+func newServerType(ncpus uint32, ramGB uint64, maxLocalVolumeGB uint64) *instance.ServerType {
+	return &instance.ServerType{
+		Ncpus: ncpus,
+		RAM:   ramGB * uint64(scw.GB),
+		Arch:  instance.ArchX86_64,
+		PerVolumeConstraint: &instance.ServerTypeVolumeConstraintsByType{
+			LSSD: &instance.ServerTypeVolumeConstraintSizes{
+				MinSize: 0,
+				MaxSize: scw.Size(maxLocalVolumeGB) * scw.GB,
+			},
+		},
+		VolumesConstraint: &instance.ServerTypeVolumeConstraintSizes{
+			MinSize: 0,
+			MaxSize: scw.Size(maxLocalVolumeGB) * scw.GB,
+		},
+		Network: &instance.ServerTypeNetwork{
+			IPv6Support: true,
+		},
+	}
+}
+
+type instanceAPI struct {
+	s *Server
+
+	servers     *store[*instance.Server]
+	volumes     *store[*instance.Volume]
+	ips         *store[*instance.IP]
+	privateNICs *store[*instance.PrivateNIC]
+	snapshots   *store[*instance.Snapshot]
+	images      *store[*instance.Image]
+	groups      *store[*instance.SecurityGroup]
+	placements  *store[*instance.PlacementGroup]
+	rules       map[string][]*instance.SecurityGroupRule
+	userData    map[string]map[string][]byte
+	dynamicIPs  map[string]*instance.ServerIP
+	ipCount     int
+}
+
+func newInstanceAPI(s *Server) *instanceAPI {
+	return &instanceAPI{
+		s:           s,
+		servers:     newStore[*instance.Server](),
+		volumes:     newStore[*instance.Volume](),
+		ips:         newStore[*instance.IP](),
+		privateNICs: newStore[*instance.PrivateNIC](),
+		snapshots:   newStore[*instance.Snapshot](),
+		images:      newStore[*instance.Image](),
+		groups:      newStore[*instance.SecurityGroup](),
+		placements:  newStore[*instance.PlacementGroup](),
+		rules:       map[string][]*instance.SecurityGroupRule{},
+		userData:    map[string]map[string][]byte{},
+		dynamicIPs:  map[string]*instance.ServerIP{},
+	}
+}
+
+func (api *instanceAPI) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+instancePrefix+"/products/servers", api.listServerTypes)
+	mux.HandleFunc("GET "+instancePrefix+"/products/servers/availability", api.getServerTypesAvailability)
+
+	mux.HandleFunc("GET "+instancePrefix+"/servers", api.listServers)
+	mux.HandleFunc("POST "+instancePrefix+"/servers", api.createServer)
+	mux.HandleFunc("GET "+instancePrefix+"/servers/{server_id}", api.getServer)
+	mux.HandleFunc("PATCH "+instancePrefix+"/servers/{server_id}", api.updateServer)
+	mux.HandleFunc("DELETE "+instancePrefix+"/servers/{server_id}", api.deleteServer)
+	mux.HandleFunc("POST "+instancePrefix+"/servers/{server_id}/action", api.serverAction)
+	mux.HandleFunc("POST "+instancePrefix+"/servers/{server_id}/attach-volume", api.attachServerVolume)
+	mux.HandleFunc("POST "+instancePrefix+"/servers/{server_id}/detach-volume", api.detachServerVolume)
+
+	mux.HandleFunc("GET "+instancePrefix+"/servers/{server_id}/user_data", api.listServerUserData)
+	mux.HandleFunc("GET "+instancePrefix+"/servers/{server_id}/user_data/{key}", api.getServerUserData)
+	mux.HandleFunc("PATCH "+instancePrefix+"/servers/{server_id}/user_data/{key}", api.setServerUserData)
+	mux.HandleFunc("DELETE "+instancePrefix+"/servers/{server_id}/user_data/{key}", api.deleteServerUserData)
+
+	mux.HandleFunc("GET "+instancePrefix+"/servers/{server_id}/private_nics", api.listPrivateNICs)
+	mux.HandleFunc("POST "+instancePrefix+"/servers/{server_id}/private_nics", api.createPrivateNIC)
+	mux.HandleFunc("GET "+instancePrefix+"/servers/{server_id}/private_nics/{nic_id}", api.getPrivateNIC)
+	mux.HandleFunc("DELETE "+instancePrefix+"/servers/{server_id}/private_nics/{nic_id}", api.deletePrivateNIC)
+
+	mux.HandleFunc("GET "+instancePrefix+"/volumes", api.listVolumes)
+	mux.HandleFunc("POST "+instancePrefix+"/volumes", api.createVolume)
+	mux.HandleFunc("GET "+instancePrefix+"/volumes/{volume_id}", api.getVolume)
+	mux.HandleFunc("PATCH "+instancePrefix+"/volumes/{volume_id}", api.updateVolume)
+	mux.HandleFunc("DELETE "+instancePrefix+"/volumes/{volume_id}", api.deleteVolume)
+
+	mux.HandleFunc("POST "+instancePrefix+"/snapshots", api.createSnapshot)
+	mux.HandleFunc("GET "+instancePrefix+"/snapshots/{snapshot_id}", api.getSnapshot)
+	mux.HandleFunc("DELETE "+instancePrefix+"/snapshots/{snapshot_id}", api.deleteSnapshot)
+
+	mux.HandleFunc("POST "+instancePrefix+"/block-migration/plan", api.planBlockMigration)
+	mux.HandleFunc("POST "+instancePrefix+"/block-migration/apply", api.applyBlockMigration)
+
+	mux.HandleFunc("POST "+instancePrefix+"/images", api.createImage)
+	mux.HandleFunc("GET "+instancePrefix+"/images/{image_id}", api.getImage)
+	mux.HandleFunc("DELETE "+instancePrefix+"/images/{image_id}", api.deleteImage)
+
+	mux.HandleFunc("GET "+instancePrefix+"/ips", api.listIPs)
+	mux.HandleFunc("POST "+instancePrefix+"/ips", api.createIP)
+	mux.HandleFunc("GET "+instancePrefix+"/ips/{ip}", api.getIP)
+	mux.HandleFunc("PATCH "+instancePrefix+"/ips/{ip}", api.updateIP)
+	mux.HandleFunc("DELETE "+instancePrefix+"/ips/{ip}", api.deleteIP)
+
+	mux.HandleFunc("POST "+instancePrefix+"/security_groups", api.createSecurityGroup)
+	mux.HandleFunc("GET "+instancePrefix+"/security_groups/{security_group_id}", api.getSecurityGroup)
+	mux.HandleFunc("DELETE "+instancePrefix+"/security_groups/{security_group_id}", api.deleteSecurityGroup)
+
+	mux.HandleFunc("GET "+instancePrefix+"/security_groups/{security_group_id}/rules", api.listSecurityGroupRules)
+	mux.HandleFunc("POST "+instancePrefix+"/security_groups/{security_group_id}/rules", api.createSecurityGroupRule)
+	mux.HandleFunc("GET "+instancePrefix+"/security_groups/{security_group_id}/rules/{rule_id}", api.getSecurityGroupRule)
+	mux.HandleFunc("PATCH "+instancePrefix+"/security_groups/{security_group_id}/rules/{rule_id}", api.updateSecurityGroupRule)
+	mux.HandleFunc("DELETE "+instancePrefix+"/security_groups/{security_group_id}/rules/{rule_id}", api.deleteSecurityGroupRule)
+
+	mux.HandleFunc("POST "+instancePrefix+"/placement_groups", api.createPlacementGroup)
+	mux.HandleFunc("GET "+instancePrefix+"/placement_groups/{placement_group_id}", api.getPlacementGroup)
+	mux.HandleFunc("DELETE "+instancePrefix+"/placement_groups/{placement_group_id}", api.deletePlacementGroup)
+	mux.HandleFunc("GET "+instancePrefix+"/placement_groups/{placement_group_id}/servers", api.getPlacementGroupServers)
+	mux.HandleFunc("PUT "+instancePrefix+"/placement_groups/{placement_group_id}/servers", api.setPlacementGroupServers)
+}
+
+// writeInstanceList writes a list response, the instance API also returns the total count in a header
+func writeInstanceList(w http.ResponseWriter, total int, body any) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	writeJSON(w, http.StatusOK, body)
+}
+
+//
+// Products
+//
+
+func (api *instanceAPI) listServerTypes(w http.ResponseWriter, _ *http.Request) {
+	writeInstanceList(w, len(serverTypes), &instance.ListServersTypesResponse{
+		TotalCount: uint32(len(serverTypes)),
+		Servers:    serverTypes,
+	})
+}
+
+func (api *instanceAPI) getServerTypesAvailability(w http.ResponseWriter, _ *http.Request) {
+	availability := make(map[string]*instance.GetServerTypesAvailabilityResponseAvailability, len(serverTypes))
+	for name := range serverTypes {
+		availability[name] = &instance.GetServerTypesAvailabilityResponseAvailability{
+			Availability: instance.ServerTypesAvailabilityAvailable,
+		}
+	}
+
+	writeInstanceList(w, len(availability), &instance.GetServerTypesAvailabilityResponse{
+		TotalCount: uint32(len(availability)),
+		Servers:    availability,
+	})
+}
+
+//
+// Servers
+//
+
+func (api *instanceAPI) findServer(w http.ResponseWriter, r *http.Request) (*instance.Server, bool) {
+	id := r.PathValue("server_id")
+
+	server, ok := api.servers.get(id)
+	if !ok || server.Zone != zoneOf(r) {
+		writeNotFound(w, "instance_server", id)
+
+		return nil, false
+	}
+
+	return server, true
+}
+
+// renderServer refreshes the fields of a server that depend on other resources
+func (api *instanceAPI) renderServer(server *instance.Server) *instance.Server {
+	server.PublicIPs = []*instance.ServerIP{}
+
+	for _, ip := range api.ips.list(func(ip *instance.IP) bool { return ip.Server != nil && ip.Server.ID == server.ID }) {
+		serverIP := &instance.ServerIP{
+			ID:               ip.ID,
+			Address:          ip.Address,
+			Family:           instance.ServerIPIPFamilyInet,
+			ProvisioningMode: instance.ServerIPProvisioningModeDHCP,
+			Netmask:          "32",
+			Gateway:          net.ParseIP("62.210.0.1"),
+			Tags:             ip.Tags,
+			IpamID:           ip.IpamID,
+			State:            instance.ServerIPStateAttached,
+		}
+
+		if ip.Type == instance.IPTypeRoutedIPv6 {
+			serverIP.Family = instance.ServerIPIPFamilyInet6
+			serverIP.ProvisioningMode = instance.ServerIPProvisioningModeSlaac
+			serverIP.Netmask = "64"
+			serverIP.Gateway = net.ParseIP("fe80::1")
+		}
+
+		server.PublicIPs = append(server.PublicIPs, serverIP)
+	}
+
+	if server.DynamicIPRequired && len(server.PublicIPs) == 0 {
+		if api.dynamicIPs[server.ID] == nil {
+			api.dynamicIPs[server.ID] = &instance.ServerIP{
+				ID:               api.s.newID(),
+				Address:          api.nextIPv4(),
+				Family:           instance.ServerIPIPFamilyInet,
+				ProvisioningMode: instance.ServerIPProvisioningModeDHCP,
+				Netmask:          "32",
+				Gateway:          net.ParseIP("62.210.0.1"),
+				Dynamic:          true,
+				Tags:             []string{},
+				State:            instance.ServerIPStateAttached,
+			}
+		}
+
+		server.PublicIPs = append(server.PublicIPs, api.dynamicIPs[server.ID])
+	} else {
+		delete(api.dynamicIPs, server.ID)
+	}
+
+	server.PublicIP = nil
+	if len(server.PublicIPs) > 0 {
+		server.PublicIP = server.PublicIPs[0]
+	}
+
+	server.PrivateNics = api.privateNICs.list(func(nic *instance.PrivateNIC) bool { return nic.ServerID == server.ID })
+	if server.PrivateNics == nil {
+		server.PrivateNics = []*instance.PrivateNIC{}
+	}
+
+	server.AllowedActions = allowedServerActions(server.State)
+
+	return server
+}
+
+func allowedServerActions(state instance.ServerState) []instance.ServerAction {
+	switch state {
+	case instance.ServerStateRunning:
+		return []instance.ServerAction{instance.ServerActionPoweroff, instance.ServerActionStopInPlace, instance.ServerActionReboot, instance.ServerActionTerminate, instance.ServerActionBackup}
+	case instance.ServerStateStopped, instance.ServerStateStoppedInPlace:
+		return []instance.ServerAction{instance.ServerActionPoweron, instance.ServerActionTerminate, instance.ServerActionBackup}
+	default:
+		return []instance.ServerAction{}
+	}
+}
+
+func (api *instanceAPI) listServers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	zone := zoneOf(r)
+
+	servers := api.servers.list(func(server *instance.Server) bool {
+		switch {
+		case server.Zone != zone:
+			return false
+		case query.Get("name") != "" && !strings.Contains(server.Name, query.Get("name")):
+			return false
+		case query.Get("project") != "" && server.Project != query.Get("project"):
+			return false
+		case query.Get("commercial_type") != "" && server.CommercialType != query.Get("commercial_type"):
+			return false
+		case query.Get("state") != "" && string(server.State) != query.Get("state"):
+			return false
+		case query.Get("tags") != "" && !hasTags(server.Tags, strings.Split(query.Get("tags"), ",")):
+			return false
+		}
+
+		return true
+	})
+
+	page, total := paginate(r, servers)
+	for _, server := range page {
+		api.renderServer(server)
+	}
+
+	writeInstanceList(w, total, &instance.ListServersResponse{
+		TotalCount: uint32(total),
+		Servers:    page,
+	})
+}
+
+func (api *instanceAPI) createServer(w http.ResponseWriter, r *http.Request) {
+	req := &instance.CreateServerRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	zone := zoneOf(r)
+
+	if _, ok := serverTypes[req.CommercialType]; !ok {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", fmt.Sprintf("commercial type %q is not available", req.CommercialType), nil)
+
+		return
+	}
+
+	server := &instance.Server{
+		ID:                api.s.newID(),
+		Name:              req.Name,
+		Organization:      DefaultOrganizationID,
+		Project:           projectOrDefault(req.Project),
+		Tags:              req.Tags,
+		CommercialType:    req.CommercialType,
+		CreationDate:      now(),
+		ModificationDate:  now(),
+		DynamicIPRequired: req.DynamicIPRequired == nil || *req.DynamicIPRequired,
+		RoutedIPEnabled:   scw.BoolPtr(true),
+		EnableIPv6:        scw.BoolPtr(req.EnableIPv6 != nil && *req.EnableIPv6),
+		Hostname:          req.Name,
+		Protected:         req.Protected,
+		MacAddress:        "de:00:00:00:00:01",
+		State:             instance.ServerStateStopped,
+		BootType:          instance.BootTypeLocal,
+		Volumes:           map[string]*instance.VolumeServer{},
+		Maintenances:      []*instance.ServerMaintenance{},
+		Arch:              instance.ArchX86_64,
+		Zone:              zone,
+		Filesystems:       []*instance.ServerFilesystem{},
+		SecurityGroup: &instance.SecurityGroupSummary{
+			ID:   api.s.newID(),
+			Name: "Default security group",
+		},
+	}
+
+	if server.Tags == nil {
+		server.Tags = []string{}
+	}
+
+	if req.BootType != nil {
+		server.BootType = *req.BootType
+	}
+
+	if req.SecurityGroup != nil {
+		server.SecurityGroup.ID = *req.SecurityGroup
+		server.SecurityGroup.Name = *req.SecurityGroup
+	}
+
+	if req.PlacementGroup != nil {
+		server.PlacementGroup = &instance.PlacementGroup{ID: *req.PlacementGroup, Zone: zone}
+	}
+
+	if req.Image != nil && *req.Image != "" {
+		server.Image = &instance.Image{
+			ID:           *req.Image,
+			Name:         *req.Image,
+			Arch:         instance.ArchX86_64,
+			Organization: DefaultOrganizationID,
+			Project:      DefaultProjectID,
+			Public:       true,
+			State:        instance.ImageStateAvailable,
+			Zone:         zone,
+			Tags:         []string{},
+		}
+
+		if localImage, ok := api.s.marketplace.findLocalImageByID(*req.Image); ok {
+			server.Image.Name = localImage.Label
+		}
+	}
+
+	volumes := req.Volumes
+	if len(volumes) == 0 && server.Image != nil {
+		volumes = map[string]*instance.VolumeServerTemplate{
+			"0": {VolumeType: instance.VolumeVolumeTypeLSSD, Size: scw.SizePtr(20 * scw.GB)},
+		}
+	}
+
+	err := api.attachServerVolumes(server, volumes)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", err.Error(), nil)
+
+		return
+	}
+
+	ipIDs := []string(nil)
+	if req.PublicIPs != nil {
+		ipIDs = *req.PublicIPs
+	}
+
+	if req.PublicIP != nil {
+		ipIDs = append(ipIDs, *req.PublicIP)
+	}
+
+	if !api.attachServerIPs(w, server, ipIDs) {
+		return
+	}
+
+	api.servers.put(server.ID, server)
+
+	writeJSON(w, http.StatusCreated, &instance.CreateServerResponse{Server: api.renderServer(server)})
+}
+
+// attachServerVolumes replaces the volumes of a server, creating the volumes that do not exist yet
+func (api *instanceAPI) attachServerVolumes(server *instance.Server, templates map[string]*instance.VolumeServerTemplate) error {
+	for _, volume := range api.volumes.list(func(v *instance.Volume) bool { return v.Server != nil && v.Server.ID == server.ID }) {
+		volume.Server = nil
+	}
+
+	api.s.block.detachServer(server.ID)
+
+	server.Volumes = map[string]*instance.VolumeServer{}
+
+	for index, template := range templates {
+		var volume *instance.Volume
+
+		switch {
+		case template.ID != nil:
+			existing, ok := api.volumes.peek(*template.ID)
+			if !ok {
+				blockVolume, err := api.attachBlockVolume(server, *template.ID)
+				if err != nil {
+					return err
+				}
+
+				server.Volumes[index] = blockVolume
+				server.Volumes[index].Boot = template.Boot != nil && *template.Boot
+
+				continue
+			}
+
+			if existing.Server != nil && existing.Server.ID != server.ID {
+				return fmt.Errorf("volume %s is already attached to server %s", existing.ID, existing.Server.ID)
+			}
+
+			volume = existing
+		case template.VolumeType == instance.VolumeVolumeTypeSbsVolume:
+			size := scw.Size(20 * scw.GB)
+			if template.Size != nil {
+				size = *template.Size
+			}
+
+			name := server.Name + "-vol-" + index
+			if template.Name != nil {
+				name = *template.Name
+			}
+
+			blockVolume := api.s.block.newVolume(server.Zone, server.Project, name, size)
+			api.s.block.attachVolume(blockVolume, server.ID)
+
+			server.Volumes[index] = blockVolumeServer(server, blockVolume)
+			server.Volumes[index].Boot = template.Boot != nil && *template.Boot
+
+			continue
+		default:
+			volumeType := template.VolumeType
+			if volumeType == "" {
+				volumeType = instance.VolumeVolumeTypeLSSD
+			}
+
+			size := scw.Size(20 * scw.GB)
+			if template.Size != nil {
+				size = *template.Size
+			}
+
+			name := server.Name + "-vol-" + index
+			if template.Name != nil {
+				name = *template.Name
+			}
+
+			volume = &instance.Volume{
+				ID:               api.s.newID(),
+				Name:             name,
+				Size:             size,
+				VolumeType:       volumeType,
+				CreationDate:     now(),
+				ModificationDate: now(),
+				Organization:     DefaultOrganizationID,
+				Project:          server.Project,
+				Tags:             []string{},
+				State:            instance.VolumeStateAvailable,
+				Zone:             server.Zone,
+			}
+			api.volumes.put(volume.ID, volume)
+		}
+
+		server.Volumes[index] = attachVolume(server, volume, template.Boot != nil && *template.Boot)
+	}
+
+	return nil
+}
+
+// attachVolume marks an instance volume as attached to a server and returns its server volume
+func attachVolume(server *instance.Server, volume *instance.Volume, boot bool) *instance.VolumeServer {
+	volume.Server = &instance.ServerSummary{ID: server.ID, Name: server.Name}
+
+	return &instance.VolumeServer{
+		ID:               volume.ID,
+		Name:             &volume.Name,
+		Organization:     &volume.Organization,
+		Project:          &volume.Project,
+		Server:           volume.Server,
+		Size:             &volume.Size,
+		VolumeType:       instance.VolumeServerVolumeType(volume.VolumeType),
+		CreationDate:     volume.CreationDate,
+		ModificationDate: volume.ModificationDate,
+		State:            volumeServerStatePtr(instance.VolumeServerState(volume.State)),
+		Boot:             boot,
+		Zone:             volume.Zone,
+	}
+}
+
+// attachBlockVolume attaches a volume of the block API to a server and returns its server volume
+func (api *instanceAPI) attachBlockVolume(server *instance.Server, volumeID string) (*instance.VolumeServer, error) {
+	volume, ok := api.s.block.volumes.peek(volumeID)
+	if !ok || volume.Zone != server.Zone {
+		return nil, fmt.Errorf("volume %s is not found", volumeID)
+	}
+
+	for _, ref := range volume.References {
+		if ref.ProductResourceType == blockServerResourceType && ref.ProductResourceID != server.ID {
+			return nil, fmt.Errorf("volume %s is already attached to server %s", volume.ID, ref.ProductResourceID)
+		}
+	}
+
+	if !volumeAttachedTo(volume, server.ID) {
+		api.s.block.attachVolume(volume, server.ID)
+	}
+
+	return blockVolumeServer(server, volume), nil
+}
+
+func blockVolumeServer(server *instance.Server, volume *block.Volume) *instance.VolumeServer {
+	return &instance.VolumeServer{
+		ID:         volume.ID,
+		Name:       &volume.Name,
+		Size:       &volume.Size,
+		VolumeType: instance.VolumeServerVolumeTypeSbsVolume,
+		Zone:       server.Zone,
+		State:      volumeServerStatePtr(instance.VolumeServerStateAvailable),
+	}
+}
+
+func volumeServerStatePtr(state instance.VolumeServerState) *instance.VolumeServerState {
+	return &state
+}
+
+// attachServerIPs replaces the flexible IPs attached to a server
+func (api *instanceAPI) attachServerIPs(w http.ResponseWriter, server *instance.Server, ipIDs []string) bool {
+	ips := make([]*instance.IP, 0, len(ipIDs))
+
+	for _, id := range ipIDs {
+		ip, ok := api.ips.peek(id)
+		if !ok || ip.Zone != server.Zone {
+			writeNotFound(w, "instance_ip", id)
+
+			return false
+		}
+
+		ips = append(ips, ip)
+	}
+
+	for _, ip := range api.ips.list(func(ip *instance.IP) bool { return ip.Server != nil && ip.Server.ID == server.ID }) {
+		ip.Server = nil
+		ip.State = instance.IPStateDetached
+	}
+
+	for _, ip := range ips {
+		ip.Server = &instance.ServerSummary{ID: server.ID, Name: server.Name}
+		ip.State = instance.IPStateAttached
+	}
+
+	return true
+}
+
+func (api *instanceAPI) getServer(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &instance.GetServerResponse{Server: api.renderServer(server)})
+}
+
+func (api *instanceAPI) updateServer(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	req := &instance.UpdateServerRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if req.CommercialType != nil && *req.CommercialType != server.CommercialType {
+		if _, ok := serverTypes[*req.CommercialType]; !ok {
+			writeError(w, http.StatusBadRequest, "invalid_arguments", fmt.Sprintf("commercial type %q is not available", *req.CommercialType), nil)
+
+			return
+		}
+
+		if server.State != instance.ServerStateStopped {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", "the server must be stopped to change its commercial type", nil)
+
+			return
+		}
+
+		server.CommercialType = *req.CommercialType
+	}
+
+	if req.Volumes != nil {
+		err := api.attachServerVolumes(server, *req.Volumes)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_arguments", err.Error(), nil)
+
+			return
+		}
+	}
+
+	if req.PublicIPs != nil && !api.attachServerIPs(w, server, *req.PublicIPs) {
+		return
+	}
+
+	if req.Name != nil {
+		server.Name = *req.Name
+		server.Hostname = *req.Name
+	}
+
+	if req.BootType != nil {
+		server.BootType = *req.BootType
+	}
+
+	if req.Tags != nil {
+		server.Tags = *req.Tags
+	}
+
+	if req.DynamicIPRequired != nil {
+		server.DynamicIPRequired = *req.DynamicIPRequired
+	}
+
+	if req.RoutedIPEnabled != nil {
+		server.RoutedIPEnabled = req.RoutedIPEnabled
+	}
+
+	if req.EnableIPv6 != nil {
+		server.EnableIPv6 = req.EnableIPv6
+	}
+
+	if req.Protected != nil {
+		server.Protected = *req.Protected
+	}
+
+	if req.SecurityGroup != nil {
+		server.SecurityGroup = &instance.SecurityGroupSummary{ID: req.SecurityGroup.ID, Name: req.SecurityGroup.Name}
+	}
+
+	if req.PlacementGroup != nil {
+		server.PlacementGroup = nil
+		if !req.PlacementGroup.Null {
+			server.PlacementGroup = &instance.PlacementGroup{ID: req.PlacementGroup.Value, Zone: server.Zone}
+		}
+	}
+
+	server.ModificationDate = now()
+
+	writeJSON(w, http.StatusOK, &instance.UpdateServerResponse{Server: api.renderServer(server)})
+}
+
+func (api *instanceAPI) deleteServer(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	if server.State != instance.ServerStateStopped && server.State != instance.ServerStateStoppedInPlace {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "the server must be stopped to be deleted", nil)
+
+		return
+	}
+
+	api.removeServer(server, false)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// removeServer deletes a server and detaches its resources, local volumes are deleted when terminating the server
+func (api *instanceAPI) removeServer(server *instance.Server, deleteVolumes bool) {
+	for _, volume := range api.volumes.list(func(v *instance.Volume) bool { return v.Server != nil && v.Server.ID == server.ID }) {
+		volume.Server = nil
+		if deleteVolumes && volume.VolumeType == instance.VolumeVolumeTypeLSSD {
+			api.volumes.delete(volume.ID)
+		}
+	}
+
+	api.s.block.detachServer(server.ID)
+
+	for _, ip := range api.ips.list(func(ip *instance.IP) bool { return ip.Server != nil && ip.Server.ID == server.ID }) {
+		ip.Server = nil
+		ip.State = instance.IPStateDetached
+	}
+
+	for _, nic := range api.privateNICs.list(func(nic *instance.PrivateNIC) bool { return nic.ServerID == server.ID }) {
+		api.s.ipam.detachResource(nic.ID)
+		api.privateNICs.delete(nic.ID)
+	}
+
+	delete(api.userData, server.ID)
+	delete(api.dynamicIPs, server.ID)
+	api.servers.delete(server.ID)
+}
+
+func (api *instanceAPI) serverAction(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	req := &instance.ServerActionRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if api.servers.busy(server.ID) {
+		writeTransientState(w, "instance_server", server.ID, string(server.State))
+
+		return
+	}
+
+	if !slices.Contains(allowedServerActions(server.State), req.Action) && req.Action != instance.ServerActionEnableRoutedIP {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", fmt.Sprintf("action %s is not allowed when the server is %s", req.Action, server.State), nil)
+
+		return
+	}
+
+	setState := func(state instance.ServerState, detail string) transition[*instance.Server] {
+		return func(server *instance.Server) bool {
+			server.State = state
+			server.StateDetail = detail
+
+			return true
+		}
+	}
+
+	switch req.Action {
+	case instance.ServerActionPoweron:
+		setState(instance.ServerStateStarting, "provisioning node")(server)
+		api.servers.schedule(server.ID, func(server *instance.Server) bool {
+			api.runCloudInit(server)
+
+			return setState(instance.ServerStateRunning, "booted")(server)
+		})
+	case instance.ServerActionReboot:
+		setState(instance.ServerStateStarting, "rebooting")(server)
+		api.servers.schedule(server.ID, setState(instance.ServerStateRunning, "booted"))
+	case instance.ServerActionPoweroff:
+		setState(instance.ServerStateStopping, "stopping")(server)
+		api.servers.schedule(server.ID, setState(instance.ServerStateStopped, ""))
+	case instance.ServerActionStopInPlace:
+		setState(instance.ServerStateStopping, "stopping")(server)
+		api.servers.schedule(server.ID, setState(instance.ServerStateStoppedInPlace, ""))
+	case instance.ServerActionTerminate:
+		setState(instance.ServerStateStopping, "terminating")(server)
+		api.servers.schedule(server.ID, func(server *instance.Server) bool {
+			api.removeServer(server, true)
+
+			return false
+		})
+	case instance.ServerActionEnableRoutedIP:
+		server.RoutedIPEnabled = scw.BoolPtr(true)
+	}
+
+	writeJSON(w, http.StatusAccepted, &instance.ServerActionResponse{
+		Task: &instance.Task{
+			ID:          api.s.newID(),
+			Description: "server_" + string(req.Action),
+			Status:      instance.TaskStatusPending,
+			HrefFrom:    "/servers/" + server.ID + "/action",
+			StartedAt:   now(),
+			Zone:        server.Zone,
+		},
+	})
+}
+
+func (api *instanceAPI) attachServerVolume(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	req := &instance.AttachServerVolumeRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	var volumeServer *instance.VolumeServer
+
+	if volume, ok := api.volumes.peek(req.VolumeID); ok {
+		if volume.Server != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", fmt.Sprintf("volume %s is already attached to server %s", volume.ID, volume.Server.ID), nil)
+
+			return
+		}
+
+		if volume.VolumeType == instance.VolumeVolumeTypeLSSD && server.State != instance.ServerStateStopped {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", "the server must be stopped to attach a local volume", nil)
+
+			return
+		}
+
+		volumeServer = attachVolume(server, volume, false)
+	} else {
+		blockVolume, err := api.attachBlockVolume(server, req.VolumeID)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error(), nil)
+
+			return
+		}
+
+		volumeServer = blockVolume
+	}
+
+	index := 0
+	for key := range server.Volumes {
+		if i, err := strconv.Atoi(key); err == nil && i >= index {
+			index = i + 1
+		}
+	}
+
+	server.Volumes[strconv.Itoa(index)] = volumeServer
+	server.ModificationDate = now()
+
+	writeJSON(w, http.StatusOK, &instance.AttachServerVolumeResponse{Server: api.renderServer(server)})
+}
+
+func (api *instanceAPI) detachServerVolume(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	req := &instance.DetachServerVolumeRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	for key, volumeServer := range server.Volumes {
+		if volumeServer.ID != req.VolumeID {
+			continue
+		}
+
+		if volume, ok := api.volumes.peek(req.VolumeID); ok {
+			if volume.VolumeType == instance.VolumeVolumeTypeLSSD && server.State != instance.ServerStateStopped {
+				writeError(w, http.StatusBadRequest, "invalid_request_error", "the server must be stopped to detach a local volume", nil)
+
+				return
+			}
+
+			volume.Server = nil
+		} else if volume, ok := api.s.block.volumes.peek(req.VolumeID); ok {
+			api.s.block.detachVolume(volume, server.ID)
+		}
+
+		delete(server.Volumes, key)
+		server.ModificationDate = now()
+
+		writeJSON(w, http.StatusOK, &instance.DetachServerVolumeResponse{Server: api.renderServer(server)})
+
+		return
+	}
+
+	writeNotFound(w, "instance_volume", req.VolumeID)
+}
+
+//
+// User data
+//
+
+// runCloudInit simulates cloud-init on boot: every script of the cloud-init user data writing to a user data key
+// through the metadata API reports a successful run
+func (api *instanceAPI) runCloudInit(server *instance.Server) {
+	for _, match := range metadataUserDataRegexp.FindAllSubmatch(api.userData[server.ID]["cloud-init"], -1) {
+		api.userData[server.ID][string(match[1])] = []byte("done")
+	}
+}
+
+func (api *instanceAPI) listServerUserData(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	keys := []string{}
+	for key := range api.userData[server.ID] {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	writeJSON(w, http.StatusOK, &instance.ListServerUserDataResponse{UserData: keys})
+}
+
+func (api *instanceAPI) getServerUserData(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	value, ok := api.userData[server.ID][r.PathValue("key")]
+	if !ok {
+		writeNotFound(w, "instance_user_data", r.PathValue("key"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(value)
+}
+
+func (api *instanceAPI) setServerUserData(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	value, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", err.Error(), nil)
+
+		return
+	}
+
+	if api.userData[server.ID] == nil {
+		api.userData[server.ID] = map[string][]byte{}
+	}
+
+	api.userData[server.ID][r.PathValue("key")] = value
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *instanceAPI) deleteServerUserData(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	delete(api.userData[server.ID], r.PathValue("key"))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//
+// Private NICs
+//
+
+func (api *instanceAPI) findPrivateNIC(w http.ResponseWriter, r *http.Request, server *instance.Server) (*instance.PrivateNIC, bool) {
+	id := r.PathValue("nic_id")
+
+	nic, ok := api.privateNICs.get(id)
+	if !ok || nic.ServerID != server.ID {
+		writeNotFound(w, "instance_private_nic", id)
+
+		return nil, false
+	}
+
+	return nic, true
+}
+
+func (api *instanceAPI) listPrivateNICs(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	nics := api.privateNICs.list(func(nic *instance.PrivateNIC) bool { return nic.ServerID == server.ID })
+	page, total := paginate(r, nics)
+
+	writeInstanceList(w, total, &instance.ListPrivateNICsResponse{
+		PrivateNics: page,
+		TotalCount:  uint64(total),
+	})
+}
+
+func (api *instanceAPI) createPrivateNIC(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	req := &instance.CreatePrivateNICRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	region, err := server.Zone.Region()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", err.Error(), nil)
+
+		return
+	}
+
+	pn, ok := api.s.vpc.privateNetworks.peek(req.PrivateNetworkID)
+	if !ok || pn.Region != region {
+		writeNotFound(w, "private_network", req.PrivateNetworkID)
+
+		return
+	}
+
+	for _, nic := range api.privateNICs.list(func(nic *instance.PrivateNIC) bool { return nic.ServerID == server.ID }) {
+		if nic.PrivateNetworkID == req.PrivateNetworkID {
+			writeError(w, http.StatusConflict, "conflict", "the server is already attached to this private network", nil)
+
+			return
+		}
+	}
+
+	nic := &instance.PrivateNIC{
+		ID:               api.s.newID(),
+		ServerID:         server.ID,
+		PrivateNetworkID: req.PrivateNetworkID,
+		MacAddress:       fmt.Sprintf("02:00:00:00:%02x:%02x", api.s.ids/256%256, api.s.ids%256),
+		State:            instance.PrivateNICStateSyncing,
+		Tags:             req.Tags,
+		CreationDate:     now(),
+		Zone:             server.Zone,
+	}
+
+	if nic.Tags == nil {
+		nic.Tags = []string{}
+	}
+
+	ipamIPs := make([]*ipam.IP, 0, len(req.IpamIPIDs))
+
+	for _, id := range req.IpamIPIDs {
+		ip, ok := api.s.ipam.ips.peek(id)
+		if !ok || ip.Region != region {
+			writeNotFound(w, "ipam_ip", id)
+
+			return
+		}
+
+		if ip.Source.PrivateNetworkID == nil || *ip.Source.PrivateNetworkID != pn.ID || ip.Resource != nil {
+			writeError(w, http.StatusBadRequest, "invalid_arguments", fmt.Sprintf("IPAM IP %s cannot be attached to the private NIC", id), nil)
+
+			return
+		}
+
+		ipamIPs = append(ipamIPs, ip)
+	}
+
+	resource := &ipam.Resource{
+		Type:       ipam.ResourceTypeInstancePrivateNic,
+		ID:         nic.ID,
+		MacAddress: &nic.MacAddress,
+		Name:       &server.Name,
+	}
+
+	if len(ipamIPs) == 0 {
+		api.s.ipam.bookResourceIP(pn, server.Project, resource)
+	}
+
+	for _, ip := range ipamIPs {
+		ip.Resource = resource
+		ip.UpdatedAt = now()
+	}
+
+	api.privateNICs.put(nic.ID, nic)
+	api.privateNICs.schedule(nic.ID, func(nic *instance.PrivateNIC) bool {
+		nic.State = instance.PrivateNICStateAvailable
+
+		return true
+	})
+
+	writeJSON(w, http.StatusCreated, &instance.CreatePrivateNICResponse{PrivateNic: nic})
+}
+
+func (api *instanceAPI) getPrivateNIC(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	nic, ok := api.findPrivateNIC(w, r, server)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &instance.GetPrivateNICResponse{PrivateNic: nic})
+}
+
+func (api *instanceAPI) deletePrivateNIC(w http.ResponseWriter, r *http.Request) {
+	server, ok := api.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	nic, ok := api.findPrivateNIC(w, r, server)
+	if !ok {
+		return
+	}
+
+	api.s.ipam.detachResource(nic.ID)
+	api.privateNICs.delete(nic.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//
+// Volumes
+//
+
+func (api *instanceAPI) findVolume(w http.ResponseWriter, r *http.Request) (*instance.Volume, bool) {
+	id := r.PathValue("volume_id")
+
+	volume, ok := api.volumes.get(id)
+	if !ok || volume.Zone != zoneOf(r) {
+		writeNotFound(w, "instance_volume", id)
+
+		return nil, false
+	}
+
+	return volume, true
+}
+
+func (api *instanceAPI) listVolumes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	zone := zoneOf(r)
+
+	volumes := api.volumes.list(func(volume *instance.Volume) bool {
+		switch {
+		case volume.Zone != zone:
+			return false
+		case query.Get("name") != "" && !strings.Contains(volume.Name, query.Get("name")):
+			return false
+		case query.Get("project") != "" && volume.Project != query.Get("project"):
+			return false
+		case query.Get("volume_type") != "" && string(volume.VolumeType) != query.Get("volume_type"):
+			return false
+		case query.Get("tags") != "" && !hasTags(volume.Tags, strings.Split(query.Get("tags"), ",")):
+			return false
+		}
+
+		return true
+	})
+
+	page, total := paginate(r, volumes)
+
+	writeInstanceList(w, total, &instance.ListVolumesResponse{
+		TotalCount: uint32(total),
+		Volumes:    page,
+	})
+}
+
+func (api *instanceAPI) createVolume(w http.ResponseWriter, r *http.Request) {
+	req := &instance.CreateVolumeRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if req.Size == nil && req.BaseSnapshot == nil {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", "one of size or base_snapshot must be set", nil)
+
+		return
+	}
+
+	volume := &instance.Volume{
+		ID:               api.s.newID(),
+		Name:             req.Name,
+		VolumeType:       req.VolumeType,
+		CreationDate:     now(),
+		ModificationDate: now(),
+		Organization:     DefaultOrganizationID,
+		Project:          projectOrDefault(req.Project),
+		Tags:             req.Tags,
+		State:            instance.VolumeStateAvailable,
+		Zone:             zoneOf(r),
+	}
+
+	if volume.VolumeType == "" {
+		volume.VolumeType = instance.VolumeVolumeTypeLSSD
+	}
+
+	if volume.Tags == nil {
+		volume.Tags = []string{}
+	}
+
+	if req.Size != nil {
+		volume.Size = *req.Size
+	}
+
+	api.volumes.put(volume.ID, volume)
+
+	if req.BaseSnapshot != nil {
+		volume.State = instance.VolumeStateFetching
+		api.volumes.schedule(volume.ID, setVolumeState(instance.VolumeStateAvailable))
+	}
+
+	writeJSON(w, http.StatusCreated, &instance.CreateVolumeResponse{Volume: volume})
+}
+
+func setVolumeState(state instance.VolumeState) transition[*instance.Volume] {
+	return func(volume *instance.Volume) bool {
+		volume.State = state
+
+		return true
+	}
+}
+
+func (api *instanceAPI) getVolume(w http.ResponseWriter, r *http.Request) {
+	volume, ok := api.findVolume(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &instance.GetVolumeResponse{Volume: volume})
+}
+
+func (api *instanceAPI) updateVolume(w http.ResponseWriter, r *http.Request) {
+	volume, ok := api.findVolume(w, r)
+	if !ok {
+		return
+	}
+
+	req := &instance.UpdateVolumeRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if req.Size != nil && *req.Size != volume.Size {
+		if *req.Size < volume.Size {
+			writeError(w, http.StatusBadRequest, "invalid_arguments", "volume size can only be increased", nil)
+
+			return
+		}
+
+		volume.Size = *req.Size
+		volume.State = instance.VolumeStateResizing
+		api.volumes.schedule(volume.ID, setVolumeState(instance.VolumeStateAvailable))
+	}
+
+	if req.Name != nil {
+		volume.Name = *req.Name
+	}
+
+	if req.Tags != nil {
+		volume.Tags = *req.Tags
+	}
+
+	volume.ModificationDate = now()
+
+	writeJSON(w, http.StatusOK, &instance.UpdateVolumeResponse{Volume: volume})
+}
+
+func (api *instanceAPI) deleteVolume(w http.ResponseWriter, r *http.Request) {
+	volume, ok := api.findVolume(w, r)
+	if !ok {
+		return
+	}
+
+	if volume.Server != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "a volume attached to a server cannot be deleted", nil)
+
+		return
+	}
+
+	api.volumes.delete(volume.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//
+// Snapshots
+//
+
+func (api *instanceAPI) findSnapshot(w http.ResponseWriter, r *http.Request) (*instance.Snapshot, bool) {
+	id := r.PathValue("snapshot_id")
+
+	snapshot, ok := api.snapshots.get(id)
+	if !ok || snapshot.Zone != zoneOf(r) {
+		writeNotFound(w, "instance_snapshot", id)
+
+		return nil, false
+	}
+
+	return snapshot, true
+}
+
+// snapshotInUse returns true if an image is made from the instance or block snapshot
+func (api *instanceAPI) snapshotInUse(snapshotID string) bool {
+	images := api.images.list(func(image *instance.Image) bool {
+		if image.RootVolume != nil && image.RootVolume.ID == snapshotID {
+			return true
+		}
+
+		for _, volume := range image.ExtraVolumes {
+			if volume.ID == snapshotID {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	return len(images) > 0
+}
+
+func (api *instanceAPI) createSnapshot(w http.ResponseWriter, r *http.Request) {
+	req := &instance.CreateSnapshotRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if req.VolumeID == nil {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", "volume_id is required by the mock API", nil)
+
+		return
+	}
+
+	volume, ok := api.volumes.peek(*req.VolumeID)
+	if !ok || volume.Zone != zoneOf(r) {
+		writeNotFound(w, "instance_volume", *req.VolumeID)
+
+		return
+	}
+
+	snapshot := &instance.Snapshot{
+		ID:               api.s.newID(),
+		Name:             req.Name,
+		Organization:     DefaultOrganizationID,
+		Project:          projectOrDefault(req.Project),
+		Tags:             []string{},
+		VolumeType:       volume.VolumeType,
+		Size:             volume.Size,
+		State:            instance.SnapshotStateSnapshotting,
+		BaseVolume:       &instance.SnapshotBaseVolume{ID: volume.ID, Name: volume.Name},
+		CreationDate:     now(),
+		ModificationDate: now(),
+		Zone:             volume.Zone,
+	}
+
+	if req.Tags != nil {
+		snapshot.Tags = *req.Tags
+	}
+
+	api.snapshots.put(snapshot.ID, snapshot)
+	api.snapshots.schedule(snapshot.ID, func(snapshot *instance.Snapshot) bool {
+		snapshot.State = instance.SnapshotStateAvailable
+
+		return true
+	})
+
+	writeJSON(w, http.StatusCreated, &instance.CreateSnapshotResponse{Snapshot: snapshot})
+}
+
+func (api *instanceAPI) getSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, ok := api.findSnapshot(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &instance.GetSnapshotResponse{Snapshot: snapshot})
+}
+
+func (api *instanceAPI) deleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, ok := api.findSnapshot(w, r)
+	if !ok {
+		return
+	}
+
+	if api.snapshotInUse(snapshot.ID) {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "a snapshot used by an image cannot be deleted", nil)
+
+		return
+	}
+
+	api.snapshots.delete(snapshot.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// migrationSnapshot returns the instance snapshot of a block migration request, the migration of volumes is not implemented
+func (api *instanceAPI) migrationSnapshot(w http.ResponseWriter, r *http.Request, volumeID *string, snapshotID *string) (*instance.Snapshot, bool) {
+	if volumeID != nil || snapshotID == nil {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", "only the migration of snapshots is implemented by the mock API", nil)
+
+		return nil, false
+	}
+
+	snapshot, ok := api.snapshots.peek(*snapshotID)
+	if !ok || snapshot.Zone != zoneOf(r) {
+		writeNotFound(w, "instance_snapshot", *snapshotID)
+
+		return nil, false
+	}
+
+	return snapshot, true
+}
+
+func (api *instanceAPI) planBlockMigration(w http.ResponseWriter, r *http.Request) {
+	req := &instance.PlanBlockMigrationRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	snapshot, ok := api.migrationSnapshot(w, r, req.VolumeID, req.SnapshotID)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &instance.MigrationPlan{
+		Snapshots:     []*instance.Snapshot{snapshot},
+		ValidationKey: "migrate-" + snapshot.ID,
+	})
+}
+
+// applyBlockMigration moves an instance snapshot to the block API, it keeps its ID
+func (api *instanceAPI) applyBlockMigration(w http.ResponseWriter, r *http.Request) {
+	req := &instance.ApplyBlockMigrationRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	snapshot, ok := api.migrationSnapshot(w, r, req.VolumeID, req.SnapshotID)
+	if !ok {
+		return
+	}
+
+	if req.ValidationKey != "migrate-"+snapshot.ID {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", "the validation key does not match the migration plan", nil)
+
+		return
+	}
+
+	api.snapshots.delete(snapshot.ID)
+
+	blockSnapshot := &block.Snapshot{
+		ID:        snapshot.ID,
+		Name:      snapshot.Name,
+		Size:      snapshot.Size,
+		ProjectID: snapshot.Project,
+		CreatedAt: snapshot.CreationDate,
+		UpdatedAt: now(),
+		Status:    block.SnapshotStatusCreating,
+		Tags:      snapshot.Tags,
+		Zone:      snapshot.Zone,
+		Class:     block.StorageClassSbs,
+	}
+	if snapshot.BaseVolume != nil {
+		blockSnapshot.ParentVolume = &block.SnapshotParentVolume{ID: snapshot.BaseVolume.ID, Name: snapshot.BaseVolume.Name}
+	}
+
+	api.s.block.snapshots.put(blockSnapshot.ID, blockSnapshot)
+	api.s.block.snapshots.schedule(blockSnapshot.ID, func(snapshot *block.Snapshot) bool {
+		snapshot.Status = block.SnapshotStatusAvailable
+
+		return true
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//
+// Images
+//
+
+func (api *instanceAPI) findImage(w http.ResponseWriter, r *http.Request) (*instance.Image, bool) {
+	id := r.PathValue("image_id")
+
+	image, ok := api.images.get(id)
+	if !ok || image.Zone != zoneOf(r) {
+		writeNotFound(w, "instance_image", id)
+
+		return nil, false
+	}
+
+	return image, true
+}
+
+// imageVolume returns the instance or block snapshot an image is made from
+func (api *instanceAPI) imageVolume(zone scw.Zone, snapshotID string) (*instance.VolumeSummary, bool) {
+	if snapshot, ok := api.snapshots.peek(snapshotID); ok && snapshot.Zone == zone {
+		return &instance.VolumeSummary{ID: snapshot.ID, Name: snapshot.Name, Size: snapshot.Size, VolumeType: snapshot.VolumeType}, true
+	}
+
+	if snapshot, ok := api.s.block.snapshots.peek(snapshotID); ok && snapshot.Zone == zone {
+		return &instance.VolumeSummary{ID: snapshot.ID, Name: snapshot.Name, Size: snapshot.Size, VolumeType: instance.VolumeVolumeTypeSbsSnapshot}, true
+	}
+
+	return nil, false
+}
+
+func (api *instanceAPI) createImage(w http.ResponseWriter, r *http.Request) {
+	req := &instance.CreateImageRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	zone := zoneOf(r)
+	project := projectOrDefault(req.Project)
+
+	images := api.images.list(func(image *instance.Image) bool { return image.Project == project })
+	if len(images) >= imageQuota {
+		writeError(w, http.StatusForbidden, "quotas_exceeded", "Quotas exceeded", map[string]any{
+			"details": []map[string]any{{"resource": "images", "quota": imageQuota, "current": len(images)}},
+		})
+
+		return
+	}
+
+	rootVolume, ok := api.imageVolume(zone, req.RootVolume)
+	if !ok {
+		writeNotFound(w, "instance_snapshot", req.RootVolume)
+
+		return
+	}
+
+	image := &instance.Image{
+		ID:               api.s.newID(),
+		Name:             req.Name,
+		Arch:             req.Arch,
+		CreationDate:     now(),
+		ModificationDate: now(),
+		ExtraVolumes:     map[string]*instance.Volume{},
+		Organization:     DefaultOrganizationID,
+		Public:           req.Public != nil && *req.Public,
+		RootVolume:       rootVolume,
+		State:            instance.ImageStateCreating,
+		Project:          project,
+		Tags:             req.Tags,
+		Zone:             zone,
+	}
+
+	if image.Tags == nil {
+		image.Tags = []string{}
+	}
+
+	for index, template := range req.ExtraVolumes {
+		volume, ok := api.imageVolume(zone, template.ID)
+		if !ok {
+			writeNotFound(w, "instance_snapshot", template.ID)
+
+			return
+		}
+
+		image.ExtraVolumes[index] = &instance.Volume{ID: volume.ID, Name: volume.Name, Size: volume.Size, VolumeType: volume.VolumeType, Zone: zone}
+	}
+
+	api.images.put(image.ID, image)
+	api.images.schedule(image.ID, func(image *instance.Image) bool {
+		image.State = instance.ImageStateAvailable
+
+		return true
+	})
+
+	writeJSON(w, http.StatusCreated, &instance.CreateImageResponse{Image: image})
+}
+
+func (api *instanceAPI) getImage(w http.ResponseWriter, r *http.Request) {
+	image, ok := api.findImage(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &instance.GetImageResponse{Image: image})
+}
+
+func (api *instanceAPI) deleteImage(w http.ResponseWriter, r *http.Request) {
+	image, ok := api.findImage(w, r)
+	if !ok {
+		return
+	}
+
+	api.images.delete(image.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//
+// IPs
+//
+
+// nextIPv4 returns a new address from the documentation range
+func (api *instanceAPI) nextIPv4() net.IP {
+	api.ipCount++
+
+	return net.IPv4(51, 15, byte(api.ipCount/256), byte(api.ipCount%256))
+}
+
+func (api *instanceAPI) findIP(w http.ResponseWriter, r *http.Request) (*instance.IP, bool) {
+	id := r.PathValue("ip")
+
+	ip, ok := api.ips.get(id)
+	if !ok {
+		// IPs can also be fetched by address
+		for _, candidate := range api.ips.list(nil) {
+			if candidate.Address.String() == id {
+				ip, ok = candidate, true
+
+				break
+			}
+		}
+	}
+
+	if !ok || ip.Zone != zoneOf(r) {
+		writeNotFound(w, "instance_ip", id)
+
+		return nil, false
+	}
+
+	return ip, true
+}
+
+func (api *instanceAPI) listIPs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	zone := zoneOf(r)
+
+	ips := api.ips.list(func(ip *instance.IP) bool {
+		switch {
+		case ip.Zone != zone:
+			return false
+		case query.Get("project") != "" && ip.Project != query.Get("project"):
+			return false
+		case query.Get("type") != "" && string(ip.Type) != query.Get("type"):
+			return false
+		case query.Get("tags") != "" && !hasTags(ip.Tags, strings.Split(query.Get("tags"), ",")):
+			return false
+		}
+
+		return true
+	})
+
+	page, total := paginate(r, ips)
+
+	writeInstanceList(w, total, &instance.ListIPsResponse{
+		TotalCount: uint32(total),
+		IPs:        page,
+	})
+}
+
+func (api *instanceAPI) createIP(w http.ResponseWriter, r *http.Request) {
+	req := &instance.CreateIPRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	ip := &instance.IP{
+		ID:           api.s.newID(),
+		Organization: DefaultOrganizationID,
+		Project:      projectOrDefault(req.Project),
+		Tags:         req.Tags,
+		Type:         req.Type,
+		State:        instance.IPStateDetached,
+		IpamID:       api.s.newID(),
+		Zone:         zoneOf(r),
+	}
+
+	if ip.Tags == nil {
+		ip.Tags = []string{}
+	}
+
+	if ip.Type == "" {
+		ip.Type = instance.IPTypeRoutedIPv4
+	}
+
+	switch ip.Type {
+	case instance.IPTypeRoutedIPv4:
+		ip.Address = api.nextIPv4()
+		ip.Prefix = scw.IPNet{IPNet: net.IPNet{IP: ip.Address, Mask: net.CIDRMask(32, 32)}}
+	case instance.IPTypeRoutedIPv6:
+		api.ipCount++
+		ip.Address = net.ParseIP(fmt.Sprintf("2001:bc8:%x::", api.ipCount))
+		ip.Prefix = scw.IPNet{IPNet: net.IPNet{IP: ip.Address, Mask: net.CIDRMask(64, 128)}}
+	default:
+		writeError(w, http.StatusBadRequest, "invalid_arguments", fmt.Sprintf("ip type %q is not supported", ip.Type), nil)
+
+		return
+	}
+
+	if req.Server != nil {
+		server, ok := api.servers.peek(*req.Server)
+		if !ok || server.Zone != ip.Zone {
+			writeNotFound(w, "instance_server", *req.Server)
+
+			return
+		}
+
+		ip.Server = &instance.ServerSummary{ID: server.ID, Name: server.Name}
+		ip.State = instance.IPStateAttached
+	}
+
+	api.ips.put(ip.ID, ip)
+
+	writeJSON(w, http.StatusCreated, &instance.CreateIPResponse{IP: ip})
+}
+
+func (api *instanceAPI) getIP(w http.ResponseWriter, r *http.Request) {
+	ip, ok := api.findIP(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &instance.GetIPResponse{IP: ip})
+}
+
+func (api *instanceAPI) updateIP(w http.ResponseWriter, r *http.Request) {
+	ip, ok := api.findIP(w, r)
+	if !ok {
+		return
+	}
+
+	req := &instance.UpdateIPRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if req.Server != nil {
+		ip.Server = nil
+		ip.State = instance.IPStateDetached
+
+		if !req.Server.Null {
+			server, ok := api.servers.peek(req.Server.Value)
+			if !ok || server.Zone != ip.Zone {
+				writeNotFound(w, "instance_server", req.Server.Value)
+
+				return
+			}
+
+			ip.Server = &instance.ServerSummary{ID: server.ID, Name: server.Name}
+			ip.State = instance.IPStateAttached
+		}
+	}
+
+	if req.Reverse != nil {
+		ip.Reverse = nil
+		if !req.Reverse.Null {
+			ip.Reverse = scw.StringPtr(req.Reverse.Value)
+		}
+	}
+
+	if req.Tags != nil {
+		ip.Tags = *req.Tags
+	}
+
+	writeJSON(w, http.StatusOK, &instance.UpdateIPResponse{IP: ip})
+}
+
+func (api *instanceAPI) deleteIP(w http.ResponseWriter, r *http.Request) {
+	ip, ok := api.findIP(w, r)
+	if !ok {
+		return
+	}
+
+	api.ips.delete(ip.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//
+// Security groups
+//
+
+func (api *instanceAPI) findSecurityGroup(w http.ResponseWriter, r *http.Request) (*instance.SecurityGroup, bool) {
+	id := r.PathValue("security_group_id")
+
+	group, ok := api.groups.get(id)
+	if !ok || group.Zone != zoneOf(r) {
+		writeNotFound(w, "instance_security_group", id)
+
+		return nil, false
+	}
+
+	return group, true
+}
+
+func (api *instanceAPI) createSecurityGroup(w http.ResponseWriter, r *http.Request) {
+	req := &instance.CreateSecurityGroupRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	group := &instance.SecurityGroup{
+		ID:                    api.s.newID(),
+		Name:                  req.Name,
+		Description:           req.Description,
+		EnableDefaultSecurity: req.EnableDefaultSecurity == nil || *req.EnableDefaultSecurity,
+		InboundDefaultPolicy:  req.InboundDefaultPolicy,
+		OutboundDefaultPolicy: req.OutboundDefaultPolicy,
+		Organization:          DefaultOrganizationID,
+		Project:               projectOrDefault(req.Project),
+		Tags:                  req.Tags,
+		CreationDate:          now(),
+		ModificationDate:      now(),
+		Servers:               []*instance.ServerSummary{},
+		Stateful:              req.Stateful,
+		State:                 instance.SecurityGroupStateAvailable,
+		Zone:                  zoneOf(r),
+	}
+
+	if group.Tags == nil {
+		group.Tags = []string{}
+	}
+
+	if group.InboundDefaultPolicy == "" {
+		group.InboundDefaultPolicy = instance.SecurityGroupPolicyAccept
+	}
+
+	if group.OutboundDefaultPolicy == "" {
+		group.OutboundDefaultPolicy = instance.SecurityGroupPolicyAccept
+	}
+
+	api.groups.put(group.ID, group)
+	api.rules[group.ID] = []*instance.SecurityGroupRule{}
+
+	writeJSON(w, http.StatusCreated, &instance.CreateSecurityGroupResponse{SecurityGroup: group})
+}
+
+func (api *instanceAPI) getSecurityGroup(w http.ResponseWriter, r *http.Request) {
+	group, ok := api.findSecurityGroup(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &instance.GetSecurityGroupResponse{SecurityGroup: group})
+}
+
+func (api *instanceAPI) deleteSecurityGroup(w http.ResponseWriter, r *http.Request) {
+	group, ok := api.findSecurityGroup(w, r)
+	if !ok {
+		return
+	}
+
+	api.groups.delete(group.ID)
+	delete(api.rules, group.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *instanceAPI) findSecurityGroupRule(w http.ResponseWriter, r *http.Request, group *instance.SecurityGroup) (int, bool) {
+	id := r.PathValue("rule_id")
+
+	i := slices.IndexFunc(api.rules[group.ID], func(rule *instance.SecurityGroupRule) bool { return rule.ID == id })
+	if i < 0 {
+		writeNotFound(w, "instance_security_group_rule", id)
+
+		return 0, false
+	}
+
+	return i, true
+}
+
+func (api *instanceAPI) listSecurityGroupRules(w http.ResponseWriter, r *http.Request) {
+	group, ok := api.findSecurityGroup(w, r)
+	if !ok {
+		return
+	}
+
+	page, total := paginate(r, api.rules[group.ID])
+
+	writeInstanceList(w, total, &instance.ListSecurityGroupRulesResponse{
+		TotalCount: uint32(total),
+		Rules:      page,
+	})
+}
+
+func (api *instanceAPI) createSecurityGroupRule(w http.ResponseWriter, r *http.Request) {
+	group, ok := api.findSecurityGroup(w, r)
+	if !ok {
+		return
+	}
+
+	req := &instance.CreateSecurityGroupRuleRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	rule := &instance.SecurityGroupRule{
+		ID:           api.s.newID(),
+		Protocol:     req.Protocol,
+		Direction:    req.Direction,
+		Action:       req.Action,
+		IPRange:      req.IPRange,
+		DestPortFrom: req.DestPortFrom,
+		DestPortTo:   req.DestPortTo,
+		Position:     uint32(len(api.rules[group.ID]) + 1),
+		Editable:     true,
+		Zone:         group.Zone,
+	}
+
+	api.rules[group.ID] = append(api.rules[group.ID], rule)
+
+	writeJSON(w, http.StatusCreated, &instance.CreateSecurityGroupRuleResponse{Rule: rule})
+}
+
+func (api *instanceAPI) getSecurityGroupRule(w http.ResponseWriter, r *http.Request) {
+	group, ok := api.findSecurityGroup(w, r)
+	if !ok {
+		return
+	}
+
+	i, ok := api.findSecurityGroupRule(w, r, group)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &instance.GetSecurityGroupRuleResponse{Rule: api.rules[group.ID][i]})
+}
+
+func (api *instanceAPI) updateSecurityGroupRule(w http.ResponseWriter, r *http.Request) {
+	group, ok := api.findSecurityGroup(w, r)
+	if !ok {
+		return
+	}
+
+	i, ok := api.findSecurityGroupRule(w, r, group)
+	if !ok {
+		return
+	}
+
+	req := &instance.UpdateSecurityGroupRuleRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	rule := api.rules[group.ID][i]
+
+	if req.Action != "" {
+		rule.Action = req.Action
+	}
+
+	if req.IPRange != nil {
+		rule.IPRange = *req.IPRange
+	}
+
+	writeJSON(w, http.StatusOK, &instance.UpdateSecurityGroupRuleResponse{Rule: rule})
+}
+
+func (api *instanceAPI) deleteSecurityGroupRule(w http.ResponseWriter, r *http.Request) {
+	group, ok := api.findSecurityGroup(w, r)
+	if !ok {
+		return
+	}
+
+	i, ok := api.findSecurityGroupRule(w, r, group)
+	if !ok {
+		return
+	}
+
+	api.rules[group.ID] = slices.Delete(api.rules[group.ID], i, i+1)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//
+// Placement groups
+//
+
+func (api *instanceAPI) findPlacementGroup(w http.ResponseWriter, r *http.Request) (*instance.PlacementGroup, bool) {
+	id := r.PathValue("placement_group_id")
+
+	group, ok := api.placements.get(id)
+	if !ok || group.Zone != zoneOf(r) {
+		writeNotFound(w, "instance_placement_group", id)
+
+		return nil, false
+	}
+
+	return group, true
+}
+
+// placementGroupServers evaluates the policy of a placement group for its servers, in their creation order
+func (api *instanceAPI) placementGroupServers(group *instance.PlacementGroup) []*instance.PlacementGroupServer {
+	members := []*instance.PlacementGroupServer{}
+	group.PolicyRespected = true
+
+	for i, server := range api.servers.list(func(server *instance.Server) bool {
+		return server.PlacementGroup != nil && server.PlacementGroup.ID == group.ID
+	}) {
+		respected := group.PolicyType != instance.PlacementGroupPolicyTypeMaxAvailability || i < placementGroupHypervisors
+		group.PolicyRespected = group.PolicyRespected && respected
+
+		server.PlacementGroup = &instance.PlacementGroup{
+			ID:              group.ID,
+			Name:            group.Name,
+			PolicyMode:      group.PolicyMode,
+			PolicyType:      group.PolicyType,
+			PolicyRespected: respected,
+			Zone:            group.Zone,
+		}
+
+		members = append(members, &instance.PlacementGroupServer{
+			ID:              server.ID,
+			Name:            server.Name,
+			PolicyRespected: respected,
+		})
+	}
+
+	return members
+}
+
+func (api *instanceAPI) createPlacementGroup(w http.ResponseWriter, r *http.Request) {
+	req := &instance.CreatePlacementGroupRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	group := &instance.PlacementGroup{
+		ID:              api.s.newID(),
+		Name:            req.Name,
+		Organization:    DefaultOrganizationID,
+		Project:         projectOrDefault(req.Project),
+		Tags:            req.Tags,
+		PolicyMode:      req.PolicyMode,
+		PolicyType:      req.PolicyType,
+		PolicyRespected: true,
+		Zone:            zoneOf(r),
+	}
+
+	if group.Tags == nil {
+		group.Tags = []string{}
+	}
+
+	if group.PolicyMode == "" {
+		group.PolicyMode = instance.PlacementGroupPolicyModeOptional
+	}
+
+	if group.PolicyType == "" {
+		group.PolicyType = instance.PlacementGroupPolicyTypeMaxAvailability
+	}
+
+	api.placements.put(group.ID, group)
+
+	writeJSON(w, http.StatusCreated, &instance.CreatePlacementGroupResponse{PlacementGroup: group})
+}
+
+func (api *instanceAPI) getPlacementGroup(w http.ResponseWriter, r *http.Request) {
+	group, ok := api.findPlacementGroup(w, r)
+	if !ok {
+		return
+	}
+
+	api.placementGroupServers(group)
+
+	writeJSON(w, http.StatusOK, &instance.GetPlacementGroupResponse{PlacementGroup: group})
+}
+
+func (api *instanceAPI) deletePlacementGroup(w http.ResponseWriter, r *http.Request) {
+	group, ok := api.findPlacementGroup(w, r)
+	if !ok {
+		return
+	}
+
+	if len(api.placementGroupServers(group)) > 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "a placement group with servers cannot be deleted", nil)
+
+		return
+	}
+
+	api.placements.delete(group.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *instanceAPI) getPlacementGroupServers(w http.ResponseWriter, r *http.Request) {
+	group, ok := api.findPlacementGroup(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &instance.GetPlacementGroupServersResponse{Servers: api.placementGroupServers(group)})
+}
+
+// setPlacementGroupServers replaces the servers of a placement group, the servers joining or leaving it must be stopped
+func (api *instanceAPI) setPlacementGroupServers(w http.ResponseWriter, r *http.Request) {
+	group, ok := api.findPlacementGroup(w, r)
+	if !ok {
+		return
+	}
+
+	req := &instance.SetPlacementGroupServersRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	changed := []*instance.Server(nil)
+
+	for _, id := range req.Servers {
+		server, ok := api.servers.peek(id)
+		if !ok || server.Zone != group.Zone {
+			writeNotFound(w, "instance_server", id)
+
+			return
+		}
+
+		if server.PlacementGroup == nil || server.PlacementGroup.ID != group.ID {
+			changed = append(changed, server)
+		}
+	}
+
+	for _, member := range api.placementGroupServers(group) {
+		if !slices.Contains(req.Servers, member.ID) {
+			server, _ := api.servers.peek(member.ID)
+			changed = append(changed, server)
+		}
+	}
+
+	for _, server := range changed {
+		if server.State != instance.ServerStateStopped {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", fmt.Sprintf("server %s must be stopped to change its placement group", server.ID), nil)
+
+			return
+		}
+	}
+
+	for _, server := range changed {
+		if slices.Contains(req.Servers, server.ID) {
+			server.PlacementGroup = &instance.PlacementGroup{ID: group.ID}
+		} else {
+			server.PlacementGroup = nil
+		}
+
+		server.ModificationDate = now()
+	}
+
+	writeJSON(w, http.StatusOK, &instance.SetPlacementGroupServersResponse{Servers: api.placementGroupServers(group)})
+}
+
+func hasTags(tags []string, expected []string) bool {
+	for _, tag := range expected {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,224 @@
+package mockapi
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const ipamPrefix = "/ipam/v1/regions/{region}"
+
+type ipamAPI struct {
+	s *Server
+
+	ips *store[*ipam.IP]
+	// addressCount is the number of addresses booked in each subnet
+	addressCount map[string]int
+	// bookedFor maps the IPs booked by another product to the resource they were booked for
+	bookedFor map[string]string
+}
+
+func newIPAMAPI(s *Server) *ipamAPI {
+	return &ipamAPI{
+		s:            s,
+		ips:          newStore[*ipam.IP](),
+		addressCount: map[string]int{},
+		bookedFor:    map[string]string{},
+	}
+}
+
+func (api *ipamAPI) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+ipamPrefix+"/ips", api.listIPs)
+	mux.HandleFunc("POST "+ipamPrefix+"/ips", api.bookIP)
+	mux.HandleFunc("GET "+ipamPrefix+"/ips/{ip_id}", api.getIP)
+	mux.HandleFunc("DELETE "+ipamPrefix+"/ips/{ip_id}", api.releaseIP)
+}
+
+//
+// IPs
+//
+
+// newIP books the next address of the IPv4 or IPv6 subnet of a private network
+func (api *ipamAPI) newIP(pn *vpc.PrivateNetwork, projectID string, isIPv6 bool) (*ipam.IP, bool) {
+	for _, subnet := range pn.Subnets {
+		if (subnet.Subnet.IP.To4() == nil) != isIPv6 {
+			continue
+		}
+
+		api.addressCount[subnet.ID]++
+
+		address := make(net.IP, len(subnet.Subnet.IP))
+		copy(address, subnet.Subnet.IP)
+		address[len(address)-1] += byte(api.addressCount[subnet.ID] + 1)
+
+		bits := 32
+		if isIPv6 {
+			bits = 128
+		}
+
+		ip := &ipam.IP{
+			ID:        api.s.newID(),
+			Address:   scw.IPNet{IPNet: net.IPNet{IP: address, Mask: net.CIDRMask(bits, bits)}},
+			ProjectID: projectID,
+			IsIPv6:    isIPv6,
+			CreatedAt: now(),
+			UpdatedAt: now(),
+			Source: &ipam.Source{
+				PrivateNetworkID: &pn.ID,
+				SubnetID:         &subnet.ID,
+			},
+			Tags:     []string{},
+			Reverses: []*ipam.Reverse{},
+			Region:   pn.Region,
+		}
+		api.ips.put(ip.ID, ip)
+
+		return ip, true
+	}
+
+	return nil, false
+}
+
+// bookResourceIP books an IP for a resource of another product, it is released along with the resource
+func (api *ipamAPI) bookResourceIP(pn *vpc.PrivateNetwork, projectID string, resource *ipam.Resource) (*ipam.IP, bool) {
+	ip, ok := api.newIP(pn, projectID, false)
+	if !ok {
+		return nil, false
+	}
+
+	ip.Resource = resource
+	api.bookedFor[ip.ID] = resource.ID
+
+	return ip, true
+}
+
+// detachResource frees the IPs held by a resource and releases the ones booked for it
+func (api *ipamAPI) detachResource(resourceID string) {
+	for _, ip := range api.ips.list(func(ip *ipam.IP) bool { return ip.Resource != nil && ip.Resource.ID == resourceID }) {
+		ip.Resource = nil
+		ip.UpdatedAt = now()
+
+		if api.bookedFor[ip.ID] == resourceID {
+			delete(api.bookedFor, ip.ID)
+			api.ips.delete(ip.ID)
+		}
+	}
+}
+
+func (api *ipamAPI) findIP(w http.ResponseWriter, r *http.Request) (*ipam.IP, bool) {
+	id := r.PathValue("ip_id")
+
+	ip, ok := api.ips.get(id)
+	if !ok || ip.Region != regionOf(r) {
+		writeNotFound(w, "ipam_ip", id)
+
+		return nil, false
+	}
+
+	return ip, true
+}
+
+func (api *ipamAPI) listIPs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	region := regionOf(r)
+
+	resourceType := query.Get("resource_type")
+	if resourceType == string(ipam.ResourceTypeUnknownType) {
+		resourceType = ""
+	}
+
+	ips := api.ips.list(func(ip *ipam.IP) bool {
+		switch {
+		case ip.Region != region:
+			return false
+		case query.Get("project_id") != "" && ip.ProjectID != query.Get("project_id"):
+			return false
+		case query.Get("private_network_id") != "" && (ip.Source.PrivateNetworkID == nil || *ip.Source.PrivateNetworkID != query.Get("private_network_id")):
+			return false
+		case query.Get("is_ipv6") != "" && strconv.FormatBool(ip.IsIPv6) != query.Get("is_ipv6"):
+			return false
+		case query.Get("attached") != "" && strconv.FormatBool(ip.Resource != nil) != query.Get("attached"):
+			return false
+		case query.Get("resource_id") != "" && (ip.Resource == nil || ip.Resource.ID != query.Get("resource_id")):
+			return false
+		case resourceType != "" && (ip.Resource == nil || string(ip.Resource.Type) != resourceType):
+			return false
+		case query.Get("mac_address") != "" && (ip.Resource == nil || ip.Resource.MacAddress == nil || *ip.Resource.MacAddress != query.Get("mac_address")):
+			return false
+		case !hasTags(ip.Tags, query["tags"]):
+			return false
+		}
+
+		return true
+	})
+
+	page, total := paginate(r, ips)
+
+	writeJSON(w, http.StatusOK, &ipam.ListIPsResponse{
+		TotalCount: uint64(total),
+		IPs:        page,
+	})
+}
+
+func (api *ipamAPI) bookIP(w http.ResponseWriter, r *http.Request) {
+	req := &ipam.BookIPRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if req.Source == nil || req.Source.PrivateNetworkID == nil {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", "source.private_network_id is required by the mock API", nil)
+
+		return
+	}
+
+	pn, ok := api.s.vpc.privateNetworks.peek(*req.Source.PrivateNetworkID)
+	if !ok || pn.Region != regionOf(r) {
+		writeNotFound(w, "private_network", *req.Source.PrivateNetworkID)
+
+		return
+	}
+
+	ip, ok := api.newIP(pn, projectOrDefault(&req.ProjectID), req.IsIPv6)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", "the private network has no subnet of the requested family", nil)
+
+		return
+	}
+
+	if req.Tags != nil {
+		ip.Tags = req.Tags
+	}
+
+	writeJSON(w, http.StatusOK, ip)
+}
+
+func (api *ipamAPI) getIP(w http.ResponseWriter, r *http.Request) {
+	ip, ok := api.findIP(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ip)
+}
+
+func (api *ipamAPI) releaseIP(w http.ResponseWriter, r *http.Request) {
+	ip, ok := api.findIP(w, r)
+	if !ok {
+		return
+	}
+
+	if ip.Resource != nil {
+		writeError(w, http.StatusPreconditionFailed, "precondition_failed", "an IP attached to a resource cannot be released", nil)
+
+		return
+	}
+
+	api.ips.delete(ip.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
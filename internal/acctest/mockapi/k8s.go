@@ -0,0 +1,546 @@
+package mockapi
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const k8sPrefix = "/k8s/v1/regions/{region}"
+
+type k8sAPI struct {
+	s *Server
+
+	clusters *store[*k8s.Cluster]
+	pools    *store[*k8s.Pool]
+	nodes    *store[*k8s.Node]
+}
+
+func newK8SAPI(s *Server) *k8sAPI {
+	return &k8sAPI{
+		s:        s,
+		clusters: newStore[*k8s.Cluster](),
+		pools:    newStore[*k8s.Pool](),
+		nodes:    newStore[*k8s.Node](),
+	}
+}
+
+func (api *k8sAPI) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+k8sPrefix+"/clusters", api.listClusters)
+	mux.HandleFunc("POST "+k8sPrefix+"/clusters", api.createCluster)
+	mux.HandleFunc("GET "+k8sPrefix+"/clusters/{cluster_id}", api.getCluster)
+	mux.HandleFunc("DELETE "+k8sPrefix+"/clusters/{cluster_id}", api.deleteCluster)
+
+	mux.HandleFunc("GET "+k8sPrefix+"/clusters/{cluster_id}/pools", api.listPools)
+	mux.HandleFunc("POST "+k8sPrefix+"/clusters/{cluster_id}/pools", api.createPool)
+	mux.HandleFunc("GET "+k8sPrefix+"/pools/{pool_id}", api.getPool)
+	mux.HandleFunc("PATCH "+k8sPrefix+"/pools/{pool_id}", api.updatePool)
+	mux.HandleFunc("DELETE "+k8sPrefix+"/pools/{pool_id}", api.deletePool)
+
+	mux.HandleFunc("GET "+k8sPrefix+"/clusters/{cluster_id}/nodes", api.listNodes)
+	mux.HandleFunc("GET "+k8sPrefix+"/nodes/{node_id}", api.getNode)
+	mux.HandleFunc("POST "+k8sPrefix+"/nodes/{node_id}/reboot", api.rebootNode)
+	mux.HandleFunc("POST "+k8sPrefix+"/nodes/{node_id}/replace", api.replaceNode)
+}
+
+//
+// Clusters
+//
+
+func (api *k8sAPI) findCluster(w http.ResponseWriter, r *http.Request) (*k8s.Cluster, bool) {
+	id := r.PathValue("cluster_id")
+
+	cluster, ok := api.clusters.get(id)
+	if !ok || cluster.Region != regionOf(r) {
+		writeNotFound(w, "k8s_cluster", id)
+
+		return nil, false
+	}
+
+	return cluster, true
+}
+
+func (api *k8sAPI) listClusters(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	region := regionOf(r)
+
+	clusters := api.clusters.list(func(cluster *k8s.Cluster) bool {
+		switch {
+		case cluster.Region != region:
+			return false
+		case query.Get("name") != "" && !strings.Contains(cluster.Name, query.Get("name")):
+			return false
+		case query.Get("project_id") != "" && cluster.ProjectID != query.Get("project_id"):
+			return false
+		case query.Get("private_network_id") != "" && (cluster.PrivateNetworkID == nil || *cluster.PrivateNetworkID != query.Get("private_network_id")):
+			return false
+		}
+
+		return true
+	})
+
+	page, total := paginate(r, clusters)
+
+	writeJSON(w, http.StatusOK, &k8s.ListClustersResponse{
+		Clusters:   page,
+		TotalCount: uint64(total),
+	})
+}
+
+func (api *k8sAPI) createCluster(w http.ResponseWriter, r *http.Request) {
+	req := &k8s.CreateClusterRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	region := regionOf(r)
+	_, podCIDR, _ := net.ParseCIDR("100.64.0.0/15")
+	_, serviceCIDR, _ := net.ParseCIDR("10.32.0.0/20")
+
+	cluster := &k8s.Cluster{
+		ID:                api.s.newID(),
+		Type:              req.Type,
+		Name:              req.Name,
+		Status:            k8s.ClusterStatusCreating,
+		Version:           req.Version,
+		Region:            region,
+		OrganizationID:    DefaultOrganizationID,
+		ProjectID:         projectOrDefault(req.ProjectID),
+		Tags:              req.Tags,
+		Cni:               req.Cni,
+		Description:       req.Description,
+		CreatedAt:         now(),
+		UpdatedAt:         now(),
+		AutoscalerConfig:  &k8s.ClusterAutoscalerConfig{},
+		AutoUpgrade:       &k8s.ClusterAutoUpgrade{MaintenanceWindow: &k8s.MaintenanceWindow{}},
+		FeatureGates:      req.FeatureGates,
+		AdmissionPlugins:  req.AdmissionPlugins,
+		ApiserverCertSans: req.ApiserverCertSans,
+		PrivateNetworkID:  req.PrivateNetworkID,
+		PodCidr:           scw.IPNet{IPNet: *podCIDR},
+		ServiceCidr:       scw.IPNet{IPNet: *serviceCIDR},
+		ServiceDNSIP:      net.ParseIP("10.32.0.10"),
+	}
+
+	if cluster.Tags == nil {
+		cluster.Tags = []string{}
+	}
+
+	if cluster.Type == "" {
+		cluster.Type = "kapsule"
+	}
+
+	cluster.ClusterURL = fmt.Sprintf("https://%s.api.k8s.%s.scw.cloud:6443", cluster.ID, region)
+	cluster.DNSWildcard = fmt.Sprintf("*.%s.nodes.k8s.%s.scw.cloud", cluster.ID, region)
+
+	api.clusters.put(cluster.ID, cluster)
+	api.clusters.schedule(cluster.ID, setClusterStatus(k8s.ClusterStatusReady))
+
+	for _, poolConfig := range req.Pools {
+		api.newPool(cluster, &k8s.CreatePoolRequest{
+			Name:             poolConfig.Name,
+			NodeType:         poolConfig.NodeType,
+			PlacementGroupID: poolConfig.PlacementGroupID,
+			Autoscaling:      poolConfig.Autoscaling,
+			Size:             poolConfig.Size,
+			MinSize:          poolConfig.MinSize,
+			MaxSize:          poolConfig.MaxSize,
+			ContainerRuntime: poolConfig.ContainerRuntime,
+			Autohealing:      poolConfig.Autohealing,
+			Tags:             poolConfig.Tags,
+			KubeletArgs:      poolConfig.KubeletArgs,
+			Zone:             poolConfig.Zone,
+			RootVolumeType:   poolConfig.RootVolumeType,
+			RootVolumeSize:   poolConfig.RootVolumeSize,
+			PublicIPDisabled: poolConfig.PublicIPDisabled,
+			SecurityGroupID:  poolConfig.SecurityGroupID,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, cluster)
+}
+
+func setClusterStatus(status k8s.ClusterStatus) transition[*k8s.Cluster] {
+	return func(cluster *k8s.Cluster) bool {
+		cluster.Status = status
+
+		return true
+	}
+}
+
+func (api *k8sAPI) getCluster(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := api.findCluster(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, cluster)
+}
+
+func (api *k8sAPI) deleteCluster(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := api.findCluster(w, r)
+	if !ok {
+		return
+	}
+
+	cluster.Status = k8s.ClusterStatusDeleting
+	api.clusters.schedule(cluster.ID, func(cluster *k8s.Cluster) bool {
+		for _, pool := range api.pools.list(func(pool *k8s.Pool) bool { return pool.ClusterID == cluster.ID }) {
+			api.removePool(pool)
+		}
+
+		return false
+	})
+
+	writeJSON(w, http.StatusOK, cluster)
+}
+
+//
+// Pools
+//
+
+func (api *k8sAPI) findPool(w http.ResponseWriter, r *http.Request) (*k8s.Pool, bool) {
+	id := r.PathValue("pool_id")
+
+	pool, ok := api.pools.get(id)
+	if !ok || pool.Region != regionOf(r) {
+		writeNotFound(w, "k8s_pool", id)
+
+		return nil, false
+	}
+
+	return pool, true
+}
+
+func (api *k8sAPI) listPools(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := api.findCluster(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+
+	pools := api.pools.list(func(pool *k8s.Pool) bool {
+		switch {
+		case pool.ClusterID != cluster.ID:
+			return false
+		case query.Get("name") != "" && !strings.Contains(pool.Name, query.Get("name")):
+			return false
+		case query.Get("status") != "" && query.Get("status") != string(k8s.PoolStatusUnknown) && string(pool.Status) != query.Get("status"):
+			return false
+		}
+
+		return true
+	})
+
+	page, total := paginate(r, pools)
+
+	writeJSON(w, http.StatusOK, &k8s.ListPoolsResponse{
+		Pools:      page,
+		TotalCount: uint64(total),
+	})
+}
+
+// newPool creates a pool along with its nodes, the pool is ready once its nodes are
+func (api *k8sAPI) newPool(cluster *k8s.Cluster, req *k8s.CreatePoolRequest) *k8s.Pool {
+	pool := &k8s.Pool{
+		ID:               api.s.newID(),
+		ClusterID:        cluster.ID,
+		CreatedAt:        now(),
+		UpdatedAt:        now(),
+		Name:             req.Name,
+		Status:           k8s.PoolStatusScaling,
+		Version:          cluster.Version,
+		NodeType:         req.NodeType,
+		Autoscaling:      req.Autoscaling,
+		Size:             req.Size,
+		ContainerRuntime: req.ContainerRuntime,
+		Autohealing:      req.Autohealing,
+		Tags:             req.Tags,
+		PlacementGroupID: req.PlacementGroupID,
+		KubeletArgs:      req.KubeletArgs,
+		UpgradePolicy:    &k8s.PoolUpgradePolicy{MaxUnavailable: 1},
+		Zone:             req.Zone,
+		RootVolumeType:   req.RootVolumeType,
+		RootVolumeSize:   req.RootVolumeSize,
+		PublicIPDisabled: req.PublicIPDisabled,
+		Region:           cluster.Region,
+	}
+
+	if req.MinSize != nil {
+		pool.MinSize = *req.MinSize
+	}
+
+	if req.MaxSize != nil {
+		pool.MaxSize = *req.MaxSize
+	} else {
+		pool.MaxSize = pool.Size
+	}
+
+	if req.SecurityGroupID != nil {
+		pool.SecurityGroupID = *req.SecurityGroupID
+	}
+
+	if pool.Tags == nil {
+		pool.Tags = []string{}
+	}
+
+	if pool.KubeletArgs == nil {
+		pool.KubeletArgs = map[string]string{}
+	}
+
+	if pool.Zone == "" {
+		pool.Zone = scw.Zone(cluster.Region + "-1")
+	}
+
+	if pool.ContainerRuntime == "" || pool.ContainerRuntime == k8s.RuntimeUnknownRuntime {
+		pool.ContainerRuntime = k8s.RuntimeContainerd
+	}
+
+	if pool.RootVolumeType == "" || pool.RootVolumeType == k8s.PoolVolumeTypeDefaultVolumeType {
+		pool.RootVolumeType = k8s.PoolVolumeTypeSbs5k
+	}
+
+	api.pools.put(pool.ID, pool)
+	api.pools.schedule(pool.ID, setPoolStatus(k8s.PoolStatusReady))
+	api.scalePool(pool)
+
+	return pool
+}
+
+// scalePool creates or removes nodes so that the pool has as many nodes as its size
+func (api *k8sAPI) scalePool(pool *k8s.Pool) {
+	nodes := api.nodes.list(func(node *k8s.Node) bool { return node.PoolID == pool.ID })
+
+	for i := len(nodes); i < int(pool.Size); i++ {
+		node := &k8s.Node{
+			ID:         api.s.newID(),
+			PoolID:     pool.ID,
+			ClusterID:  pool.ClusterID,
+			ProviderID: fmt.Sprintf("scaleway://instance/%s/%s", pool.Zone, api.s.newID()),
+			Region:     pool.Region,
+			Name:       fmt.Sprintf("scw-%s-%s-%d", pool.ClusterID[len(pool.ClusterID)-8:], pool.Name, i),
+			Status:     k8s.NodeStatusCreating,
+			CreatedAt:  now(),
+			UpdatedAt:  now(),
+		}
+		api.nodes.put(node.ID, node)
+		api.nodes.schedule(node.ID, setNodeStatus(k8s.NodeStatusReady))
+	}
+
+	for i := int(pool.Size); i < len(nodes); i++ {
+		api.nodes.delete(nodes[i].ID)
+	}
+}
+
+func setPoolStatus(status k8s.PoolStatus) transition[*k8s.Pool] {
+	return func(pool *k8s.Pool) bool {
+		pool.Status = status
+
+		return true
+	}
+}
+
+func (api *k8sAPI) createPool(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := api.findCluster(w, r)
+	if !ok {
+		return
+	}
+
+	req := &k8s.CreatePoolRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	for _, pool := range api.pools.list(func(pool *k8s.Pool) bool { return pool.ClusterID == cluster.ID }) {
+		if pool.Name == req.Name {
+			writeError(w, http.StatusConflict, "conflict", fmt.Sprintf("a pool named %q already exists in the cluster", req.Name), nil)
+
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, api.newPool(cluster, req))
+}
+
+func (api *k8sAPI) getPool(w http.ResponseWriter, r *http.Request) {
+	pool, ok := api.findPool(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, pool)
+}
+
+func (api *k8sAPI) updatePool(w http.ResponseWriter, r *http.Request) {
+	pool, ok := api.findPool(w, r)
+	if !ok {
+		return
+	}
+
+	req := &k8s.UpdatePoolRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if req.Autoscaling != nil {
+		pool.Autoscaling = *req.Autoscaling
+	}
+
+	if req.MinSize != nil {
+		pool.MinSize = *req.MinSize
+	}
+
+	if req.MaxSize != nil {
+		pool.MaxSize = *req.MaxSize
+	}
+
+	if req.Autohealing != nil {
+		pool.Autohealing = *req.Autohealing
+	}
+
+	if req.Tags != nil {
+		pool.Tags = *req.Tags
+	}
+
+	if req.KubeletArgs != nil {
+		pool.KubeletArgs = *req.KubeletArgs
+	}
+
+	if req.SecurityGroupID != nil {
+		pool.SecurityGroupID = *req.SecurityGroupID
+	}
+
+	if req.Size != nil && *req.Size != pool.Size {
+		pool.Size = *req.Size
+		pool.Status = k8s.PoolStatusScaling
+		api.pools.schedule(pool.ID, setPoolStatus(k8s.PoolStatusReady))
+		api.scalePool(pool)
+	}
+
+	pool.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, pool)
+}
+
+func (api *k8sAPI) deletePool(w http.ResponseWriter, r *http.Request) {
+	pool, ok := api.findPool(w, r)
+	if !ok {
+		return
+	}
+
+	pool.Status = k8s.PoolStatusDeleting
+	api.pools.schedule(pool.ID, func(pool *k8s.Pool) bool {
+		api.removePool(pool)
+
+		return false
+	})
+
+	writeJSON(w, http.StatusOK, pool)
+}
+
+// removePool deletes a pool and its nodes
+func (api *k8sAPI) removePool(pool *k8s.Pool) {
+	for _, node := range api.nodes.list(func(node *k8s.Node) bool { return node.PoolID == pool.ID }) {
+		api.nodes.delete(node.ID)
+	}
+
+	api.pools.delete(pool.ID)
+}
+
+//
+// Nodes
+//
+
+func (api *k8sAPI) findNode(w http.ResponseWriter, r *http.Request) (*k8s.Node, bool) {
+	id := r.PathValue("node_id")
+
+	node, ok := api.nodes.get(id)
+	if !ok || node.Region != regionOf(r) {
+		writeNotFound(w, "k8s_node", id)
+
+		return nil, false
+	}
+
+	return node, true
+}
+
+func (api *k8sAPI) listNodes(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := api.findCluster(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+
+	nodes := api.nodes.list(func(node *k8s.Node) bool {
+		switch {
+		case node.ClusterID != cluster.ID:
+			return false
+		case query.Get("pool_id") != "" && node.PoolID != query.Get("pool_id"):
+			return false
+		case query.Get("name") != "" && !strings.Contains(node.Name, query.Get("name")):
+			return false
+		case query.Get("status") != "" && query.Get("status") != string(k8s.NodeStatusUnknown) && string(node.Status) != query.Get("status"):
+			return false
+		}
+
+		return true
+	})
+
+	page, total := paginate(r, nodes)
+
+	writeJSON(w, http.StatusOK, &k8s.ListNodesResponse{
+		Nodes:      page,
+		TotalCount: uint64(total),
+	})
+}
+
+func setNodeStatus(status k8s.NodeStatus) transition[*k8s.Node] {
+	return func(node *k8s.Node) bool {
+		node.Status = status
+		node.UpdatedAt = now()
+
+		return true
+	}
+}
+
+func (api *k8sAPI) getNode(w http.ResponseWriter, r *http.Request) {
+	node, ok := api.findNode(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, node)
+}
+
+func (api *k8sAPI) rebootNode(w http.ResponseWriter, r *http.Request) {
+	api.nodeAction(w, r, k8s.NodeStatusRebooting)
+}
+
+func (api *k8sAPI) replaceNode(w http.ResponseWriter, r *http.Request) {
+	api.nodeAction(w, r, k8s.NodeStatusCreating)
+}
+
+// nodeAction moves a ready node to the given transient status until the action is done
+func (api *k8sAPI) nodeAction(w http.ResponseWriter, r *http.Request, status k8s.NodeStatus) {
+	node, ok := api.findNode(w, r)
+	if !ok {
+		return
+	}
+
+	if api.nodes.busy(node.ID) || node.Status != k8s.NodeStatusReady {
+		writeTransientState(w, "k8s_node", node.ID, string(node.Status))
+
+		return
+	}
+
+	setNodeStatus(status)(node)
+	api.nodes.schedule(node.ID, setNodeStatus(k8s.NodeStatusReady))
+
+	writeJSON(w, http.StatusOK, node)
+}
@@ -0,0 +1,128 @@
+package mockapi
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const marketplacePrefix = "/marketplace/v2"
+
+// imageLabels are the marketplace images available in every zone for every commercial type
+var imageLabels = []string{
+	"debian_bookworm",
+	"ubuntu_focal",
+	"ubuntu_jammy",
+	"ubuntu_noble",
+}
+
+type marketplaceAPI struct {
+	s *Server
+
+	localImages map[string]*marketplace.LocalImage
+}
+
+func newMarketplaceAPI(s *Server) *marketplaceAPI {
+	return &marketplaceAPI{
+		s:           s,
+		localImages: map[string]*marketplace.LocalImage{},
+	}
+}
+
+func (api *marketplaceAPI) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+marketplacePrefix+"/local-images", api.listLocalImages)
+	mux.HandleFunc("GET "+marketplacePrefix+"/local-images/{local_image_id}", api.getLocalImage)
+}
+
+// localImage returns the local image of a label, its ID is generated on first use and then stays stable
+func (api *marketplaceAPI) localImage(label string, zone scw.Zone, imageType marketplace.LocalImageType) *marketplace.LocalImage {
+	key := label + "/" + zone.String() + "/" + imageType.String()
+
+	if image, ok := api.localImages[key]; ok {
+		return image
+	}
+
+	compatibleTypes := make([]string, 0, len(serverTypes))
+	for name := range serverTypes {
+		compatibleTypes = append(compatibleTypes, name)
+	}
+
+	slices.Sort(compatibleTypes)
+
+	image := &marketplace.LocalImage{
+		ID:                        api.s.newID(),
+		CompatibleCommercialTypes: compatibleTypes,
+		Arch:                      "x86_64",
+		Zone:                      zone,
+		Label:                     label,
+		Type:                      imageType,
+	}
+	api.localImages[key] = image
+
+	return image
+}
+
+// findLocalImageByID returns a local image that has already been listed
+func (api *marketplaceAPI) findLocalImageByID(id string) (*marketplace.LocalImage, bool) {
+	for _, image := range api.localImages {
+		if image.ID == id {
+			return image, true
+		}
+	}
+
+	return nil, false
+}
+
+func (api *marketplaceAPI) listLocalImages(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	zones := scw.AllZones
+	if query.Get("zone") != "" {
+		zones = []scw.Zone{scw.Zone(query.Get("zone"))}
+	}
+
+	imageTypes := []marketplace.LocalImageType{marketplace.LocalImageTypeInstanceLocal, marketplace.LocalImageTypeInstanceSbs}
+	if query.Get("type") != "" && query.Get("type") != marketplace.LocalImageTypeUnknownType.String() {
+		imageTypes = []marketplace.LocalImageType{marketplace.LocalImageType(query.Get("type"))}
+	}
+
+	labels := imageLabels
+	if query.Get("image_label") != "" {
+		labels = []string{query.Get("image_label")}
+		if !slices.Contains(imageLabels, query.Get("image_label")) {
+			labels = nil
+		}
+	}
+
+	images := []*marketplace.LocalImage{}
+
+	for _, label := range labels {
+		for _, zone := range zones {
+			for _, imageType := range imageTypes {
+				images = append(images, api.localImage(label, zone, imageType))
+			}
+		}
+	}
+
+	page, total := paginate(r, images)
+
+	writeJSON(w, http.StatusOK, &marketplace.ListLocalImagesResponse{
+		LocalImages: page,
+		TotalCount:  uint32(total),
+	})
+}
+
+func (api *marketplaceAPI) getLocalImage(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("local_image_id")
+
+	image, ok := api.findLocalImageByID(id)
+	if !ok {
+		writeNotFound(w, "local_image", id)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, image)
+}
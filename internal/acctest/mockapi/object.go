@@ -0,0 +1,179 @@
+package mockapi
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// forwardedHostHeader keeps the host requested by the client, the object storage API identifies the bucket by its host
+const forwardedHostHeader = "X-Forwarded-Host"
+
+type bucket struct {
+	name      string
+	region    scw.Region
+	projectID string
+}
+
+type objectAPI struct {
+	s *Server
+
+	buckets map[string]*bucket
+}
+
+func newObjectAPI(s *Server) *objectAPI {
+	return &objectAPI{
+		s:       s,
+		buckets: map[string]*bucket{},
+	}
+}
+
+func (api *objectAPI) register(mux *http.ServeMux) {
+	mux.HandleFunc("PUT /{$}", api.createBucket)
+	mux.HandleFunc("HEAD /{$}", api.headBucket)
+	mux.HandleFunc("GET /{$}", api.getBucket)
+	mux.HandleFunc("DELETE /{$}", api.deleteBucket)
+}
+
+type s3Owner struct {
+	ID          string `xml:"ID"`
+	DisplayName string `xml:"DisplayName"`
+}
+
+type s3AccessControlPolicy struct {
+	XMLName           xml.Name `xml:"AccessControlPolicy"`
+	Owner             s3Owner  `xml:"Owner"`
+	AccessControlList struct{} `xml:"AccessControlList"`
+}
+
+type s3ListBucketResult struct {
+	XMLName     xml.Name `xml:"ListBucketResult"`
+	Name        string   `xml:"Name"`
+	IsTruncated bool     `xml:"IsTruncated"`
+}
+
+type s3VersioningConfiguration struct {
+	XMLName xml.Name `xml:"VersioningConfiguration"`
+}
+
+// bucketOf returns the name and the region of the bucket from the virtual host requested by the client
+func bucketOf(r *http.Request) (string, scw.Region) {
+	host := r.Header.Get(forwardedHostHeader)
+
+	name, endpoint, found := strings.Cut(host, ".s3.")
+	if !found {
+		return "", ""
+	}
+
+	return name, scw.Region(strings.TrimSuffix(endpoint, ".scw.cloud"))
+}
+
+func (api *objectAPI) findBucket(w http.ResponseWriter, r *http.Request) (*bucket, bool) {
+	name, region := bucketOf(r)
+
+	b, ok := api.buckets[name]
+	if !ok || b.region != region {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+
+		return nil, false
+	}
+
+	return b, true
+}
+
+func (api *objectAPI) createBucket(w http.ResponseWriter, r *http.Request) {
+	if len(r.URL.Query()) > 0 {
+		writeS3Error(w, http.StatusNotImplemented, "NotImplemented", "bucket configurations are not implemented by the mock API")
+
+		return
+	}
+
+	name, region := bucketOf(r)
+	if name == "" {
+		writeS3Error(w, http.StatusBadRequest, "InvalidBucketName", "The specified bucket is not valid")
+
+		return
+	}
+
+	if _, exists := api.buckets[name]; exists {
+		writeS3Error(w, http.StatusConflict, "BucketAlreadyExists", "The requested bucket name is not available")
+
+		return
+	}
+
+	api.buckets[name] = &bucket{
+		name:      name,
+		region:    region,
+		projectID: DefaultProjectID,
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (api *objectAPI) headBucket(w http.ResponseWriter, r *http.Request) {
+	if _, ok := api.findBucket(w, r); !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// getBucket returns the configuration selected by the query, buckets of the mock API have no configuration set
+func (api *objectAPI) getBucket(w http.ResponseWriter, r *http.Request) {
+	b, ok := api.findBucket(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+
+	switch {
+	case query.Has("acl"):
+		writeXML(w, &s3AccessControlPolicy{Owner: s3Owner{ID: b.projectID + ":" + b.projectID, DisplayName: b.projectID}})
+	case query.Has("object-lock"):
+		writeS3Error(w, http.StatusNotFound, "ObjectLockConfigurationNotFoundError", "Object Lock configuration does not exist for this bucket")
+	case query.Has("tagging"):
+		writeS3Error(w, http.StatusNotFound, "NoSuchTagSet", "The TagSet does not exist")
+	case query.Has("cors"):
+		writeS3Error(w, http.StatusNotFound, "NoSuchCORSConfiguration", "The CORS configuration does not exist")
+	case query.Has("versioning"):
+		writeXML(w, &s3VersioningConfiguration{})
+	case query.Has("lifecycle"):
+		writeS3Error(w, http.StatusNotFound, "NoSuchLifecycleConfiguration", "The lifecycle configuration does not exist")
+	case len(query) == 0 || query.Has("list-type") || query.Has("prefix") || query.Has("marker"):
+		writeXML(w, &s3ListBucketResult{Name: b.name})
+	default:
+		writeS3Error(w, http.StatusNotImplemented, "NotImplemented", "this bucket configuration is not implemented by the mock API")
+	}
+}
+
+func (api *objectAPI) deleteBucket(w http.ResponseWriter, r *http.Request) {
+	b, ok := api.findBucket(w, r)
+	if !ok {
+		return
+	}
+
+	delete(api.buckets, b.name)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeXML(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+
+	_ = xml.NewEncoder(w).Encode(body)
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}{Code: code, Message: message})
+}
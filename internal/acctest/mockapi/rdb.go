@@ -0,0 +1,201 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+)
+
+const rdbPrefix = "/rdb/v1/regions/{region}"
+
+type rdbAPI struct {
+	s *Server
+
+	instances *store[*rdb.Instance]
+	databases map[string][]*rdb.Database
+}
+
+func newRDBAPI(s *Server) *rdbAPI {
+	return &rdbAPI{
+		s:         s,
+		instances: newStore[*rdb.Instance](),
+		databases: map[string][]*rdb.Database{},
+	}
+}
+
+func (api *rdbAPI) register(mux *http.ServeMux) {
+	mux.HandleFunc("POST "+rdbPrefix+"/instances", api.createInstance)
+	mux.HandleFunc("GET "+rdbPrefix+"/instances/{instance_id}", api.getInstance)
+	mux.HandleFunc("DELETE "+rdbPrefix+"/instances/{instance_id}", api.deleteInstance)
+
+	mux.HandleFunc("GET "+rdbPrefix+"/instances/{instance_id}/databases", api.listDatabases)
+	mux.HandleFunc("POST "+rdbPrefix+"/instances/{instance_id}/databases", api.createDatabase)
+	mux.HandleFunc("DELETE "+rdbPrefix+"/instances/{instance_id}/databases/{name}", api.deleteDatabase)
+}
+
+//
+// Instances
+//
+
+func (api *rdbAPI) findInstance(w http.ResponseWriter, r *http.Request) (*rdb.Instance, bool) {
+	id := r.PathValue("instance_id")
+
+	instance, ok := api.instances.get(id)
+	if !ok || instance.Region != regionOf(r) {
+		writeNotFound(w, "instance", id)
+
+		return nil, false
+	}
+
+	return instance, true
+}
+
+func (api *rdbAPI) createInstance(w http.ResponseWriter, r *http.Request) {
+	req := &rdb.CreateInstanceRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	instance := &rdb.Instance{
+		CreatedAt:      now(),
+		Volume:         &rdb.Volume{Type: req.VolumeType, Size: req.VolumeSize},
+		Region:         regionOf(r),
+		ID:             api.s.newID(),
+		Name:           req.Name,
+		OrganizationID: DefaultOrganizationID,
+		ProjectID:      projectOrDefault(req.ProjectID),
+		Status:         rdb.InstanceStatusProvisioning,
+		Engine:         req.Engine,
+		Tags:           req.Tags,
+		Settings:       []*rdb.InstanceSetting{},
+		BackupSchedule: &rdb.BackupSchedule{Disabled: req.DisableBackup},
+		IsHaCluster:    req.IsHaCluster,
+		ReadReplicas:   []*rdb.ReadReplica{},
+		NodeType:       req.NodeType,
+		InitSettings:   req.InitSettings,
+		Endpoints:      []*rdb.Endpoint{},
+		LogsPolicy:     &rdb.LogsPolicy{},
+		Maintenances:   []*rdb.Maintenance{},
+		Encryption:     &rdb.EncryptionAtRest{},
+	}
+
+	if instance.Tags == nil {
+		instance.Tags = []string{}
+	}
+
+	api.instances.put(instance.ID, instance)
+	api.instances.schedule(instance.ID, func(instance *rdb.Instance) bool {
+		instance.Status = rdb.InstanceStatusReady
+
+		return true
+	})
+
+	api.databases[instance.ID] = []*rdb.Database{{Name: "rdb", Owner: req.UserName, Managed: true}}
+
+	writeJSON(w, http.StatusOK, instance)
+}
+
+func (api *rdbAPI) getInstance(w http.ResponseWriter, r *http.Request) {
+	instance, ok := api.findInstance(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, instance)
+}
+
+func (api *rdbAPI) deleteInstance(w http.ResponseWriter, r *http.Request) {
+	instance, ok := api.findInstance(w, r)
+	if !ok {
+		return
+	}
+
+	instance.Status = rdb.InstanceStatusDeleting
+	api.instances.schedule(instance.ID, func(instance *rdb.Instance) bool {
+		delete(api.databases, instance.ID)
+
+		return false
+	})
+
+	writeJSON(w, http.StatusOK, instance)
+}
+
+//
+// Databases
+//
+
+func (api *rdbAPI) listDatabases(w http.ResponseWriter, r *http.Request) {
+	instance, ok := api.findInstance(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+
+	databases := []*rdb.Database{}
+	for _, database := range api.databases[instance.ID] {
+		if query.Get("name") == "" || database.Name == query.Get("name") {
+			databases = append(databases, database)
+		}
+	}
+
+	page, total := paginate(r, databases)
+
+	writeJSON(w, http.StatusOK, &rdb.ListDatabasesResponse{
+		Databases:  page,
+		TotalCount: uint32(total),
+	})
+}
+
+func (api *rdbAPI) createDatabase(w http.ResponseWriter, r *http.Request) {
+	instance, ok := api.findInstance(w, r)
+	if !ok {
+		return
+	}
+
+	if api.instances.busy(instance.ID) {
+		writeTransientState(w, "instance", instance.ID, string(instance.Status))
+
+		return
+	}
+
+	req := &rdb.CreateDatabaseRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	for _, database := range api.databases[instance.ID] {
+		if database.Name == req.Name {
+			writeError(w, http.StatusConflict, "conflict", fmt.Sprintf("database %q already exists", req.Name), nil)
+
+			return
+		}
+	}
+
+	database := &rdb.Database{Name: req.Name}
+	api.databases[instance.ID] = append(api.databases[instance.ID], database)
+
+	writeJSON(w, http.StatusOK, database)
+}
+
+func (api *rdbAPI) deleteDatabase(w http.ResponseWriter, r *http.Request) {
+	instance, ok := api.findInstance(w, r)
+	if !ok {
+		return
+	}
+
+	name := r.PathValue("name")
+
+	i := slices.IndexFunc(api.databases[instance.ID], func(database *rdb.Database) bool { return database.Name == name })
+	if i < 0 {
+		writeNotFound(w, "database", name)
+
+		return
+	}
+
+	api.databases[instance.ID] = slices.Delete(api.databases[instance.ID], i, i+1)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,413 @@
+package mockapi
+
+import (
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"slices"
+	"strconv"
+
+	secret "github.com/scaleway/scaleway-sdk-go/api/secret/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const secretPrefix = "/secret-manager/v1beta1/regions/{region}"
+
+type secretVersion struct {
+	*secret.SecretVersion
+
+	data []byte
+}
+
+type secretAPI struct {
+	s *Server
+
+	secrets  *store[*secret.Secret]
+	versions map[string][]*secretVersion
+}
+
+func newSecretAPI(s *Server) *secretAPI {
+	return &secretAPI{
+		s:        s,
+		secrets:  newStore[*secret.Secret](),
+		versions: map[string][]*secretVersion{},
+	}
+}
+
+func (api *secretAPI) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+secretPrefix+"/secrets", api.listSecrets)
+	mux.HandleFunc("POST "+secretPrefix+"/secrets", api.createSecret)
+	mux.HandleFunc("GET "+secretPrefix+"/secrets/{secret_id}", api.getSecret)
+	mux.HandleFunc("PATCH "+secretPrefix+"/secrets/{secret_id}", api.updateSecret)
+	mux.HandleFunc("DELETE "+secretPrefix+"/secrets/{secret_id}", api.deleteSecret)
+	mux.HandleFunc("POST "+secretPrefix+"/secrets/{secret_id}/protect", api.protectSecret(true))
+	mux.HandleFunc("POST "+secretPrefix+"/secrets/{secret_id}/unprotect", api.protectSecret(false))
+
+	mux.HandleFunc("GET "+secretPrefix+"/secrets/{secret_id}/versions", api.listSecretVersions)
+	mux.HandleFunc("POST "+secretPrefix+"/secrets/{secret_id}/versions", api.createSecretVersion)
+	mux.HandleFunc("GET "+secretPrefix+"/secrets/{secret_id}/versions/{revision}", api.getSecretVersion)
+	mux.HandleFunc("PATCH "+secretPrefix+"/secrets/{secret_id}/versions/{revision}", api.updateSecretVersion)
+	mux.HandleFunc("DELETE "+secretPrefix+"/secrets/{secret_id}/versions/{revision}", api.deleteSecretVersion)
+	mux.HandleFunc("GET "+secretPrefix+"/secrets/{secret_id}/versions/{revision}/access", api.accessSecretVersion)
+}
+
+//
+// Secrets
+//
+
+func (api *secretAPI) findSecret(w http.ResponseWriter, r *http.Request) (*secret.Secret, bool) {
+	id := r.PathValue("secret_id")
+
+	sec, ok := api.secrets.get(id)
+	if !ok || sec.Region != regionOf(r) {
+		writeNotFound(w, "secret", id)
+
+		return nil, false
+	}
+
+	return sec, true
+}
+
+func (api *secretAPI) listSecrets(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	region := regionOf(r)
+
+	secrets := api.secrets.list(func(sec *secret.Secret) bool {
+		switch {
+		case sec.Region != region:
+			return false
+		case query.Get("name") != "" && sec.Name != query.Get("name"):
+			return false
+		case query.Get("path") != "" && sec.Path != query.Get("path"):
+			return false
+		case query.Get("project_id") != "" && sec.ProjectID != query.Get("project_id"):
+			return false
+		case query.Get("type") != "" && string(sec.Type) != query.Get("type"):
+			return false
+		case !hasTags(sec.Tags, query["tags"]):
+			return false
+		}
+
+		return true
+	})
+
+	page, total := paginate(r, secrets)
+
+	writeJSON(w, http.StatusOK, &secret.ListSecretsResponse{
+		Secrets:    page,
+		TotalCount: uint64(total),
+	})
+}
+
+func (api *secretAPI) createSecret(w http.ResponseWriter, r *http.Request) {
+	req := &secret.CreateSecretRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	region := regionOf(r)
+
+	sec := &secret.Secret{
+		ID:              api.s.newID(),
+		ProjectID:       projectOrDefault(&req.ProjectID),
+		Name:            req.Name,
+		Status:          secret.SecretStatusReady,
+		CreatedAt:       now(),
+		UpdatedAt:       now(),
+		Tags:            req.Tags,
+		Description:     req.Description,
+		Protected:       req.Protected,
+		Type:            req.Type,
+		Path:            "/",
+		EphemeralPolicy: req.EphemeralPolicy,
+		UsedBy:          []secret.Product{},
+		KeyID:           req.KeyID,
+		Region:          region,
+	}
+
+	if req.Path != nil {
+		sec.Path = *req.Path
+	}
+
+	if sec.Tags == nil {
+		sec.Tags = []string{}
+	}
+
+	if sec.Type == "" || sec.Type == secret.SecretTypeUnknownType {
+		sec.Type = secret.SecretTypeOpaque
+	}
+
+	for _, existing := range api.secrets.list(nil) {
+		if existing.Region == region && existing.ProjectID == sec.ProjectID && existing.Path == sec.Path && existing.Name == sec.Name {
+			writeError(w, http.StatusConflict, "conflict", fmt.Sprintf("a secret named %q already exists at path %q", sec.Name, sec.Path), nil)
+
+			return
+		}
+	}
+
+	api.secrets.put(sec.ID, sec)
+
+	writeJSON(w, http.StatusOK, sec)
+}
+
+func (api *secretAPI) getSecret(w http.ResponseWriter, r *http.Request) {
+	sec, ok := api.findSecret(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, sec)
+}
+
+func (api *secretAPI) updateSecret(w http.ResponseWriter, r *http.Request) {
+	sec, ok := api.findSecret(w, r)
+	if !ok {
+		return
+	}
+
+	req := &secret.UpdateSecretRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if req.Name != nil {
+		sec.Name = *req.Name
+	}
+
+	if req.Tags != nil {
+		sec.Tags = *req.Tags
+	}
+
+	if req.Description != nil {
+		sec.Description = req.Description
+	}
+
+	if req.Path != nil {
+		sec.Path = *req.Path
+	}
+
+	if req.EphemeralPolicy != nil {
+		sec.EphemeralPolicy = req.EphemeralPolicy
+	}
+
+	sec.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, sec)
+}
+
+func (api *secretAPI) deleteSecret(w http.ResponseWriter, r *http.Request) {
+	sec, ok := api.findSecret(w, r)
+	if !ok {
+		return
+	}
+
+	if sec.Protected {
+		writeError(w, http.StatusPreconditionFailed, "precondition_failed", "a protected secret cannot be deleted", map[string]any{
+			"precondition": "resource_still_in_use",
+		})
+
+		return
+	}
+
+	delete(api.versions, sec.ID)
+	api.secrets.delete(sec.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *secretAPI) protectSecret(protected bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sec, ok := api.findSecret(w, r)
+		if !ok {
+			return
+		}
+
+		sec.Protected = protected
+		sec.UpdatedAt = now()
+
+		writeJSON(w, http.StatusOK, sec)
+	}
+}
+
+//
+// Versions
+//
+
+// findSecretVersion resolves a revision number or one of the latest and latest_enabled aliases
+func (api *secretAPI) findSecretVersion(w http.ResponseWriter, r *http.Request, sec *secret.Secret) (*secretVersion, bool) {
+	revision := r.PathValue("revision")
+	versions := api.versions[sec.ID]
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		version := versions[i]
+
+		switch {
+		case version.Status == secret.SecretVersionStatusDeleted:
+			continue
+		case revision == "latest",
+			revision == "latest_enabled" && version.Status == secret.SecretVersionStatusEnabled,
+			revision == strconv.FormatUint(uint64(version.Revision), 10):
+			return version, true
+		}
+	}
+
+	writeNotFound(w, "secret_version", revision)
+
+	return nil, false
+}
+
+func (api *secretAPI) listSecretVersions(w http.ResponseWriter, r *http.Request) {
+	sec, ok := api.findSecret(w, r)
+	if !ok {
+		return
+	}
+
+	statuses := r.URL.Query()["status"]
+	versions := []*secret.SecretVersion{}
+
+	for _, version := range api.versions[sec.ID] {
+		if len(statuses) > 0 && !slices.Contains(statuses, string(version.Status)) {
+			continue
+		}
+
+		versions = append(versions, version.SecretVersion)
+	}
+
+	page, total := paginate(r, versions)
+
+	writeJSON(w, http.StatusOK, &secret.ListSecretVersionsResponse{
+		Versions:   page,
+		TotalCount: uint64(total),
+	})
+}
+
+func (api *secretAPI) createSecretVersion(w http.ResponseWriter, r *http.Request) {
+	sec, ok := api.findSecret(w, r)
+	if !ok {
+		return
+	}
+
+	req := &secret.CreateSecretVersionRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if req.DataCrc32 != nil && *req.DataCrc32 != crc32.ChecksumIEEE(req.Data) {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", "data_crc32 does not match the data", nil)
+
+		return
+	}
+
+	for _, version := range api.versions[sec.ID] {
+		version.Latest = false
+
+		if req.DisablePrevious != nil && *req.DisablePrevious && version.Status == secret.SecretVersionStatusEnabled {
+			version.Status = secret.SecretVersionStatusDisabled
+		}
+	}
+
+	version := &secretVersion{
+		SecretVersion: &secret.SecretVersion{
+			Revision:    uint32(len(api.versions[sec.ID]) + 1),
+			SecretID:    sec.ID,
+			Status:      secret.SecretVersionStatusEnabled,
+			CreatedAt:   now(),
+			UpdatedAt:   now(),
+			Description: req.Description,
+			Latest:      true,
+			Region:      sec.Region,
+		},
+		data: req.Data,
+	}
+
+	api.versions[sec.ID] = append(api.versions[sec.ID], version)
+	sec.VersionCount++
+
+	writeJSON(w, http.StatusOK, version.SecretVersion)
+}
+
+func (api *secretAPI) getSecretVersion(w http.ResponseWriter, r *http.Request) {
+	sec, ok := api.findSecret(w, r)
+	if !ok {
+		return
+	}
+
+	version, ok := api.findSecretVersion(w, r, sec)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, version.SecretVersion)
+}
+
+func (api *secretAPI) updateSecretVersion(w http.ResponseWriter, r *http.Request) {
+	sec, ok := api.findSecret(w, r)
+	if !ok {
+		return
+	}
+
+	version, ok := api.findSecretVersion(w, r, sec)
+	if !ok {
+		return
+	}
+
+	req := &secret.UpdateSecretVersionRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if req.Description != nil {
+		version.Description = req.Description
+	}
+
+	version.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, version.SecretVersion)
+}
+
+func (api *secretAPI) deleteSecretVersion(w http.ResponseWriter, r *http.Request) {
+	sec, ok := api.findSecret(w, r)
+	if !ok {
+		return
+	}
+
+	version, ok := api.findSecretVersion(w, r, sec)
+	if !ok {
+		return
+	}
+
+	version.Status = secret.SecretVersionStatusDeleted
+	version.DeletedAt = now()
+	version.data = nil
+	sec.VersionCount--
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *secretAPI) accessSecretVersion(w http.ResponseWriter, r *http.Request) {
+	sec, ok := api.findSecret(w, r)
+	if !ok {
+		return
+	}
+
+	version, ok := api.findSecretVersion(w, r, sec)
+	if !ok {
+		return
+	}
+
+	if version.Status != secret.SecretVersionStatusEnabled {
+		writeError(w, http.StatusPreconditionFailed, "precondition_failed", "the secret version is not enabled", map[string]any{
+			"precondition": "resource_not_usable",
+		})
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &secret.AccessSecretVersionResponse{
+		SecretID:  sec.ID,
+		Revision:  version.Revision,
+		Data:      version.data,
+		DataCrc32: scw.Uint32Ptr(crc32.ChecksumIEEE(version.data)),
+		Type:      sec.Type,
+	})
+}
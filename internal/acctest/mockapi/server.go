@@ -0,0 +1,222 @@
+// Package mockapi provides an in-process fake of the Scaleway API.
+// It keeps the state of the resources created during a test so that acceptance tests can run without cassettes.
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// DefaultProjectID is the project used when a request does not specify one
+const DefaultProjectID = "11111111-1111-1111-1111-111111111111"
+
+// DefaultOrganizationID is the organization owning every resource of the mock
+const DefaultOrganizationID = "22222222-2222-2222-2222-222222222222"
+
+// Server is a fake Scaleway API served by an httptest server.
+// Every API keeps its resources in memory and simulates the status transitions expected by the SDK waiters.
+type Server struct {
+	*httptest.Server
+
+	mu  sync.Mutex
+	mux *http.ServeMux
+	ids int
+
+	instance    *instanceAPI
+	block       *blockAPI
+	marketplace *marketplaceAPI
+	vpc         *vpcAPI
+	ipam        *ipamAPI
+	iam         *iamAPI
+	secret      *secretAPI
+	k8s         *k8sAPI
+	rdb         *rdbAPI
+	object      *objectAPI
+}
+
+// NewServer starts a new mock API server. It must be closed once done with it.
+func NewServer() *Server {
+	s := &Server{
+		mux: http.NewServeMux(),
+	}
+
+	s.instance = newInstanceAPI(s)
+	s.block = newBlockAPI(s)
+	s.marketplace = newMarketplaceAPI(s)
+	s.vpc = newVPCAPI(s)
+	s.ipam = newIPAMAPI(s)
+	s.iam = newIAMAPI(s)
+	s.secret = newSecretAPI(s)
+	s.k8s = newK8SAPI(s)
+	s.rdb = newRDBAPI(s)
+	s.object = newObjectAPI(s)
+
+	s.instance.register(s.mux)
+	s.block.register(s.mux)
+	s.marketplace.register(s.mux)
+	s.vpc.register(s.mux)
+	s.ipam.register(s.mux)
+	s.iam.register(s.mux)
+	s.secret.register(s.mux)
+	s.k8s.register(s.mux)
+	s.rdb.register(s.mux)
+	s.object.register(s.mux)
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Client returns an HTTP client sending every request to the mock server whatever the requested host.
+func (s *Server) Client() *http.Client {
+	target, _ := url.Parse(s.URL)
+
+	return &http.Client{
+		Transport: &redirectTransport{
+			target: target,
+			next:   s.Server.Client().Transport,
+		},
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, pattern := s.mux.Handler(r)
+	if pattern == "" {
+		writeError(w, http.StatusNotImplemented, "not_implemented", fmt.Sprintf("%s %s is not implemented by the mock API", r.Method, r.URL.Path), nil)
+
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// newID returns a new unique UUID formatted identifier
+func (s *Server) newID() string {
+	s.ids++
+
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.ids)
+}
+
+type redirectTransport struct {
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (t *redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set(forwardedHostHeader, r.URL.Host)
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	r.Host = t.target.Host
+
+	return t.next.RoundTrip(r)
+}
+
+func now() *time.Time {
+	t := time.Now().UTC().Truncate(time.Second)
+
+	return &t
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, req any) bool {
+	if r.ContentLength == 0 {
+		return true
+	}
+
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_arguments", "invalid request body: "+err.Error(), nil)
+
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if body != nil {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, errorType string, message string, fields map[string]any) {
+	body := map[string]any{
+		"type":    errorType,
+		"message": message,
+	}
+	for k, v := range fields {
+		body[k] = v
+	}
+
+	writeJSON(w, status, body)
+}
+
+func writeNotFound(w http.ResponseWriter, resource string, id string) {
+	writeError(w, http.StatusNotFound, "not_found", "resource is not found", map[string]any{
+		"resource":    resource,
+		"resource_id": id,
+	})
+}
+
+func writeTransientState(w http.ResponseWriter, resource string, id string, state string) {
+	writeError(w, http.StatusConflict, "transient_state", "resource is in a transient state", map[string]any{
+		"resource":      resource,
+		"resource_id":   id,
+		"current_state": state,
+	})
+}
+
+// paginate returns the requested page of items along with the total count.
+// The page size query parameter is named per_page on the instance API and page_size on the others.
+func paginate[T any](r *http.Request, items []T) ([]T, int) {
+	query := r.URL.Query()
+
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(query.Get("page_size"))
+	if err != nil {
+		pageSize, err = strconv.Atoi(query.Get("per_page"))
+	}
+
+	if err != nil || pageSize < 1 {
+		pageSize = 50
+	}
+
+	start := min((page-1)*pageSize, len(items))
+	end := min(start+pageSize, len(items))
+
+	return items[start:end], len(items)
+}
+
+func projectOrDefault(project *string) string {
+	if project == nil || *project == "" {
+		return DefaultProjectID
+	}
+
+	return *project
+}
+
+func regionOf(r *http.Request) scw.Region {
+	return scw.Region(r.PathValue("region"))
+}
+
+func zoneOf(r *http.Request) scw.Zone {
+	return scw.Zone(r.PathValue("zone"))
+}
@@ -0,0 +1,525 @@
+package mockapi_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	secret "github.com/scaleway/scaleway-sdk-go/api/secret/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest/mockapi"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIDjfkdWCwkYlVQMDUfiZlVrmjaGOfBYnmkucssae8Iup opensource@scaleway.com"
+
+func newClient(t *testing.T) *scw.Client {
+	t.Helper()
+
+	server := mockapi.NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := scw.NewClient(
+		scw.WithHTTPClient(server.Client()),
+		scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "00000000-0000-0000-0000-000000000000"),
+		scw.WithDefaultProjectID(mockapi.DefaultProjectID),
+		scw.WithDefaultZone(scw.ZoneFrPar1),
+		scw.WithDefaultRegion(scw.RegionFrPar),
+	)
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestInstanceServer(t *testing.T) {
+	api := instance.NewAPI(newClient(t))
+	retryInterval := time.Duration(0)
+
+	ip, err := api.CreateIP(&instance.CreateIPRequest{Type: instance.IPTypeRoutedIPv4})
+	require.NoError(t, err)
+
+	created, err := api.CreateServer(&instance.CreateServerRequest{
+		Name:           "test",
+		CommercialType: "DEV1-S",
+		Image:          new("ubuntu_jammy"),
+		PublicIPs:      &[]string{ip.IP.ID},
+		Tags:           []string{"foo"},
+	})
+	require.NoError(t, err)
+
+	server := created.Server
+	assert.Equal(t, instance.ServerStateStopped, server.State)
+	assert.Equal(t, mockapi.DefaultProjectID, server.Project)
+	require.Len(t, server.PublicIPs, 1)
+	assert.Equal(t, ip.IP.ID, server.PublicIPs[0].ID)
+	require.Contains(t, server.Volumes, "0")
+	assert.Equal(t, instance.VolumeServerVolumeTypeLSSD, server.Volumes["0"].VolumeType)
+
+	_, err = api.ServerAction(&instance.ServerActionRequest{ServerID: server.ID, Action: instance.ServerActionPoweron})
+	require.NoError(t, err)
+
+	got, err := api.GetServer(&instance.GetServerRequest{ServerID: server.ID})
+	require.NoError(t, err)
+	assert.Equal(t, instance.ServerStateStarting, got.Server.State)
+
+	server, err = api.WaitForServer(&instance.WaitForServerRequest{ServerID: server.ID, RetryInterval: &retryInterval})
+	require.NoError(t, err)
+	assert.Equal(t, instance.ServerStateRunning, server.State)
+
+	err = api.DeleteServer(&instance.DeleteServerRequest{ServerID: server.ID})
+	require.Error(t, err, "a running server cannot be deleted")
+
+	err = api.ServerActionAndWait(&instance.ServerActionAndWaitRequest{ServerID: server.ID, Action: instance.ServerActionPoweroff, RetryInterval: &retryInterval})
+	require.NoError(t, err)
+
+	updated, err := api.UpdateServer(&instance.UpdateServerRequest{ServerID: server.ID, Name: new("renamed"), PublicIPs: &[]string{}, DynamicIPRequired: new(false)})
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", updated.Server.Name)
+	assert.Empty(t, updated.Server.PublicIPs)
+
+	err = api.DeleteServer(&instance.DeleteServerRequest{ServerID: server.ID})
+	require.NoError(t, err)
+
+	_, err = api.GetServer(&instance.GetServerRequest{ServerID: server.ID})
+	assert.True(t, httperrors.Is404(err))
+
+	detachedIP, err := api.GetIP(&instance.GetIPRequest{IP: ip.IP.ID})
+	require.NoError(t, err)
+	assert.Equal(t, instance.IPStateDetached, detachedIP.IP.State)
+	assert.Nil(t, detachedIP.IP.Server)
+}
+
+func TestInstanceServerTerminate(t *testing.T) {
+	api := instance.NewAPI(newClient(t))
+
+	created, err := api.CreateServer(&instance.CreateServerRequest{
+		Name:           "test",
+		CommercialType: "DEV1-S",
+		Image:          new("ubuntu_jammy"),
+	})
+	require.NoError(t, err)
+
+	volumeID := created.Server.Volumes["0"].ID
+
+	_, err = api.ServerAction(&instance.ServerActionRequest{ServerID: created.Server.ID, Action: instance.ServerActionTerminate})
+	require.NoError(t, err)
+
+	got, err := api.GetServer(&instance.GetServerRequest{ServerID: created.Server.ID})
+	require.NoError(t, err)
+	assert.Equal(t, instance.ServerStateStopping, got.Server.State)
+
+	_, err = api.GetServer(&instance.GetServerRequest{ServerID: created.Server.ID})
+	assert.True(t, httperrors.Is404(err))
+
+	_, err = api.GetVolume(&instance.GetVolumeRequest{VolumeID: volumeID})
+	assert.True(t, httperrors.Is404(err), "local volumes are deleted with the server")
+}
+
+func TestInstanceServerUnknownCommercialType(t *testing.T) {
+	api := instance.NewAPI(newClient(t))
+
+	_, err := api.CreateServer(&instance.CreateServerRequest{
+		Name:           "test",
+		CommercialType: "UNKNOWN",
+	})
+	require.ErrorAs(t, err, new(*scw.InvalidArgumentsError))
+}
+
+func TestInstanceVolumeResize(t *testing.T) {
+	api := instance.NewAPI(newClient(t))
+	retryInterval := time.Duration(0)
+
+	created, err := api.CreateVolume(&instance.CreateVolumeRequest{
+		Name:       "test",
+		VolumeType: instance.VolumeVolumeTypeBSSD,
+		Size:       scw.SizePtr(10 * scw.GB),
+	})
+	require.NoError(t, err)
+
+	updated, err := api.UpdateVolume(&instance.UpdateVolumeRequest{VolumeID: created.Volume.ID, Size: scw.SizePtr(20 * scw.GB)})
+	require.NoError(t, err)
+	assert.Equal(t, instance.VolumeStateResizing, updated.Volume.State)
+
+	volume, err := api.WaitForVolume(&instance.WaitForVolumeRequest{VolumeID: created.Volume.ID, RetryInterval: &retryInterval})
+	require.NoError(t, err)
+	assert.Equal(t, instance.VolumeStateAvailable, volume.State)
+	assert.Equal(t, 20*scw.GB, volume.Size)
+
+	_, err = api.UpdateVolume(&instance.UpdateVolumeRequest{VolumeID: created.Volume.ID, Size: scw.SizePtr(5 * scw.GB)})
+	require.Error(t, err)
+}
+
+func TestInstanceServerBlockVolumes(t *testing.T) {
+	client := newClient(t)
+	instanceAPI := instance.NewAPI(client)
+	blockAPI := block.NewAPI(client)
+	retryInterval := time.Duration(0)
+
+	created, err := instanceAPI.CreateServer(&instance.CreateServerRequest{
+		Name:           "test",
+		CommercialType: "PLAY2-PICO",
+		Image:          new("ubuntu_jammy"),
+		Volumes: map[string]*instance.VolumeServerTemplate{
+			"0": {VolumeType: instance.VolumeVolumeTypeSbsVolume, Size: scw.SizePtr(20 * scw.GB)},
+		},
+	})
+	require.NoError(t, err)
+
+	volumeID := created.Server.Volumes["0"].ID
+
+	volume, err := blockAPI.GetVolume(&block.GetVolumeRequest{VolumeID: volumeID})
+	require.NoError(t, err)
+	assert.Equal(t, block.VolumeStatusInUse, volume.Status)
+	require.Len(t, volume.References, 1)
+	assert.Equal(t, created.Server.ID, volume.References[0].ProductResourceID)
+
+	err = blockAPI.DeleteVolume(&block.DeleteVolumeRequest{VolumeID: volumeID})
+	assert.True(t, httperrors.Is412(err), "a volume attached to a server cannot be deleted")
+
+	snapshot, err := blockAPI.CreateSnapshot(&block.CreateSnapshotRequest{VolumeID: volumeID, Name: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, block.SnapshotStatusCreating, snapshot.Status)
+
+	snapshot, err = blockAPI.WaitForSnapshot(&block.WaitForSnapshotRequest{SnapshotID: snapshot.ID, RetryInterval: &retryInterval})
+	require.NoError(t, err)
+	assert.Equal(t, block.SnapshotStatusAvailable, snapshot.Status)
+
+	image, err := instanceAPI.CreateImage(&instance.CreateImageRequest{Name: "test", RootVolume: snapshot.ID, Arch: instance.ArchX86_64})
+	require.NoError(t, err)
+	assert.Equal(t, instance.VolumeVolumeTypeSbsSnapshot, image.Image.RootVolume.VolumeType)
+
+	_, err = instanceAPI.CreateImage(&instance.CreateImageRequest{Name: "test", RootVolume: snapshot.ID, Arch: instance.ArchX86_64})
+	require.ErrorAs(t, err, new(*scw.QuotasExceededError))
+
+	err = blockAPI.DeleteSnapshot(&block.DeleteSnapshotRequest{SnapshotID: snapshot.ID})
+	assert.True(t, httperrors.Is412(err), "a snapshot used by an image cannot be deleted")
+
+	err = instanceAPI.DeleteImage(&instance.DeleteImageRequest{ImageID: image.Image.ID})
+	require.NoError(t, err)
+
+	err = blockAPI.DeleteSnapshot(&block.DeleteSnapshotRequest{SnapshotID: snapshot.ID})
+	require.NoError(t, err)
+
+	err = instanceAPI.DeleteServer(&instance.DeleteServerRequest{ServerID: created.Server.ID})
+	require.NoError(t, err)
+
+	volume, err = blockAPI.GetVolume(&block.GetVolumeRequest{VolumeID: volumeID})
+	require.NoError(t, err)
+	assert.Equal(t, block.VolumeStatusAvailable, volume.Status, "block volumes are detached from deleted servers")
+
+	err = blockAPI.DeleteVolume(&block.DeleteVolumeRequest{VolumeID: volumeID})
+	require.NoError(t, err)
+}
+
+func TestInstanceServerCloudInit(t *testing.T) {
+	api := instance.NewAPI(newClient(t))
+	retryInterval := time.Duration(0)
+
+	created, err := api.CreateServer(&instance.CreateServerRequest{Name: "test", CommercialType: "DEV1-S", Image: new("ubuntu_jammy")})
+	require.NoError(t, err)
+
+	err = api.SetServerUserData(&instance.SetServerUserDataRequest{
+		ServerID: created.Server.ID,
+		Key:      "cloud-init",
+		Content:  strings.NewReader("#!/bin/sh\ncurl -X PATCH --data done http://169.254.42.42/user_data/status\n"),
+	})
+	require.NoError(t, err)
+
+	err = api.ServerActionAndWait(&instance.ServerActionAndWaitRequest{ServerID: created.Server.ID, Action: instance.ServerActionPoweron, RetryInterval: &retryInterval})
+	require.NoError(t, err)
+
+	status, err := api.GetServerUserData(&instance.GetServerUserDataRequest{ServerID: created.Server.ID, Key: "status"})
+	require.NoError(t, err)
+
+	content, err := io.ReadAll(status)
+	require.NoError(t, err)
+	assert.Equal(t, "done", string(content), "cloud-init reports to the metadata API on boot")
+}
+
+func TestInstancePlacementGroupServers(t *testing.T) {
+	api := instance.NewAPI(newClient(t))
+
+	group, err := api.CreatePlacementGroup(&instance.CreatePlacementGroupRequest{
+		PolicyMode: instance.PlacementGroupPolicyModeEnforced,
+		PolicyType: instance.PlacementGroupPolicyTypeMaxAvailability,
+	})
+	require.NoError(t, err)
+
+	serverIDs := []string(nil)
+
+	for range 3 {
+		created, err := api.CreateServer(&instance.CreateServerRequest{Name: "test", CommercialType: "DEV1-S"})
+		require.NoError(t, err)
+
+		serverIDs = append(serverIDs, created.Server.ID)
+	}
+
+	// The policy cannot be respected for the servers beyond the number of hypervisors
+	res, err := api.SetPlacementGroupServers(&instance.SetPlacementGroupServersRequest{PlacementGroupID: group.PlacementGroup.ID, Servers: serverIDs})
+	require.NoError(t, err)
+	require.Len(t, res.Servers, 3)
+	assert.True(t, res.Servers[0].PolicyRespected)
+	assert.False(t, res.Servers[2].PolicyRespected)
+
+	got, err := api.GetPlacementGroup(&instance.GetPlacementGroupRequest{PlacementGroupID: group.PlacementGroup.ID})
+	require.NoError(t, err)
+	assert.False(t, got.PlacementGroup.PolicyRespected)
+
+	server, err := api.GetServer(&instance.GetServerRequest{ServerID: serverIDs[0]})
+	require.NoError(t, err)
+	require.NotNil(t, server.Server.PlacementGroup)
+	assert.Equal(t, group.PlacementGroup.ID, server.Server.PlacementGroup.ID)
+
+	// A running server cannot leave the placement group
+	_, err = api.ServerAction(&instance.ServerActionRequest{ServerID: serverIDs[2], Action: instance.ServerActionPoweron})
+	require.NoError(t, err)
+
+	_, err = api.SetPlacementGroupServers(&instance.SetPlacementGroupServersRequest{PlacementGroupID: group.PlacementGroup.ID, Servers: serverIDs[:2]})
+	require.Error(t, err)
+
+	err = api.DeletePlacementGroup(&instance.DeletePlacementGroupRequest{PlacementGroupID: group.PlacementGroup.ID})
+	require.Error(t, err, "a placement group with servers cannot be deleted")
+}
+
+func TestPrivateNetworkAttachment(t *testing.T) {
+	client := newClient(t)
+	instanceAPI := instance.NewAPI(client)
+	vpcAPI := vpc.NewAPI(client)
+	retryInterval := time.Duration(0)
+
+	pn, err := vpcAPI.CreatePrivateNetwork(&vpc.CreatePrivateNetworkRequest{Name: "test"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, pn.VpcID, "private networks are created in the default VPC")
+	assert.Len(t, pn.Subnets, 2)
+
+	created, err := instanceAPI.CreateServer(&instance.CreateServerRequest{Name: "test", CommercialType: "PLAY2-PICO"})
+	require.NoError(t, err)
+
+	_, err = instanceAPI.CreatePrivateNIC(&instance.CreatePrivateNICRequest{ServerID: created.Server.ID, PrivateNetworkID: "unknown"})
+	assert.True(t, httperrors.Is404(err))
+
+	nic, err := instanceAPI.CreatePrivateNIC(&instance.CreatePrivateNICRequest{ServerID: created.Server.ID, PrivateNetworkID: pn.ID})
+	require.NoError(t, err)
+	assert.Equal(t, instance.PrivateNICStateSyncing, nic.PrivateNic.State)
+
+	available, err := instanceAPI.WaitForPrivateNIC(&instance.WaitForPrivateNICRequest{
+		ServerID:      created.Server.ID,
+		PrivateNicID:  nic.PrivateNic.ID,
+		RetryInterval: &retryInterval,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, instance.PrivateNICStateAvailable, available.State)
+
+	err = vpcAPI.DeletePrivateNetwork(&vpc.DeletePrivateNetworkRequest{PrivateNetworkID: pn.ID})
+	assert.True(t, httperrors.Is412(err), "a private network in use cannot be deleted")
+
+	err = instanceAPI.DeletePrivateNIC(&instance.DeletePrivateNICRequest{ServerID: created.Server.ID, PrivateNicID: nic.PrivateNic.ID})
+	require.NoError(t, err)
+
+	err = vpcAPI.DeletePrivateNetwork(&vpc.DeletePrivateNetworkRequest{PrivateNetworkID: pn.ID})
+	require.NoError(t, err)
+
+	list, err := vpcAPI.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{}, scw.WithAllPages())
+	require.NoError(t, err)
+	assert.Empty(t, list.PrivateNetworks)
+}
+
+func TestPrivateNICIPAMIPs(t *testing.T) {
+	client := newClient(t)
+	instanceAPI := instance.NewAPI(client)
+	ipamAPI := ipam.NewAPI(client)
+
+	pn, err := vpc.NewAPI(client).CreatePrivateNetwork(&vpc.CreatePrivateNetworkRequest{Name: "test"})
+	require.NoError(t, err)
+
+	booked, err := ipamAPI.BookIP(&ipam.BookIPRequest{Source: &ipam.Source{PrivateNetworkID: &pn.ID}})
+	require.NoError(t, err)
+	assert.Nil(t, booked.Resource)
+
+	created, err := instanceAPI.CreateServer(&instance.CreateServerRequest{Name: "test", CommercialType: "PLAY2-PICO"})
+	require.NoError(t, err)
+
+	// A private NIC holds the requested IPAM IPs, or an IP booked along with it
+	withIP, err := instanceAPI.CreatePrivateNIC(&instance.CreatePrivateNICRequest{ServerID: created.Server.ID, PrivateNetworkID: pn.ID, IpamIPIDs: []string{booked.ID}})
+	require.NoError(t, err)
+
+	ip, err := ipamAPI.GetIP(&ipam.GetIPRequest{IPID: booked.ID})
+	require.NoError(t, err)
+	require.NotNil(t, ip.Resource)
+	assert.Equal(t, withIP.PrivateNic.ID, ip.Resource.ID)
+	assert.Equal(t, ipam.ResourceTypeInstancePrivateNic, ip.Resource.Type)
+
+	other, err := instanceAPI.CreateServer(&instance.CreateServerRequest{Name: "other", CommercialType: "PLAY2-PICO"})
+	require.NoError(t, err)
+
+	_, err = instanceAPI.CreatePrivateNIC(&instance.CreatePrivateNICRequest{ServerID: other.Server.ID, PrivateNetworkID: pn.ID, IpamIPIDs: []string{booked.ID}})
+	require.Error(t, err, "an IPAM IP is held by one private NIC")
+
+	withoutIP, err := instanceAPI.CreatePrivateNIC(&instance.CreatePrivateNICRequest{ServerID: other.Server.ID, PrivateNetworkID: pn.ID})
+	require.NoError(t, err)
+
+	list, err := ipamAPI.ListIPs(&ipam.ListIPsRequest{ResourceID: &withoutIP.PrivateNic.ID, ResourceType: ipam.ResourceTypeInstancePrivateNic}, scw.WithAllPages())
+	require.NoError(t, err)
+	require.Len(t, list.IPs, 1)
+	assert.NotEqual(t, booked.ID, list.IPs[0].ID)
+
+	// Deleting the private NICs releases the booked IP and frees the requested one
+	err = instanceAPI.DeletePrivateNIC(&instance.DeletePrivateNICRequest{ServerID: other.Server.ID, PrivateNicID: withoutIP.PrivateNic.ID})
+	require.NoError(t, err)
+
+	err = instanceAPI.DeletePrivateNIC(&instance.DeletePrivateNICRequest{ServerID: created.Server.ID, PrivateNicID: withIP.PrivateNic.ID})
+	require.NoError(t, err)
+
+	list, err = ipamAPI.ListIPs(&ipam.ListIPsRequest{}, scw.WithAllPages())
+	require.NoError(t, err)
+	require.Len(t, list.IPs, 1)
+	assert.Equal(t, booked.ID, list.IPs[0].ID)
+	assert.Nil(t, list.IPs[0].Resource)
+}
+
+func TestIAMSSHKey(t *testing.T) {
+	api := iam.NewAPI(newClient(t))
+
+	_, err := api.CreateSSHKey(&iam.CreateSSHKeyRequest{Name: "invalid", PublicKey: "not a key"})
+	require.ErrorAs(t, err, new(*scw.InvalidArgumentsError))
+
+	key, err := api.CreateSSHKey(&iam.CreateSSHKeyRequest{Name: "test", PublicKey: testPublicKey})
+	require.NoError(t, err)
+	assert.Contains(t, key.Fingerprint, "(ssh-ed25519)")
+
+	key, err = api.UpdateSSHKey(&iam.UpdateSSHKeyRequest{SSHKeyID: key.ID, Disabled: new(true)})
+	require.NoError(t, err)
+	assert.True(t, key.Disabled)
+
+	list, err := api.ListSSHKeys(&iam.ListSSHKeysRequest{Name: new("test")}, scw.WithAllPages())
+	require.NoError(t, err)
+	assert.Len(t, list.SSHKeys, 1)
+
+	err = api.DeleteSSHKey(&iam.DeleteSSHKeyRequest{SSHKeyID: key.ID})
+	require.NoError(t, err)
+
+	_, err = api.GetSSHKey(&iam.GetSSHKeyRequest{SSHKeyID: key.ID})
+	assert.True(t, httperrors.Is404(err))
+}
+
+func TestSecretVersions(t *testing.T) {
+	api := secret.NewAPI(newClient(t))
+
+	sec, err := api.CreateSecret(&secret.CreateSecretRequest{Name: "test", Protected: true})
+	require.NoError(t, err)
+	assert.Equal(t, secret.SecretTypeOpaque, sec.Type)
+	assert.Equal(t, "/", sec.Path)
+
+	_, err = api.CreateSecretVersion(&secret.CreateSecretVersionRequest{SecretID: sec.ID, Data: []byte("first")})
+	require.NoError(t, err)
+
+	_, err = api.CreateSecretVersion(&secret.CreateSecretVersionRequest{SecretID: sec.ID, Data: []byte("second"), DisablePrevious: new(true)})
+	require.NoError(t, err)
+
+	access, err := api.AccessSecretVersion(&secret.AccessSecretVersionRequest{SecretID: sec.ID, Revision: "latest_enabled"})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), access.Revision)
+	assert.Equal(t, []byte("second"), access.Data)
+
+	versions, err := api.ListSecretVersions(&secret.ListSecretVersionsRequest{SecretID: sec.ID, Status: []secret.SecretVersionStatus{secret.SecretVersionStatusDisabled}}, scw.WithAllPages())
+	require.NoError(t, err)
+	require.Len(t, versions.Versions, 1)
+	assert.Equal(t, uint32(1), versions.Versions[0].Revision)
+
+	err = api.DeleteSecret(&secret.DeleteSecretRequest{SecretID: sec.ID})
+	assert.True(t, httperrors.Is412(err), "a protected secret cannot be deleted")
+
+	_, err = api.UnprotectSecret(&secret.UnprotectSecretRequest{SecretID: sec.ID})
+	require.NoError(t, err)
+
+	err = api.DeleteSecret(&secret.DeleteSecretRequest{SecretID: sec.ID})
+	require.NoError(t, err)
+}
+
+func TestK8SPoolNodes(t *testing.T) {
+	api := k8s.NewAPI(newClient(t))
+	retryInterval := time.Duration(0)
+
+	cluster, err := api.CreateCluster(&k8s.CreateClusterRequest{Name: "test", Version: "1.32.3", Cni: k8s.CNICilium})
+	require.NoError(t, err)
+
+	pool, err := api.CreatePool(&k8s.CreatePoolRequest{ClusterID: cluster.ID, Name: "default", NodeType: "PRO2-XXS", Size: 2})
+	require.NoError(t, err)
+	assert.Equal(t, k8s.PoolStatusScaling, pool.Status)
+
+	pool, err = api.WaitForPool(&k8s.WaitForPoolRequest{PoolID: pool.ID, RetryInterval: &retryInterval})
+	require.NoError(t, err)
+	assert.Equal(t, k8s.PoolStatusReady, pool.Status)
+
+	nodes, err := api.ListNodes(&k8s.ListNodesRequest{ClusterID: cluster.ID, PoolID: &pool.ID}, scw.WithAllPages())
+	require.NoError(t, err)
+	require.Len(t, nodes.Nodes, 2)
+	assert.Contains(t, nodes.Nodes[0].ProviderID, "scaleway://instance/fr-par-1/")
+
+	node, err := api.WaitForNode(&k8s.WaitForNodeRequest{NodeID: nodes.Nodes[0].ID, RetryInterval: &retryInterval})
+	require.NoError(t, err)
+	assert.Equal(t, k8s.NodeStatusReady, node.Status)
+
+	node, err = api.RebootNode(&k8s.RebootNodeRequest{NodeID: node.ID})
+	require.NoError(t, err)
+	assert.Equal(t, k8s.NodeStatusRebooting, node.Status)
+
+	_, err = api.ReplaceNode(&k8s.ReplaceNodeRequest{NodeID: node.ID})
+	require.Error(t, err, "a rebooting node cannot be replaced")
+
+	_, err = api.UpdatePool(&k8s.UpdatePoolRequest{PoolID: pool.ID, Size: new(uint32(1))})
+	require.NoError(t, err)
+
+	nodes, err = api.ListNodes(&k8s.ListNodesRequest{ClusterID: cluster.ID}, scw.WithAllPages())
+	require.NoError(t, err)
+	assert.Len(t, nodes.Nodes, 1)
+}
+
+func TestRDBDatabases(t *testing.T) {
+	api := rdb.NewAPI(newClient(t))
+	retryInterval := time.Duration(0)
+
+	instance, err := api.CreateInstance(&rdb.CreateInstanceRequest{Name: "test", Engine: "PostgreSQL-15", NodeType: "db-dev-s", UserName: "admin"})
+	require.NoError(t, err)
+
+	_, err = api.CreateDatabase(&rdb.CreateDatabaseRequest{InstanceID: instance.ID, Name: "foo"})
+	require.Error(t, err, "databases cannot be created while the instance is provisioning")
+
+	_, err = api.WaitForInstance(&rdb.WaitForInstanceRequest{InstanceID: instance.ID, RetryInterval: &retryInterval})
+	require.NoError(t, err)
+
+	_, err = api.CreateDatabase(&rdb.CreateDatabaseRequest{InstanceID: instance.ID, Name: "foo"})
+	require.NoError(t, err)
+
+	databases, err := api.ListDatabases(&rdb.ListDatabasesRequest{InstanceID: instance.ID, Name: new("foo")}, scw.WithAllPages())
+	require.NoError(t, err)
+	require.Len(t, databases.Databases, 1)
+
+	err = api.DeleteDatabase(&rdb.DeleteDatabaseRequest{InstanceID: instance.ID, Name: "foo"})
+	require.NoError(t, err)
+
+	err = api.DeleteDatabase(&rdb.DeleteDatabaseRequest{InstanceID: instance.ID, Name: "foo"})
+	assert.True(t, httperrors.Is404(err))
+}
+
+func TestUnknownRoute(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	resp, err := server.Client().Get("https://api.scaleway.com/unknown/v1/zones/fr-par-1/resources")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+}
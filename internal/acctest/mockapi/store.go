@@ -0,0 +1,118 @@
+package mockapi
+
+// transition is applied to a resource the next time it is read.
+// It returns false when the resource must be removed from the store.
+type transition[T any] func(T) bool
+
+type record[T any] struct {
+	value   T
+	pending []transition[T]
+	// settled is true once the status preceding the pending transitions has been read
+	settled bool
+}
+
+// store keeps the resources of one type in creation order.
+// Every read after the first one applies the next pending transition so that the SDK waiters see the intermediate statuses.
+type store[T any] struct {
+	records map[string]*record[T]
+	order   []string
+}
+
+func newStore[T any]() *store[T] {
+	return &store[T]{
+		records: map[string]*record[T]{},
+	}
+}
+
+func (s *store[T]) put(id string, value T) {
+	if _, exists := s.records[id]; !exists {
+		s.order = append(s.order, id)
+	}
+
+	s.records[id] = &record[T]{value: value}
+}
+
+// peek returns a resource without applying its pending transitions
+func (s *store[T]) peek(id string) (T, bool) {
+	rec, ok := s.records[id]
+	if !ok {
+		var zero T
+
+		return zero, false
+	}
+
+	return rec.value, true
+}
+
+// get returns a resource, applying its next pending transition if the current status has already been read
+func (s *store[T]) get(id string) (T, bool) {
+	var zero T
+
+	rec, ok := s.records[id]
+	if !ok {
+		return zero, false
+	}
+
+	if len(rec.pending) > 0 && !rec.settled {
+		rec.settled = true
+	} else if len(rec.pending) > 0 {
+		next := rec.pending[0]
+		rec.pending = rec.pending[1:]
+
+		if !next(rec.value) {
+			s.delete(id)
+
+			return zero, false
+		}
+	}
+
+	return rec.value, true
+}
+
+// list returns every resource matching filter in creation order
+func (s *store[T]) list(filter func(T) bool) []T {
+	values := []T(nil)
+
+	for _, id := range append([]string(nil), s.order...) {
+		value, ok := s.get(id)
+		if ok && (filter == nil || filter(value)) {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// schedule queues transitions applied one by one on the next reads of the resource
+func (s *store[T]) schedule(id string, transitions ...transition[T]) {
+	if rec, ok := s.records[id]; ok {
+		if len(rec.pending) == 0 {
+			rec.settled = false
+		}
+
+		rec.pending = append(rec.pending, transitions...)
+	}
+}
+
+// busy returns true if the resource still has pending transitions
+func (s *store[T]) busy(id string) bool {
+	rec, ok := s.records[id]
+
+	return ok && len(rec.pending) > 0
+}
+
+func (s *store[T]) delete(id string) {
+	if _, ok := s.records[id]; !ok {
+		return
+	}
+
+	delete(s.records, id)
+
+	for i, orderedID := range s.order {
+		if orderedID == id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+
+			break
+		}
+	}
+}
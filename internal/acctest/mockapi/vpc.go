@@ -0,0 +1,377 @@
+package mockapi
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const vpcPrefix = "/vpc/v2/regions/{region}"
+
+type vpcAPI struct {
+	s *Server
+
+	vpcs            *store[*vpc.VPC]
+	privateNetworks *store[*vpc.PrivateNetwork]
+	subnetCount     int
+}
+
+func newVPCAPI(s *Server) *vpcAPI {
+	return &vpcAPI{
+		s:               s,
+		vpcs:            newStore[*vpc.VPC](),
+		privateNetworks: newStore[*vpc.PrivateNetwork](),
+	}
+}
+
+func (api *vpcAPI) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+vpcPrefix+"/vpcs", api.listVPCs)
+	mux.HandleFunc("POST "+vpcPrefix+"/vpcs", api.createVPC)
+	mux.HandleFunc("GET "+vpcPrefix+"/vpcs/{vpc_id}", api.getVPC)
+	mux.HandleFunc("PATCH "+vpcPrefix+"/vpcs/{vpc_id}", api.updateVPC)
+	mux.HandleFunc("DELETE "+vpcPrefix+"/vpcs/{vpc_id}", api.deleteVPC)
+
+	mux.HandleFunc("GET "+vpcPrefix+"/private-networks", api.listPrivateNetworks)
+	mux.HandleFunc("POST "+vpcPrefix+"/private-networks", api.createPrivateNetwork)
+	mux.HandleFunc("GET "+vpcPrefix+"/private-networks/{private_network_id}", api.getPrivateNetwork)
+	mux.HandleFunc("PATCH "+vpcPrefix+"/private-networks/{private_network_id}", api.updatePrivateNetwork)
+	mux.HandleFunc("DELETE "+vpcPrefix+"/private-networks/{private_network_id}", api.deletePrivateNetwork)
+}
+
+//
+// VPCs
+//
+
+func (api *vpcAPI) newVPC(region scw.Region, projectID string, name string, tags []string, isDefault bool) *vpc.VPC {
+	if tags == nil {
+		tags = []string{}
+	}
+
+	v := &vpc.VPC{
+		ID:             api.s.newID(),
+		Name:           name,
+		OrganizationID: DefaultOrganizationID,
+		ProjectID:      projectID,
+		Region:         region,
+		Tags:           tags,
+		IsDefault:      isDefault,
+		CreatedAt:      now(),
+		UpdatedAt:      now(),
+		RoutingEnabled: true,
+	}
+	api.vpcs.put(v.ID, v)
+
+	return v
+}
+
+// defaultVPC returns the default VPC of a project, creating it on first use like the API does
+func (api *vpcAPI) defaultVPC(region scw.Region, projectID string) *vpc.VPC {
+	for _, v := range api.vpcs.list(nil) {
+		if v.Region == region && v.ProjectID == projectID && v.IsDefault {
+			return v
+		}
+	}
+
+	return api.newVPC(region, projectID, "default", nil, true)
+}
+
+func (api *vpcAPI) findVPC(w http.ResponseWriter, r *http.Request) (*vpc.VPC, bool) {
+	id := r.PathValue("vpc_id")
+
+	v, ok := api.vpcs.get(id)
+	if !ok || v.Region != regionOf(r) {
+		writeNotFound(w, "vpc", id)
+
+		return nil, false
+	}
+
+	return v, true
+}
+
+// renderVPC refreshes the fields of a VPC that depend on other resources
+func (api *vpcAPI) renderVPC(v *vpc.VPC) *vpc.VPC {
+	v.PrivateNetworkCount = uint32(len(api.privateNetworks.list(func(pn *vpc.PrivateNetwork) bool { return pn.VpcID == v.ID })))
+
+	return v
+}
+
+func (api *vpcAPI) listVPCs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	region := regionOf(r)
+
+	vpcs := api.vpcs.list(func(v *vpc.VPC) bool {
+		switch {
+		case v.Region != region:
+			return false
+		case query.Get("name") != "" && !strings.Contains(v.Name, query.Get("name")):
+			return false
+		case query.Get("project_id") != "" && v.ProjectID != query.Get("project_id"):
+			return false
+		case query.Get("is_default") != "" && fmt.Sprint(v.IsDefault) != query.Get("is_default"):
+			return false
+		case !hasTags(v.Tags, query["tags"]):
+			return false
+		}
+
+		return true
+	})
+
+	page, total := paginate(r, vpcs)
+	for _, v := range page {
+		api.renderVPC(v)
+	}
+
+	writeJSON(w, http.StatusOK, &vpc.ListVPCsResponse{
+		Vpcs:       page,
+		TotalCount: uint32(total),
+	})
+}
+
+func (api *vpcAPI) createVPC(w http.ResponseWriter, r *http.Request) {
+	req := &vpc.CreateVPCRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	v := api.newVPC(regionOf(r), projectOrDefault(&req.ProjectID), req.Name, req.Tags, false)
+	v.RoutingEnabled = req.EnableRouting
+
+	writeJSON(w, http.StatusOK, api.renderVPC(v))
+}
+
+func (api *vpcAPI) getVPC(w http.ResponseWriter, r *http.Request) {
+	v, ok := api.findVPC(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.renderVPC(v))
+}
+
+func (api *vpcAPI) updateVPC(w http.ResponseWriter, r *http.Request) {
+	v, ok := api.findVPC(w, r)
+	if !ok {
+		return
+	}
+
+	req := &vpc.UpdateVPCRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if req.Name != nil {
+		v.Name = *req.Name
+	}
+
+	if req.Tags != nil {
+		v.Tags = *req.Tags
+	}
+
+	v.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, api.renderVPC(v))
+}
+
+func (api *vpcAPI) deleteVPC(w http.ResponseWriter, r *http.Request) {
+	v, ok := api.findVPC(w, r)
+	if !ok {
+		return
+	}
+
+	if api.renderVPC(v).PrivateNetworkCount > 0 {
+		writeError(w, http.StatusPreconditionFailed, "precondition_failed", "the VPC still contains private networks", map[string]any{
+			"precondition": "resource_still_in_use",
+		})
+
+		return
+	}
+
+	api.vpcs.delete(v.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//
+// Private networks
+//
+
+func (api *vpcAPI) findPrivateNetwork(w http.ResponseWriter, r *http.Request) (*vpc.PrivateNetwork, bool) {
+	id := r.PathValue("private_network_id")
+
+	pn, ok := api.privateNetworks.get(id)
+	if !ok || pn.Region != regionOf(r) {
+		writeNotFound(w, "private_network", id)
+
+		return nil, false
+	}
+
+	return pn, true
+}
+
+// newSubnets returns the subnets of a new private network, generating an IPv4 and an IPv6 subnet when none are requested
+func (api *vpcAPI) newSubnets(pn *vpc.PrivateNetwork, requested []scw.IPNet) []*vpc.Subnet {
+	if len(requested) == 0 {
+		api.subnetCount++
+
+		_, ipv4, _ := net.ParseCIDR(fmt.Sprintf("172.16.%d.0/22", (api.subnetCount*4)%256))
+		_, ipv6, _ := net.ParseCIDR(fmt.Sprintf("fd5f:519c:6d46:%x::/64", api.subnetCount))
+		requested = []scw.IPNet{{IPNet: *ipv4}, {IPNet: *ipv6}}
+	}
+
+	subnets := make([]*vpc.Subnet, 0, len(requested))
+	for _, subnet := range requested {
+		subnets = append(subnets, &vpc.Subnet{
+			ID:               api.s.newID(),
+			CreatedAt:        now(),
+			UpdatedAt:        now(),
+			Subnet:           subnet,
+			ProjectID:        pn.ProjectID,
+			PrivateNetworkID: pn.ID,
+			VpcID:            pn.VpcID,
+		})
+	}
+
+	return subnets
+}
+
+func (api *vpcAPI) listPrivateNetworks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	region := regionOf(r)
+
+	pns := api.privateNetworks.list(func(pn *vpc.PrivateNetwork) bool {
+		switch {
+		case pn.Region != region:
+			return false
+		case query.Get("name") != "" && !strings.Contains(pn.Name, query.Get("name")):
+			return false
+		case query.Get("project_id") != "" && pn.ProjectID != query.Get("project_id"):
+			return false
+		case query.Get("vpc_id") != "" && pn.VpcID != query.Get("vpc_id"):
+			return false
+		case len(query["private_network_ids"]) > 0 && !slices.Contains(query["private_network_ids"], pn.ID):
+			return false
+		case !hasTags(pn.Tags, query["tags"]):
+			return false
+		}
+
+		return true
+	})
+
+	page, total := paginate(r, pns)
+
+	writeJSON(w, http.StatusOK, &vpc.ListPrivateNetworksResponse{
+		PrivateNetworks: page,
+		TotalCount:      uint32(total),
+	})
+}
+
+func (api *vpcAPI) createPrivateNetwork(w http.ResponseWriter, r *http.Request) {
+	req := &vpc.CreatePrivateNetworkRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	region := regionOf(r)
+	projectID := projectOrDefault(&req.ProjectID)
+
+	var v *vpc.VPC
+
+	if req.VpcID != nil {
+		existing, ok := api.vpcs.peek(*req.VpcID)
+		if !ok || existing.Region != region {
+			writeNotFound(w, "vpc", *req.VpcID)
+
+			return
+		}
+
+		v = existing
+	} else {
+		v = api.defaultVPC(region, projectID)
+	}
+
+	pn := &vpc.PrivateNetwork{
+		ID:                             api.s.newID(),
+		Name:                           req.Name,
+		OrganizationID:                 DefaultOrganizationID,
+		ProjectID:                      projectID,
+		Region:                         region,
+		Tags:                           req.Tags,
+		CreatedAt:                      now(),
+		UpdatedAt:                      now(),
+		VpcID:                          v.ID,
+		DHCPEnabled:                    true,
+		DefaultRoutePropagationEnabled: req.DefaultRoutePropagationEnabled,
+	}
+
+	if pn.Tags == nil {
+		pn.Tags = []string{}
+	}
+
+	pn.Subnets = api.newSubnets(pn, req.Subnets)
+	api.privateNetworks.put(pn.ID, pn)
+
+	writeJSON(w, http.StatusOK, pn)
+}
+
+func (api *vpcAPI) getPrivateNetwork(w http.ResponseWriter, r *http.Request) {
+	pn, ok := api.findPrivateNetwork(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, pn)
+}
+
+func (api *vpcAPI) updatePrivateNetwork(w http.ResponseWriter, r *http.Request) {
+	pn, ok := api.findPrivateNetwork(w, r)
+	if !ok {
+		return
+	}
+
+	req := &vpc.UpdatePrivateNetworkRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	if req.Name != nil {
+		pn.Name = *req.Name
+	}
+
+	if req.Tags != nil {
+		pn.Tags = *req.Tags
+	}
+
+	if req.DefaultRoutePropagationEnabled != nil {
+		pn.DefaultRoutePropagationEnabled = *req.DefaultRoutePropagationEnabled
+	}
+
+	pn.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, pn)
+}
+
+func (api *vpcAPI) deletePrivateNetwork(w http.ResponseWriter, r *http.Request) {
+	pn, ok := api.findPrivateNetwork(w, r)
+	if !ok {
+		return
+	}
+
+	attached := api.s.instance.privateNICs.list(func(nic *instance.PrivateNIC) bool { return nic.PrivateNetworkID == pn.ID })
+	if len(attached) > 0 {
+		writeError(w, http.StatusPreconditionFailed, "precondition_failed", "the private network is still attached to resources", map[string]any{
+			"precondition": "resource_still_in_use",
+		})
+
+		return
+	}
+
+	api.privateNetworks.delete(pn.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,33 @@
+package acctest_test
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest/mockapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTestToolsWithMockAPI(t *testing.T) {
+	mockAPI := *acctest.MockAPI
+	*acctest.MockAPI = true
+
+	t.Cleanup(func() {
+		*acctest.MockAPI = mockAPI
+	})
+
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	api := instance.NewAPI(tt.Meta.ScwClient())
+
+	res, err := api.CreateIP(&instance.CreateIPRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, mockapi.DefaultProjectID, res.IP.Project)
+
+	list, err := api.ListIPs(&instance.ListIPsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, list.IPs, 1)
+}
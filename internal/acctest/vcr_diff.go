@@ -0,0 +1,263 @@
+package acctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/dnaeon/go-vcr.v3/cassette"
+)
+
+// volatileUUID matches the identifiers that change each time a cassette is recorded
+var volatileUUID = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+// CassetteDiff lists the differences between two recordings of the same cassette
+type CassetteDiff struct {
+	OldPath         string
+	NewPath         string
+	OldSize         int64
+	NewSize         int64
+	OldInteractions int
+	NewInteractions int
+	Interactions    []InteractionDiff
+}
+
+// InteractionDiff lists the changes of one interaction, identified by its index in the cassettes
+type InteractionDiff struct {
+	Index   int
+	Method  string
+	URL     string
+	Changes []string
+}
+
+// Growth returns the ratio between the size of the new cassette and the old one
+func (diff *CassetteDiff) Growth() float64 {
+	if diff.OldSize == 0 {
+		return 0
+	}
+
+	return float64(diff.NewSize) / float64(diff.OldSize)
+}
+
+// Print writes the diff in a human-readable format
+func (diff *CassetteDiff) Print(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "--- %s (%d interactions, %d bytes)\n", diff.OldPath, diff.OldInteractions, diff.OldSize)
+	_, _ = fmt.Fprintf(w, "+++ %s (%d interactions, %d bytes, growth x%.2f)\n", diff.NewPath, diff.NewInteractions, diff.NewSize, diff.Growth())
+
+	for _, interaction := range diff.Interactions {
+		_, _ = fmt.Fprintf(w, "interaction %d: %s %s\n", interaction.Index, interaction.Method, interaction.URL)
+
+		for _, change := range interaction.Changes {
+			_, _ = fmt.Fprintf(w, "    %s\n", change)
+		}
+	}
+}
+
+// loadCassette loads a go-vcr cassette, the path may be given with or without its .yaml extension
+func loadCassette(path string) (*cassette.Cassette, int64, error) {
+	path = strings.TrimSuffix(path, ".yaml")
+
+	info, err := os.Stat(path + ".yaml")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	c, err := cassette.Load(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load cassette %s: %w", path, err)
+	}
+
+	return c, info.Size(), nil
+}
+
+// DiffCassettes compares two cassettes interaction by interaction.
+// Requests are compared with the cassette matcher and JSON bodies field by field, identifiers and dates are
+// considered equal as they change on every recording.
+func DiffCassettes(oldPath, newPath string) (*CassetteDiff, error) {
+	oldCassette, oldSize, err := loadCassette(oldPath)
+	if err != nil {
+		return nil, err
+	}
+
+	newCassette, newSize, err := loadCassette(newPath)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &CassetteDiff{
+		OldPath:         oldPath,
+		NewPath:         newPath,
+		OldSize:         oldSize,
+		NewSize:         newSize,
+		OldInteractions: len(oldCassette.Interactions),
+		NewInteractions: len(newCassette.Interactions),
+	}
+
+	for i := range max(len(oldCassette.Interactions), len(newCassette.Interactions)) {
+		var changes []string
+
+		interaction := InteractionDiff{Index: i}
+
+		switch {
+		case i >= len(newCassette.Interactions):
+			old := oldCassette.Interactions[i]
+			interaction.Method, interaction.URL = old.Request.Method, old.Request.URL
+			changes = []string{"removed"}
+		case i >= len(oldCassette.Interactions):
+			added := newCassette.Interactions[i]
+			interaction.Method, interaction.URL = added.Request.Method, added.Request.URL
+			changes = []string{"added"}
+		default:
+			old, current := oldCassette.Interactions[i], newCassette.Interactions[i]
+			interaction.Method, interaction.URL = current.Request.Method, current.Request.URL
+			changes = diffInteractions(old, current)
+		}
+
+		if len(changes) > 0 {
+			interaction.Changes = changes
+			diff.Interactions = append(diff.Interactions, interaction)
+		}
+	}
+
+	return diff, nil
+}
+
+func diffInteractions(old, current *cassette.Interaction) []string {
+	var changes []string
+
+	if !requestsMatch(old.Request, current.Request) {
+		oldURL := volatileUUID.ReplaceAllString(old.Request.URL, "{id}")
+		currentURL := volatileUUID.ReplaceAllString(current.Request.URL, "{id}")
+
+		if old.Request.Method != current.Request.Method || oldURL != currentURL {
+			changes = append(changes, fmt.Sprintf("request: %s %s -> %s %s", old.Request.Method, old.Request.URL, current.Request.Method, current.Request.URL))
+		} else {
+			changes = append(changes, diffBodies("request.body", old.Request.Body, current.Request.Body)...)
+		}
+	}
+
+	if old.Response.Code != current.Response.Code {
+		changes = append(changes, fmt.Sprintf("response.code: %d -> %d", old.Response.Code, current.Response.Code))
+	}
+
+	changes = append(changes, diffBodies("response.body", old.Response.Body, current.Response.Body)...)
+
+	return changes
+}
+
+// requestsMatch returns true if the new request would be replayed by the old one
+func requestsMatch(old, current cassette.Request) bool {
+	request, err := http.NewRequest(current.Method, current.URL, strings.NewReader(current.Body))
+	if err != nil {
+		return false
+	}
+
+	return CassetteMatcher(request, old)
+}
+
+func diffBodies(path, old, current string) []string {
+	if old == current {
+		return nil
+	}
+
+	var oldJSON, currentJSON any
+
+	if json.Unmarshal([]byte(old), &oldJSON) != nil || json.Unmarshal([]byte(current), &currentJSON) != nil {
+		return []string{fmt.Sprintf("%s: %d bytes -> %d bytes", path, len(old), len(current))}
+	}
+
+	return diffJSONValues(path, oldJSON, currentJSON)
+}
+
+// diffJSONValues walks two JSON documents and returns the path of each changed value
+func diffJSONValues(path string, old, current any) []string {
+	if reflect.TypeOf(old) != reflect.TypeOf(current) {
+		return []string{fmt.Sprintf("%s: %s -> %s", path, marshalJSONValue(old), marshalJSONValue(current))}
+	}
+
+	switch old := old.(type) {
+	case map[string]any:
+		current := current.(map[string]any)
+
+		keys := make([]string, 0, len(old)+len(current))
+		for key := range old {
+			keys = append(keys, key)
+		}
+
+		for key := range current {
+			if _, exists := old[key]; !exists {
+				keys = append(keys, key)
+			}
+		}
+
+		slices.Sort(keys)
+
+		var changes []string
+
+		for _, key := range keys {
+			oldValue, inOld := old[key]
+			currentValue, inCurrent := current[key]
+
+			switch {
+			case !inOld:
+				changes = append(changes, fmt.Sprintf("%s.%s: added %s", path, key, marshalJSONValue(currentValue)))
+			case !inCurrent:
+				changes = append(changes, fmt.Sprintf("%s.%s: removed", path, key))
+			default:
+				changes = append(changes, diffJSONValues(path+"."+key, oldValue, currentValue)...)
+			}
+		}
+
+		return changes
+	case []any:
+		current := current.([]any)
+		if len(old) != len(current) {
+			return []string{fmt.Sprintf("%s: %d items -> %d items", path, len(old), len(current))}
+		}
+
+		var changes []string
+
+		for i := range old {
+			changes = append(changes, diffJSONValues(fmt.Sprintf("%s[%d]", path, i), old[i], current[i])...)
+		}
+
+		return changes
+	case string:
+		if isVolatileString(old) && isVolatileString(current.(string)) {
+			return nil
+		}
+	}
+
+	if compareJSONFields(old, current, true) {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("%s: %s -> %s", path, marshalJSONValue(old), marshalJSONValue(current))}
+}
+
+// isVolatileString returns true for values that change on every recording like identifiers and dates
+func isVolatileString(value string) bool {
+	if volatileUUID.MatchString(value) && volatileUUID.ReplaceAllString(value, "") == "" {
+		return true
+	}
+
+	_, err := time.Parse(time.RFC3339, value)
+
+	return err == nil
+}
+
+func marshalJSONValue(value any) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(raw)
+}
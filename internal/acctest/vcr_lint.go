@@ -0,0 +1,416 @@
+package acctest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/dnaeon/go-vcr.v3/cassette"
+)
+
+// LintSeverity tells whether a lint issue must fail the check
+type LintSeverity string
+
+const (
+	LintSeverityError   = LintSeverity("error")
+	LintSeverityWarning = LintSeverity("warning")
+)
+
+const (
+	// LintRuleLeakedSecret reports a credential that has not been removed from the cassette
+	LintRuleLeakedSecret = "leaked-secret"
+	// LintRuleDuplicatePolling reports a resource polled more than twice in a row with the same response.
+	// Reading a resource twice is expected: once by the waiter and once by the read function.
+	LintRuleDuplicatePolling = "duplicate-polling"
+	// LintRuleUnmatchedPolling reports a resource polled in a transient state that is never read again
+	LintRuleUnmatchedPolling = "unmatched-polling"
+	// LintRuleUncompressedWaitLoop reports interactions that would be removed by the cassette compressor
+	LintRuleUncompressedWaitLoop = "uncompressed-wait-loop"
+)
+
+// maxSamePolling is the number of identical consecutive reads of a resource allowed before reporting a duplicate polling
+const maxSamePolling = 2
+
+var (
+	// accessKeyPattern matches the Scaleway access keys, anonymized ones repeating a single character like SCWXXXXXXXXXXXXXXXXX
+	accessKeyPattern = regexp.MustCompile(`\bSCW[0-9A-Z]{17}\b`)
+	// uuidPattern matches a value that is a UUID, like a secret key
+	uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	// tokenPattern matches a value that looks like a random token, like the token of a kubeconfig
+	tokenPattern = regexp.MustCompile(`^[0-9A-Za-z_.-]{32,}$`)
+	// secretAssignmentPattern matches the key: value and key=value pairs of a text, like a kubeconfig or a form
+	secretAssignmentPattern = regexp.MustCompile(`([0-9A-Za-z_-]+)["']?[ \t]*[:=][ \t]*["']?([0-9A-Za-z_.-]+)`)
+)
+
+// sensitiveHeaders are removed from the requests before a cassette is saved
+var sensitiveHeaders = []string{
+	"X-Auth-Token",
+	"Authorization",
+}
+
+// LintIssue is a problem found in a cassette
+type LintIssue struct {
+	Path        string
+	Interaction int
+	Severity    LintSeverity
+	Rule        string
+	Message     string
+}
+
+func (issue LintIssue) String() string {
+	return fmt.Sprintf("%s: interaction %d: %s: [%s] %s", issue.Path, issue.Interaction, issue.Severity, issue.Rule, issue.Message)
+}
+
+// LintCassette looks for leaked secrets and for wait loops that should not be recorded in the cassette at the given path.
+func LintCassette(path string) ([]LintIssue, error) {
+	c, _, err := loadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []LintIssue
+
+	addIssue := func(index int, severity LintSeverity, rule string, format string, args ...any) {
+		issues = append(issues, LintIssue{
+			Path:        path,
+			Interaction: index,
+			Severity:    severity,
+			Rule:        rule,
+			Message:     fmt.Sprintf(format, args...),
+		})
+	}
+
+	// The secrets found in a field are looked for in the whole cassette, like a secret key in the URL of a later request
+	locations := make([][]cassetteLocation, len(c.Interactions))
+	knownSecrets := map[string]string{}
+
+	for i, interaction := range c.Interactions {
+		locations[i] = interactionLocations(interaction)
+
+		for _, location := range locations[i] {
+			for _, secret := range location.secrets {
+				if _, known := knownSecrets[secret.value]; !known {
+					knownSecrets[secret.value] = fmt.Sprintf("%s of interaction %d", location.describe(secret), i)
+				}
+			}
+		}
+	}
+
+	transitioning := false
+	repeated := 0
+
+	for i, interaction := range c.Interactions {
+		for _, header := range sensitiveHeaders {
+			if interaction.Request.Headers.Get(header) != "" {
+				addIssue(i, LintSeverityError, LintRuleLeakedSecret, "request header %s has not been removed", header)
+			}
+		}
+
+		for _, location := range locations[i] {
+			for _, message := range leakedSecretIssues(location, knownSecrets) {
+				addIssue(i, LintSeverityError, LintRuleLeakedSecret, "%s", message)
+			}
+		}
+
+		if i > 0 && isSamePolling(c.Interactions[i-1], interaction) {
+			repeated++
+		} else {
+			repeated = 0
+		}
+
+		if interaction.Request.Method != http.MethodGet {
+			transitioning = false
+
+			continue
+		}
+
+		status, hasStatus := responseStatus(interaction)
+		if !hasStatus {
+			continue
+		}
+
+		_, isTransient := transientStates[status]
+
+		switch {
+		case isTransient && transitioning:
+			addIssue(i, LintSeverityWarning, LintRuleUncompressedWaitLoop, "%s is still %s, run the cassette compressor", interaction.Request.URL, status)
+		case repeated >= maxSamePolling:
+			addIssue(i, LintSeverityWarning, LintRuleDuplicatePolling, "%s returned the same %s response %d times in a row", interaction.Request.URL, status, repeated+1)
+		}
+
+		transitioning = isTransient
+
+		if isTransient && !isPolledAgain(c.Interactions[i+1:], interaction.Request.URL) {
+			addIssue(i, LintSeverityWarning, LintRuleUnmatchedPolling, "%s is left %s and never read again", interaction.Request.URL, status)
+		}
+	}
+
+	return issues, nil
+}
+
+// leakedSecret is a credential found in a cassette, named after the field holding it
+type leakedSecret struct {
+	field string
+	value string
+}
+
+// cassetteLocation is a part of an interaction that can hold a credential, like its URL, a header or a body
+type cassetteLocation struct {
+	name    string
+	text    string
+	secrets []leakedSecret
+}
+
+// describe names the field of the location holding a secret
+func (location cassetteLocation) describe(secret leakedSecret) string {
+	if secret.field == "" {
+		return location.name
+	}
+
+	return location.name + " field " + secret.field
+}
+
+// interactionLocations splits an interaction into the locations where a credential can leak and finds the
+// credentials held by their secret-like fields
+func interactionLocations(interaction *cassette.Interaction) []cassetteLocation {
+	locations := []cassetteLocation{{
+		name:    "request URL",
+		text:    interaction.Request.URL,
+		secrets: querySecrets(interaction.Request.URL),
+	}}
+
+	for _, headers := range []struct {
+		name    string
+		headers http.Header
+	}{
+		{"request header", interaction.Request.Headers},
+		{"response header", interaction.Response.Headers},
+	} {
+		for _, name := range slices.Sorted(maps.Keys(headers.headers)) {
+			location := cassetteLocation{name: headers.name + " " + name}
+
+			for _, value := range headers.headers[name] {
+				location.text += value + "\n"
+
+				if isLeakedSecret(name, value) {
+					location.secrets = append(location.secrets, leakedSecret{value: value})
+				}
+			}
+
+			locations = append(locations, location)
+		}
+	}
+
+	for _, body := range []struct {
+		name string
+		body string
+	}{
+		{"request body", interaction.Request.Body},
+		{"response body", interaction.Response.Body},
+	} {
+		locations = append(locations, cassetteLocation{
+			name:    body.name,
+			text:    body.body,
+			secrets: bodySecrets(body.body),
+		})
+	}
+
+	return locations
+}
+
+// leakedSecretIssues returns the messages of the leaked secrets of a location. Besides the secret-like fields of the
+// location, it looks for Scaleway access keys and for the secrets found in other locations of the cassette.
+func leakedSecretIssues(location cassetteLocation, knownSecrets map[string]string) []string {
+	messages := []string(nil)
+	values := map[string]bool{}
+
+	for _, secret := range location.secrets {
+		messages = append(messages, location.describe(secret)+" has not been anonymized")
+		values[secret.value] = true
+	}
+
+	for _, accessKey := range slices.Compact(slices.Sorted(slices.Values(accessKeyPattern.FindAllString(location.text, -1)))) {
+		if !isAnonymized(strings.TrimPrefix(accessKey, "SCW"), nil) {
+			messages = append(messages, fmt.Sprintf("%s holds the Scaleway access key %s...", location.name, accessKey[:6]))
+		}
+	}
+
+	for _, value := range slices.Sorted(maps.Keys(knownSecrets)) {
+		if !values[value] && strings.Contains(location.text, value) {
+			messages = append(messages, fmt.Sprintf("%s holds the value of %s", location.name, knownSecrets[value]))
+		}
+	}
+
+	return messages
+}
+
+// querySecrets returns the credentials held by the query parameters of a URL
+func querySecrets(rawURL string) []leakedSecret {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+
+	query := parsed.Query()
+	secrets := []leakedSecret(nil)
+
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		for _, value := range query[key] {
+			if isLeakedSecret(key, value) {
+				secrets = append(secrets, leakedSecret{field: key, value: value})
+			}
+		}
+	}
+
+	return secrets
+}
+
+// bodySecrets returns the credentials held by the fields of a JSON body, or by the key: value and key=value pairs
+// of other bodies like kubeconfigs and forms. Unlike the anonymizer applied when recording, nested objects are
+// inspected, as well as the text encoded in base64 in JSON strings.
+func bodySecrets(body string) []leakedSecret {
+	var jsonBody any
+
+	if json.Unmarshal([]byte(body), &jsonBody) != nil {
+		return textSecrets("", body)
+	}
+
+	var secrets []leakedSecret
+
+	var walk func(path string, value any)
+
+	walk = func(path string, value any) {
+		switch value := value.(type) {
+		case map[string]any:
+			for _, key := range slices.Sorted(maps.Keys(value)) {
+				fieldPath := strings.TrimPrefix(path+"."+key, ".")
+
+				if isLeakedSecret(key, value[key]) {
+					secrets = append(secrets, leakedSecret{field: fieldPath, value: fmt.Sprint(value[key])})
+
+					continue
+				}
+
+				walk(fieldPath, value[key])
+			}
+		case []any:
+			for i, item := range value {
+				walk(fmt.Sprintf("%s[%d]", path, i), item)
+			}
+		case string:
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err == nil && len(decoded) > 0 && utf8.Valid(decoded) {
+				secrets = append(secrets, textSecrets(path+" (base64)", string(decoded))...)
+			}
+		}
+	}
+
+	walk("", jsonBody)
+
+	return secrets
+}
+
+// textSecrets returns the credentials held by the key: value and key=value pairs of a text
+func textSecrets(path string, text string) []leakedSecret {
+	secrets := []leakedSecret(nil)
+
+	for _, match := range secretAssignmentPattern.FindAllStringSubmatch(text, -1) {
+		if isLeakedSecret(match[1], match[2]) {
+			secrets = append(secrets, leakedSecret{field: strings.TrimPrefix(path+"."+match[1], "."), value: match[2]})
+		}
+	}
+
+	return secrets
+}
+
+// isLeakedSecret returns true if a field holds a credential that has not been anonymized: a field of SensitiveFields
+// not holding its placeholder, or a secret-like field (token, password, secret* or *_key) holding a UUID or a token
+func isLeakedSecret(key string, value any) bool {
+	if anonymized, sensitive := SensitiveFields[key]; sensitive {
+		return !isAnonymized(value, anonymized)
+	}
+
+	str, isString := value.(string)
+	if !isString || !isSecretKey(key) {
+		return false
+	}
+
+	return (uuidPattern.MatchString(str) || tokenPattern.MatchString(str)) && !isAnonymized(str, nil)
+}
+
+// isSecretKey returns true if the name of a field, a query parameter or a header tells that it holds a credential
+func isSecretKey(key string) bool {
+	key = strings.ToLower(strings.ReplaceAll(key, "-", "_"))
+
+	// The IDs and checksums of secrets, like the secret_id of a secret manager version, are not credentials
+	if strings.HasSuffix(key, "_id") || strings.HasSuffix(key, "_ids") || strings.HasSuffix(key, "_checksum") {
+		return false
+	}
+
+	return key == "token" || key == "password" || strings.HasPrefix(key, "secret") || strings.HasSuffix(key, "_key")
+}
+
+// isAnonymized returns true if a sensitive value is empty or has been replaced by a placeholder,
+// either the one of SensitiveFields or a value repeating a single character like 11111111-1111-1111-1111-111111111111
+func isAnonymized(value any, anonymized any) bool {
+	if value == nil || reflect.DeepEqual(value, anonymized) {
+		return true
+	}
+
+	str, isString := value.(string)
+	if !isString {
+		return false
+	}
+
+	str = strings.ReplaceAll(str, "-", "")
+
+	return str == "" || strings.Count(str, str[:1]) == len(str)
+}
+
+// responseStatus returns the status field of a JSON response
+func responseStatus(interaction *cassette.Interaction) (string, bool) {
+	var m map[string]any
+
+	if json.Unmarshal([]byte(interaction.Response.Body), &m) != nil {
+		return "", false
+	}
+
+	status, ok := m["status"].(string)
+
+	return status, ok
+}
+
+// isSamePolling returns true if both interactions read the same resource and got the same response
+func isSamePolling(previous, current *cassette.Interaction) bool {
+	if previous.Request.Method != http.MethodGet || current.Request.Method != http.MethodGet || previous.Request.URL != current.Request.URL {
+		return false
+	}
+
+	return previous.Response.Code == current.Response.Code && previous.Response.Body == current.Response.Body
+}
+
+// isPolledAgain returns true if a later GET request reads the same URL
+func isPolledAgain(interactions []*cassette.Interaction, url string) bool {
+	for _, interaction := range interactions {
+		if interaction.Request.Method == http.MethodGet && interaction.Request.URL == url {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasLintErrors returns true if one of the issues must fail the check
+func HasLintErrors(issues []LintIssue) bool {
+	return slices.ContainsFunc(issues, func(issue LintIssue) bool {
+		return issue.Severity == LintSeverityError
+	})
+}
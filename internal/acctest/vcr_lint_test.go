@@ -0,0 +1,281 @@
+package acctest_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/dnaeon/go-vcr.v3/cassette"
+)
+
+const testServerURL = "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers/11111111-1111-1111-1111-111111111111"
+
+func newTestInteraction(method string, url string, code int, body string) *cassette.Interaction {
+	return &cassette.Interaction{
+		Request: cassette.Request{
+			Method:  method,
+			URL:     url,
+			Headers: http.Header{},
+		},
+		Response: cassette.Response{
+			Code: code,
+			Body: body,
+		},
+	}
+}
+
+func saveTestCassette(t *testing.T, name string, interactions ...*cassette.Interaction) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+
+	c := cassette.New(path)
+	for _, interaction := range interactions {
+		c.AddInteraction(interaction)
+	}
+
+	require.NoError(t, c.Save())
+
+	return path
+}
+
+func lintRules(issues []acctest.LintIssue) []string {
+	rules := []string(nil)
+	for _, issue := range issues {
+		rules = append(rules, issue.Rule)
+	}
+
+	return rules
+}
+
+func TestLintCassette_LeakedSecret(t *testing.T) {
+	authenticated := newTestInteraction(http.MethodGet, testServerURL, http.StatusOK, `{}`)
+	authenticated.Request.Headers.Set("X-Auth-Token", "secret")
+
+	path := saveTestCassette(t, "leaked",
+		newTestInteraction(http.MethodPost, "https://api.scaleway.com/iam/v1alpha1/api-keys", http.StatusOK, `{"access_key":"SCWXXXXXXXXXXXXXXXXX","secret_key":"00000000-0000-0000-0000-000000000000"}`),
+		newTestInteraction(http.MethodGet, "https://api.scaleway.com/iam/v1alpha1/api-keys", http.StatusOK, `{"api_keys":[{"secret_key":"4kQNJwhDT6bP692UCTSMZHr390tj4Nt7"}]}`),
+		newTestInteraction(http.MethodGet, "https://api.scaleway.com/iam/v1alpha1/api-keys", http.StatusOK, `{"api_keys":[{"secret_key":"11111111-1111-1111-1111-111111111111"},{"secret_key":null}]}`),
+		authenticated,
+	)
+
+	issues, err := acctest.LintCassette(path)
+	require.NoError(t, err)
+
+	require.Len(t, issues, 2)
+	assert.Equal(t, 1, issues[0].Interaction)
+	assert.Contains(t, issues[0].Message, "api_keys[0].secret_key")
+	assert.Equal(t, 3, issues[1].Interaction)
+	assert.Contains(t, issues[1].Message, "X-Auth-Token")
+	assert.True(t, acctest.HasLintErrors(issues))
+}
+
+func TestLintCassette_LeakedSecretValues(t *testing.T) {
+	const (
+		apiKeysURL = "https://api.scaleway.com/iam/v1alpha1/api-keys"
+		secretKey  = "5f3b1e6a-8c2d-4e7f-9a0b-1c2d3e4f5a6b"
+		kubeconfig = "apiVersion: v1\nusers:\n- name: admin\n  user:\n    token: r3ibw1nkFknbtSe2VcRslgExTfiiZ3lfI6I4SYv5IDhLXrERc9sXPlFU\n"
+	)
+
+	withHeader := func(interaction *cassette.Interaction, name string, value string) *cassette.Interaction {
+		interaction.Request.Headers.Set(name, value)
+
+		return interaction
+	}
+
+	tests := []struct {
+		name         string
+		interactions []*cassette.Interaction
+		want         []string
+	}{
+		{
+			name: "access key in a URL",
+			interactions: []*cassette.Interaction{
+				newTestInteraction(http.MethodGet, apiKeysURL+"/SCW1A2B3C4D5E6F7G8H9", http.StatusOK, `{}`),
+			},
+			want: []string{"request URL holds the Scaleway access key SCW1A2..."},
+		},
+		{
+			name: "access key in a header",
+			interactions: []*cassette.Interaction{
+				withHeader(newTestInteraction(http.MethodGet, apiKeysURL, http.StatusOK, `{}`), "X-Access-Key", "SCW1A2B3C4D5E6F7G8H9"),
+			},
+			want: []string{"request header X-Access-Key holds the Scaleway access key SCW1A2..."},
+		},
+		{
+			name: "access key in a JSON body",
+			interactions: []*cassette.Interaction{
+				newTestInteraction(http.MethodPost, apiKeysURL, http.StatusOK, `{"access_key":"SCW1A2B3C4D5E6F7G8H9"}`),
+			},
+			want: []string{"response body holds the Scaleway access key SCW1A2..."},
+		},
+		{
+			name: "access key in a non-JSON body",
+			interactions: []*cassette.Interaction{
+				newTestInteraction(http.MethodPut, "https://test-bucket.s3.fr-par.scw.cloud/config", http.StatusOK, "key SCW1A2B3C4D5E6F7G8H9 of the bucket"),
+			},
+			want: []string{"response body holds the Scaleway access key SCW1A2..."},
+		},
+		{
+			name: "secret key in a later URL",
+			interactions: []*cassette.Interaction{
+				newTestInteraction(http.MethodPost, apiKeysURL, http.StatusOK, `{"api_key":{"secret_key":"`+secretKey+`"}}`),
+				newTestInteraction(http.MethodGet, "https://api.scaleway.com/secret-manager/v1beta1/regions/fr-par/secrets/"+secretKey, http.StatusOK, `{}`),
+			},
+			want: []string{
+				"response body field api_key.secret_key has not been anonymized",
+				"request URL holds the value of response body field api_key.secret_key of interaction 0",
+			},
+		},
+		{
+			name: "secret key in a query string",
+			interactions: []*cassette.Interaction{
+				newTestInteraction(http.MethodGet, apiKeysURL+"?secret_key="+secretKey, http.StatusOK, `{}`),
+			},
+			want: []string{"request URL field secret_key has not been anonymized"},
+		},
+		{
+			name: "secret key in a header",
+			interactions: []*cassette.Interaction{
+				withHeader(newTestInteraction(http.MethodGet, apiKeysURL, http.StatusOK, `{}`), "X-Secret-Key", secretKey),
+			},
+			want: []string{"request header X-Secret-Key has not been anonymized"},
+		},
+		{
+			name: "token and password in a JSON response",
+			interactions: []*cassette.Interaction{
+				newTestInteraction(http.MethodPost, "https://api.scaleway.com/rdb/v1/regions/fr-par/instances", http.StatusOK, `{"users":[{"password":"`+secretKey+`"}],"token":"`+secretKey+`"}`),
+			},
+			want: []string{
+				"response body field token has not been anonymized",
+				"response body field users[0].password has not been anonymized",
+			},
+		},
+		{
+			name: "token in a form body",
+			interactions: []*cassette.Interaction{
+				newTestInteraction(http.MethodPost, "https://api.scaleway.com/oauth/token", http.StatusOK, "grant_type=password&password="+secretKey),
+			},
+			want: []string{"response body field password has not been anonymized"},
+		},
+		{
+			name: "kubeconfig token",
+			interactions: []*cassette.Interaction{
+				newTestInteraction(http.MethodGet, "https://api.scaleway.com/k8s/v1/regions/fr-par/clusters/11111111-1111-1111-1111-111111111111/kubeconfig", http.StatusOK,
+					`{"name":"kubeconfig","content":"`+base64.StdEncoding.EncodeToString([]byte(kubeconfig))+`"}`),
+				newTestInteraction(http.MethodGet, "https://11111111-1111-1111-1111-111111111111.api.k8s.fr-par.scw.cloud:6443/config", http.StatusOK, kubeconfig),
+			},
+			want: []string{
+				"response body field content (base64).token has not been anonymized",
+				"response body field token has not been anonymized",
+			},
+		},
+		{
+			name: "anonymized values and secret IDs",
+			interactions: []*cassette.Interaction{
+				withHeader(newTestInteraction(http.MethodGet, apiKeysURL+"/SCWXXXXXXXXXXXXXXXXX?secret_key=00000000-0000-0000-0000-000000000000", http.StatusOK,
+					`{"access_key":"SCWXXXXXXXXXXXXXXXXX","secret_id":"`+secretKey+`","token":"11111111-1111-1111-1111-111111111111","password":null}`), "X-Request-Id", secretKey),
+				newTestInteraction(http.MethodGet, "https://api.scaleway.com/secret-manager/v1beta1/regions/fr-par/secrets/"+secretKey, http.StatusOK, "token: short"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := acctest.LintCassette(saveTestCassette(t, "leaked", tt.interactions...))
+			require.NoError(t, err)
+
+			messages := []string(nil)
+			for _, issue := range issues {
+				assert.Equal(t, acctest.LintRuleLeakedSecret, issue.Rule)
+				messages = append(messages, issue.Message)
+			}
+
+			assert.Equal(t, tt.want, messages)
+		})
+	}
+}
+
+func TestLintCassette_Polling(t *testing.T) {
+	starting := `{"server":{"state":"starting"},"status":"starting"}`
+	running := `{"server":{"state":"running"},"status":"running"}`
+
+	path := saveTestCassette(t, "polling",
+		newTestInteraction(http.MethodPost, testServerURL+"/action", http.StatusAccepted, `{}`),
+		newTestInteraction(http.MethodGet, testServerURL, http.StatusOK, starting),
+		newTestInteraction(http.MethodGet, testServerURL, http.StatusOK, starting),
+		newTestInteraction(http.MethodGet, testServerURL, http.StatusOK, running),
+		newTestInteraction(http.MethodGet, testServerURL, http.StatusOK, running),
+		newTestInteraction(http.MethodGet, testServerURL, http.StatusOK, running),
+		newTestInteraction(http.MethodPost, testServerURL+"/action", http.StatusAccepted, `{}`),
+		newTestInteraction(http.MethodGet, testServerURL, http.StatusOK, starting),
+	)
+
+	issues, err := acctest.LintCassette(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		acctest.LintRuleUncompressedWaitLoop,
+		acctest.LintRuleDuplicatePolling,
+		acctest.LintRuleUnmatchedPolling,
+	}, lintRules(issues))
+	assert.Equal(t, []int{2, 5, 7}, []int{issues[0].Interaction, issues[1].Interaction, issues[2].Interaction})
+	assert.False(t, acctest.HasLintErrors(issues))
+}
+
+func TestDiffCassettes(t *testing.T) {
+	oldPath := saveTestCassette(t, "old",
+		newTestInteraction(http.MethodPost, "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers", http.StatusCreated, `{"server":{"id":"11111111-1111-1111-1111-111111111111","name":"tf-srv-gifted-yonath","commercial_type":"DEV1-S","creation_date":"2025-01-01T00:00:00Z"}}`),
+		newTestInteraction(http.MethodGet, "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers/11111111-1111-1111-1111-111111111111", http.StatusOK, `{"server":{"tags":["a"]}}`),
+	)
+	newPath := saveTestCassette(t, "new",
+		newTestInteraction(http.MethodPost, "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers", http.StatusCreated, `{"server":{"id":"22222222-2222-2222-2222-222222222222","name":"tf-srv-eager-turing","commercial_type":"DEV1-M","creation_date":"2026-01-01T00:00:00Z","arch":"x86_64"}}`),
+		newTestInteraction(http.MethodGet, "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers/22222222-2222-2222-2222-222222222222", http.StatusOK, `{"server":{"tags":["a","b"]}}`),
+		newTestInteraction(http.MethodDelete, "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers/22222222-2222-2222-2222-222222222222", http.StatusNoContent, ""),
+	)
+
+	diff, err := acctest.DiffCassettes(oldPath, newPath+".yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, diff.OldInteractions)
+	assert.Equal(t, 3, diff.NewInteractions)
+	assert.Greater(t, diff.Growth(), 1.0)
+
+	require.Len(t, diff.Interactions, 3)
+	assert.Equal(t, []string{
+		`response.body.server.arch: added "x86_64"`,
+		`response.body.server.commercial_type: "DEV1-S" -> "DEV1-M"`,
+	}, diff.Interactions[0].Changes)
+	assert.Equal(t, []string{"response.body.server.tags: 1 items -> 2 items"}, diff.Interactions[1].Changes)
+	assert.Equal(t, []string{"added"}, diff.Interactions[2].Changes)
+
+	output := &strings.Builder{}
+	diff.Print(output)
+	assert.Contains(t, output.String(), "interaction 2: DELETE")
+}
+
+func TestCassetteStats(t *testing.T) {
+	path := saveTestCassette(t, "stats",
+		newTestInteraction(http.MethodGet, testServerURL, http.StatusOK, `{}`),
+		newTestInteraction(http.MethodGet, testServerURL, http.StatusNotFound, `{}`),
+		newTestInteraction(http.MethodGet, "https://api.scaleway.com/vpc/v2/regions/fr-par/private-networks", http.StatusOK, `{}`),
+		newTestInteraction(http.MethodPut, "https://test-bucket.s3.fr-par.scw.cloud/", http.StatusOK, ""),
+	)
+
+	stats := acctest.NewCassetteStats()
+	require.NoError(t, stats.AddCassette(path))
+
+	assert.Equal(t, 1, stats.Cassettes)
+	assert.Equal(t, 4, stats.Interactions)
+	assert.Equal(t, 1, stats.Errors)
+	assert.Equal(t, map[string]int{
+		"instance/v1": 2,
+		"vpc/v2":      1,
+		"s3":          1,
+	}, stats.Requests)
+}
@@ -0,0 +1,102 @@
+package acctest
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// CassetteStats counts the requests recorded in one or several cassettes
+type CassetteStats struct {
+	Cassettes    int
+	Interactions int
+	Size         int64
+	// Errors counts the responses with a status code of 400 or more
+	Errors int
+	// Requests counts the requests per API, see CassetteAPI
+	Requests map[string]int
+}
+
+// NewCassetteStats returns empty statistics to which cassettes can be added
+func NewCassetteStats() *CassetteStats {
+	return &CassetteStats{
+		Requests: map[string]int{},
+	}
+}
+
+// AddCassette adds the interactions of the cassette at the given path to the statistics
+func (stats *CassetteStats) AddCassette(path string) error {
+	c, size, err := loadCassette(path)
+	if err != nil {
+		return err
+	}
+
+	stats.Cassettes++
+	stats.Size += size
+
+	for _, interaction := range c.Interactions {
+		stats.Interactions++
+		stats.Requests[CassetteAPI(interaction.Request.URL)]++
+
+		if interaction.Response.Code >= 400 {
+			stats.Errors++
+		}
+	}
+
+	return nil
+}
+
+// Print writes the statistics as a table, the most requested APIs first
+func (stats *CassetteStats) Print(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "%d cassettes, %d interactions, %d bytes, %d error responses\n", stats.Cassettes, stats.Interactions, stats.Size, stats.Errors)
+
+	apis := make([]string, 0, len(stats.Requests))
+	for api := range stats.Requests {
+		apis = append(apis, api)
+	}
+
+	sort.Slice(apis, func(i, j int) bool {
+		if stats.Requests[apis[i]] != stats.Requests[apis[j]] {
+			return stats.Requests[apis[i]] > stats.Requests[apis[j]]
+		}
+
+		return apis[i] < apis[j]
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "API\tREQUESTS")
+
+	for _, api := range apis {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\n", api, stats.Requests[api])
+	}
+
+	_ = tw.Flush()
+}
+
+// CassetteAPI returns the API targeted by a recorded request URL.
+// Requests to api.scaleway.com are identified by their product and version, e.g. instance/v1,
+// object storage requests by s3 and any other request by its host.
+func CassetteAPI(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "unknown"
+	}
+
+	if (strings.HasSuffix(u.Host, "scw.cloud") && strings.Contains(u.Host, ".s3.")) || strings.HasPrefix(u.Host, "s3.") {
+		return "s3"
+	}
+
+	if u.Host != "api.scaleway.com" {
+		return u.Host
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 {
+		return u.Host
+	}
+
+	return segments[0] + "/" + segments[1]
+}
@@ -0,0 +1,113 @@
+package cdf
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+)
+
+// WithProviderTags makes a resource with a tags list use the provider default_tags and ignore_tags.
+// The tags attribute becomes computed so that the merged tags can be planned.
+func WithProviderTags(r *schema.Resource) {
+	if r.SchemaFunc == nil {
+		return
+	}
+
+	schemaFunc := r.SchemaFunc
+
+	tagsSchema, ok := schemaFunc()["tags"]
+	if !ok || tagsSchema.Type != schema.TypeList || !tagsSchema.Optional {
+		return
+	}
+
+	// Resources already computing their tags keep the remote tags when none are configured
+	keepUnsetTags := tagsSchema.Computed
+
+	r.SchemaFunc = func() map[string]*schema.Schema {
+		s := schemaFunc()
+		s["tags"].Computed = true
+
+		return s
+	}
+
+	if r.CustomizeDiff == nil {
+		r.CustomizeDiff = ProviderTags(keepUnsetTags)
+	} else {
+		r.CustomizeDiff = customdiff.All(r.CustomizeDiff, ProviderTags(keepUnsetTags))
+	}
+}
+
+// ProviderTags plans the tags of a resource as its configured tags merged with the provider default tags
+// and the tags matching the provider ignore_tags prefixes that are already set on the resource.
+func ProviderTags(keepUnsetTags bool) schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, m any) error {
+		rawConfig := diff.GetRawConfig()
+		if rawConfig.IsNull() || !rawConfig.Type().HasAttribute("tags") {
+			return nil
+		}
+
+		rawTags := rawConfig.GetAttr("tags")
+		if !rawTags.IsWhollyKnown() {
+			return nil
+		}
+
+		oldTagsRaw, newTagsRaw := diff.GetChange("tags")
+		oldTags := expandTags(oldTagsRaw)
+
+		var configTags []string
+
+		switch {
+		case !rawTags.IsNull():
+			for _, tag := range rawTags.AsValueSlice() {
+				if !tag.IsNull() {
+					configTags = append(configTags, tag.AsString())
+				}
+			}
+		case keepUnsetTags:
+			configTags = oldTags
+		}
+
+		tags := m.(*meta.Meta).MergeTags(configTags, oldTags)
+
+		// Keep the current order to avoid a diff when only the order changed
+		if sameTags(tags, oldTags) {
+			tags = oldTags
+		}
+
+		if diff.NewValueKnown("tags") && slices.Equal(tags, expandTags(newTagsRaw)) {
+			return nil
+		}
+
+		return diff.SetNew("tags", tags)
+	}
+}
+
+func expandTags(raw any) []string {
+	rawTags, _ := raw.([]any)
+	tags := make([]string, 0, len(rawTags))
+
+	for _, tag := range rawTags {
+		if tag != nil {
+			tags = append(tags, tag.(string))
+		}
+	}
+
+	return tags
+}
+
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, tag := range a {
+		if !slices.Contains(b, tag) {
+			return false
+		}
+	}
+
+	return true
+}
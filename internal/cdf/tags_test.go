@@ -0,0 +1,116 @@
+package cdf_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderTags(t *testing.T) {
+	t.Setenv("SCW_ACCESS_KEY", "SCWXXXXXXXXXXXXXXXXX")
+	t.Setenv("SCW_SECRET_KEY", "866F4A9A-D058-4D3C-A39F-86930849CCC0")
+	t.Setenv("SCW_DEFAULT_PROJECT_ID", "866F4A9A-D058-4D3C-A39F-86930849CCC0")
+
+	m, err := meta.NewMeta(t.Context(), &meta.Config{
+		DefaultTags: []string{"owner=platform"},
+		IgnoreTags:  []string{"k8s"},
+	})
+	require.NoError(t, err)
+
+	r := &schema.Resource{
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"tags": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			}
+		},
+	}
+	cdf.WithProviderTags(r)
+
+	assert.True(t, r.SchemaMap()["tags"].Computed)
+
+	tags := func(values ...string) cty.Value {
+		if len(values) == 0 {
+			return cty.NullVal(cty.List(cty.String))
+		}
+
+		list := make([]cty.Value, 0, len(values))
+		for _, value := range values {
+			list = append(list, cty.StringVal(value))
+		}
+
+		return cty.ListVal(list)
+	}
+
+	tests := []struct {
+		name     string
+		state    map[string]string
+		config   cty.Value
+		expected map[string]string
+	}{
+		{
+			name:     "create with default tags",
+			config:   tags("env=prod"),
+			expected: map[string]string{"tags.#": "2", "tags.0": "env=prod", "tags.1": "owner=platform"},
+		},
+		{
+			name:     "create without tags",
+			config:   tags(),
+			expected: map[string]string{"tags.#": "1", "tags.0": "owner=platform"},
+		},
+		{
+			name:     "no diff on merged and ignored tags",
+			state:    map[string]string{"tags.#": "3", "tags.0": "env=prod", "tags.1": "k8s-cluster=abc", "tags.2": "owner=platform"},
+			config:   tags("env=prod"),
+			expected: map[string]string{},
+		},
+		{
+			name:     "removed tags keep ignored tags",
+			state:    map[string]string{"tags.#": "3", "tags.0": "env=prod", "tags.1": "k8s-cluster=abc", "tags.2": "owner=platform"},
+			config:   tags(),
+			expected: map[string]string{"tags.#": "2", "tags.0": "owner=platform"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				RawConfig: cty.ObjectVal(map[string]cty.Value{
+					"id":   cty.NullVal(cty.String),
+					"tags": tt.config,
+				}),
+			}
+			if tt.state != nil {
+				state.ID = "11111111-1111-1111-1111-111111111111"
+				state.Attributes = tt.state
+			}
+
+			diff, err := r.SimpleDiff(t.Context(), state, terraform.NewResourceConfigShimmed(state.RawConfig, r.CoreConfigSchema()), m)
+			require.NoError(t, err)
+
+			planned := map[string]string{}
+			if diff != nil {
+				for key, attr := range diff.Attributes {
+					planned[key] = attr.New
+				}
+			}
+
+			for key, value := range tt.expected {
+				assert.Equal(t, value, planned[key], key)
+			}
+
+			if len(tt.expected) == 0 {
+				assert.Empty(t, planned)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ const (
 	RetryDelay = "TF_RETRY_DELAY"
 	// UpdateCassettes if set to "true" will trigger the cassettes to be recorded
 	UpdateCassettes = "TF_UPDATE_CASSETTES"
+	// MockAPI if set to "true" will run the acceptance tests against an in-process mock of the Scaleway API instead of cassettes
+	MockAPI = "TF_MOCK_API"
 	// TestDomain is the DNS domain used during our tests
 	TestDomain = "TF_TEST_DOMAIN"
 	// TestDomainZone is the DNS zone used during our tests
@@ -0,0 +1,76 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+)
+
+var _ function.Function = &BuildRegionalID{}
+
+type BuildRegionalID struct{}
+
+func NewBuildRegionalID() function.Function {
+	return &BuildRegionalID{}
+}
+
+func (f *BuildRegionalID) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_regional_id"
+}
+
+func (f *BuildRegionalID) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a regional ID",
+		Description: "Given a region and an ID, returns the regional ID `<region>/<id>`. If the ID is already localized, its locality is replaced.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "region",
+				Description: "region of the ID",
+			},
+			function.StringParameter{
+				Name:        "id",
+				Description: "id to localize",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *BuildRegionalID) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var regionInput, idInput types.String
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &regionInput, &idInput))
+
+	if regionInput.IsNull() || regionInput.IsUnknown() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, regionInput))
+
+		return
+	}
+
+	if idInput.IsNull() || idInput.IsUnknown() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, idInput))
+
+		return
+	}
+
+	region, err := parseRegion(regionInput.ValueString())
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewStringUnknown()))
+
+		return
+	}
+
+	id, err := unlocalizedID(idInput.ValueString())
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, err.Error()))
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewStringUnknown()))
+
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, types.StringValue(regional.NewIDString(region, id))))
+}
@@ -0,0 +1,76 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+)
+
+var _ function.Function = &BuildZonalID{}
+
+type BuildZonalID struct{}
+
+func NewBuildZonalID() function.Function {
+	return &BuildZonalID{}
+}
+
+func (f *BuildZonalID) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_zonal_id"
+}
+
+func (f *BuildZonalID) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a zonal ID",
+		Description: "Given a zone and an ID, returns the zonal ID `<zone>/<id>`. If the ID is already localized, its locality is replaced.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "zone",
+				Description: "zone of the ID",
+			},
+			function.StringParameter{
+				Name:        "id",
+				Description: "id to localize",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *BuildZonalID) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var zoneInput, idInput types.String
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &zoneInput, &idInput))
+
+	if zoneInput.IsNull() || zoneInput.IsUnknown() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, zoneInput))
+
+		return
+	}
+
+	if idInput.IsNull() || idInput.IsUnknown() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, idInput))
+
+		return
+	}
+
+	zone, err := parseZone(zoneInput.ValueString())
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewStringUnknown()))
+
+		return
+	}
+
+	id, err := unlocalizedID(idInput.ValueString())
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, err.Error()))
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewStringUnknown()))
+
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, types.StringValue(zonal.NewIDString(zone, id))))
+}
@@ -0,0 +1,99 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &CIDROverlaps{}
+
+type CIDROverlaps struct{}
+
+func NewCIDROverlaps() function.Function {
+	return &CIDROverlaps{}
+}
+
+func (f *CIDROverlaps) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_overlaps"
+}
+
+func (f *CIDROverlaps) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check if CIDRs overlap",
+		Description: "Given a list of IPv4 and IPv6 CIDRs, returns true if at least two of them overlap.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "cidrs",
+				Description: "CIDRs to check",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *CIDROverlaps) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input types.List
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input))
+
+	if input.IsNull() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewBoolNull()))
+
+		return
+	}
+
+	if input.IsUnknown() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewBoolUnknown()))
+
+		return
+	}
+
+	var cidrs []types.String
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, input.ElementsAs(ctx, &cidrs, false)))
+	if resp.Error != nil {
+		return
+	}
+
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+
+	for i, cidr := range cidrs {
+		if cidr.IsUnknown() {
+			resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewBoolUnknown()))
+
+			return
+		}
+
+		if cidr.IsNull() {
+			continue
+		}
+
+		prefix, err := parsePrefix(cidr.ValueString())
+		if err != nil {
+			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, fmt.Sprintf("element %d: %s", i, err)))
+			resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewBoolUnknown()))
+
+			return
+		}
+
+		prefixes = append(prefixes, prefix)
+	}
+
+	overlaps := false
+
+	for i := range prefixes {
+		for j := i + 1; j < len(prefixes); j++ {
+			if prefixes[i].Overlaps(prefixes[j]) {
+				overlaps = true
+			}
+		}
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, types.BoolValue(overlaps)))
+}
@@ -0,0 +1,86 @@
+package functions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/functions"
+)
+
+func TestCIDROverlapsFunctionRun(t *testing.T) {
+	t.Parallel()
+
+	stringList := func(values ...attr.Value) types.List {
+		return types.ListValueMust(types.StringType, values)
+	}
+
+	testCases := map[string]struct {
+		expected function.RunResponse
+		request  function.RunRequest
+	}{
+		"unknown-element": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{stringList(types.StringValue("10.0.0.0/24"), types.StringUnknown())}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.BoolUnknown()),
+			},
+		},
+		"disjoint": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{stringList(
+					types.StringValue("10.0.0.0/24"),
+					types.StringValue("10.0.1.0/24"),
+					types.StringValue("fd00::/64"),
+				)}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.BoolValue(false)),
+			},
+		},
+		"overlapping": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{stringList(
+					types.StringValue("10.0.0.0/24"),
+					types.StringValue("10.0.1.0/24"),
+					types.StringValue("10.0.0.0/16"),
+				)}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.BoolValue(true)),
+			},
+		},
+		"invalid": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{stringList(
+					types.StringValue("10.0.0.0/24"),
+					types.StringValue("10.0.0.0/33"),
+				)}),
+			},
+			expected: function.RunResponse{
+				Error:  function.NewArgumentFuncError(0, `element 1: invalid CIDR "10.0.0.0/33": netip.ParsePrefix("10.0.0.0/33"): prefix length out of range`),
+				Result: function.NewResultData(types.BoolUnknown()),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := function.RunResponse{
+				Result: function.NewResultData(types.BoolUnknown()),
+			}
+
+			functions.NewCIDROverlaps().Run(context.Background(), testCase.request, &got)
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &CIDRReservedAddresses{}
+
+type CIDRReservedAddresses struct{}
+
+func NewCIDRReservedAddresses() function.Function {
+	return &CIDRReservedAddresses{}
+}
+
+func (f *CIDRReservedAddresses) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_reserved_addresses"
+}
+
+func (f *CIDRReservedAddresses) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "List the addresses reserved by Scaleway in a private network subnet",
+		Description: "Given an IPv4 or IPv6 private network subnet, returns the addresses that Scaleway reserves and that cannot be used as static IPs: " +
+			"the network address, the default gateway which is the first address of the subnet and, for IPv4, the broadcast address.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "cidr",
+				Description: "subnet of the private network",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *CIDRReservedAddresses) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input types.String
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input))
+
+	if input.IsNull() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewListNull(types.StringType)))
+
+		return
+	}
+
+	if input.IsUnknown() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewListUnknown(types.StringType)))
+
+		return
+	}
+
+	prefix, err := parsePrefix(input.ValueString())
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewListUnknown(types.StringType)))
+
+		return
+	}
+
+	addresses := []attr.Value(nil)
+	for _, addr := range reservedAddresses(prefix) {
+		addresses = append(addresses, types.StringValue(addr.String()))
+	}
+
+	result, diags := basetypes.NewListValue(types.StringType, addresses)
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,88 @@
+package functions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/functions"
+)
+
+func TestCIDRReservedAddressesFunctionRun(t *testing.T) {
+	t.Parallel()
+
+	stringList := func(values ...string) types.List {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, types.StringValue(value))
+		}
+
+		return types.ListValueMust(types.StringType, elements)
+	}
+
+	testCases := map[string]struct {
+		expected function.RunResponse
+		request  function.RunRequest
+	}{
+		"unknown": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringUnknown()}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(types.StringType)),
+			},
+		},
+		"ipv4": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("172.16.32.0/22")}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(stringList("172.16.32.0", "172.16.32.1", "172.16.35.255")),
+			},
+		},
+		"ipv4-not-masked": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("192.168.1.42/24")}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(stringList("192.168.1.0", "192.168.1.1", "192.168.1.255")),
+			},
+		},
+		"ipv6": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("fd46:78ab:30b8:177c::/64")}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(stringList("fd46:78ab:30b8:177c::", "fd46:78ab:30b8:177c::1")),
+			},
+		},
+		"invalid": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("172.16.32.0")}),
+			},
+			expected: function.RunResponse{
+				Error:  function.NewArgumentFuncError(0, `invalid CIDR "172.16.32.0": netip.ParsePrefix("172.16.32.0"): no '/'`),
+				Result: function.NewResultData(types.ListUnknown(types.StringType)),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(types.StringType)),
+			}
+
+			functions.NewCIDRReservedAddresses().Run(context.Background(), testCase.request, &got)
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
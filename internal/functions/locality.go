@@ -0,0 +1,82 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+)
+
+// parseZone parses a zone and checks that it is known by the Scaleway SDK
+func parseZone(value string) (scw.Zone, error) {
+	zone, err := scw.ParseZone(value)
+	if err != nil || !zone.Exists() {
+		zones := make([]string, 0, len(scw.AllZones))
+		for _, z := range scw.AllZones {
+			zones = append(zones, z.String())
+		}
+
+		return "", fmt.Errorf("unknown zone %q, available zones are: %s", value, strings.Join(zones, ", "))
+	}
+
+	return zone, nil
+}
+
+// parseRegion parses a region and checks that it is known by the Scaleway SDK
+func parseRegion(value string) (scw.Region, error) {
+	region, err := scw.ParseRegion(value)
+	if err != nil || !region.Exists() {
+		regions := make([]string, 0, len(scw.AllRegions))
+		for _, r := range scw.AllRegions {
+			regions = append(regions, r.String())
+		}
+
+		return "", fmt.Errorf("unknown region %q, available regions are: %s", value, strings.Join(regions, ", "))
+	}
+
+	return region, nil
+}
+
+// checkLocality checks that a locality is a zone or a region known by the Scaleway SDK
+func checkLocality(value string) error {
+	if scw.Zone(value).Exists() || scw.Region(value).Exists() {
+		return nil
+	}
+
+	return fmt.Errorf("unknown locality %q, expected a zone or a region", value)
+}
+
+// localityFromID returns the locality of a localized ID, nested or not
+func localityFromID(id string) (string, error) {
+	loc, _, err := locality.ParseLocalizedID(id)
+	if err == nil {
+		return loc, nil
+	}
+
+	loc, _, _, err = locality.ParseLocalizedNestedID(id)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse ID %q: expected <locality>/<id>", id)
+	}
+
+	return loc, nil
+}
+
+// unlocalizedID strips the locality of an ID when there is one
+func unlocalizedID(id string) (string, error) {
+	if id == "" {
+		return "", errors.New("ID cannot be empty")
+	}
+
+	if !strings.Contains(id, "/") {
+		return id, nil
+	}
+
+	_, rawID, err := locality.ParseLocalizedID(id)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse ID %q: expected <id> or <locality>/<id>", id)
+	}
+
+	return rawID, nil
+}
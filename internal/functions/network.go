@@ -0,0 +1,90 @@
+package functions
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// parsePrefix parses a CIDR and makes sure it is the network address of the subnet
+func parsePrefix(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+	}
+
+	return prefix.Masked(), nil
+}
+
+// lastAddr returns the last address of a prefix
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+
+	for i := prefix.Bits(); i < len(bytes)*8; i++ {
+		bytes[i/8] |= 1 << (7 - i%8)
+	}
+
+	addr, _ := netip.AddrFromSlice(bytes)
+
+	return addr
+}
+
+// reservedAddresses returns the addresses of a private network subnet that cannot be used by resources.
+// Scaleway takes the network address and the first address of the subnet, which is the default gateway.
+// IPv4 subnets also lose their broadcast address.
+func reservedAddresses(prefix netip.Prefix) []netip.Addr {
+	network := prefix.Addr()
+	reserved := []netip.Addr{network}
+
+	gateway := network.Next()
+	if gateway.IsValid() && prefix.Contains(gateway) {
+		reserved = append(reserved, gateway)
+	}
+
+	if network.Is4() {
+		broadcast := lastAddr(prefix)
+		if broadcast != network && broadcast != gateway {
+			reserved = append(reserved, broadcast)
+		}
+	}
+
+	return reserved
+}
+
+// nextFreeSubnet returns the first prefix of the given length in the parent prefix that does not overlap the used prefixes
+func nextFreeSubnet(parent netip.Prefix, used []netip.Prefix, bits int) (netip.Prefix, error) {
+	if bits < parent.Bits() || bits > parent.Addr().BitLen() {
+		return netip.Prefix{}, fmt.Errorf("prefix length must be between %d and %d", parent.Bits(), parent.Addr().BitLen())
+	}
+
+	candidate := netip.PrefixFrom(parent.Addr(), bits)
+
+	for {
+		overlapping := netip.Prefix{}
+
+		for _, prefix := range used {
+			if prefix.Overlaps(candidate) {
+				overlapping = prefix
+
+				break
+			}
+		}
+
+		if !overlapping.IsValid() {
+			return candidate, nil
+		}
+
+		// Skip the whole used prefix when it is bigger than the candidate
+		next := lastAddr(candidate).Next()
+		if overlapping.Bits() < candidate.Bits() {
+			next = lastAddr(overlapping).Next()
+		}
+
+		if !next.IsValid() || !parent.Contains(next) {
+			break
+		}
+
+		candidate = netip.PrefixFrom(next, bits)
+	}
+
+	return netip.Prefix{}, fmt.Errorf("no free /%d subnet left in %s", bits, parent)
+}
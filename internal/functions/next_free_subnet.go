@@ -0,0 +1,112 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &NextFreeSubnet{}
+
+type NextFreeSubnet struct{}
+
+func NewNextFreeSubnet() function.Function {
+	return &NextFreeSubnet{}
+}
+
+func (f *NextFreeSubnet) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "next_free_subnet"
+}
+
+func (f *NextFreeSubnet) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Find the next free subnet of a VPC",
+		Description: "Given the CIDR of a VPC, the subnets already used in it and a prefix length, " +
+			"returns the first subnet of this length in the VPC that does not overlap any used subnet.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "cidr",
+				Description: "CIDR to carve the subnet out of, usually the one of a VPC",
+			},
+			function.ListParameter{
+				Name:        "used_cidrs",
+				Description: "subnets already used, e.g. the subnets of the private networks of the VPC",
+				ElementType: types.StringType,
+			},
+			function.Int64Parameter{
+				Name:        "prefix_length",
+				Description: "prefix length of the subnet to find",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NextFreeSubnet) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		cidr         types.String
+		usedCIDRs    types.List
+		prefixLength types.Int64
+	)
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidr, &usedCIDRs, &prefixLength))
+
+	if cidr.IsNull() || cidr.IsUnknown() || usedCIDRs.IsUnknown() || prefixLength.IsNull() || prefixLength.IsUnknown() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewStringUnknown()))
+
+		return
+	}
+
+	parent, err := parsePrefix(cidr.ValueString())
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewStringUnknown()))
+
+		return
+	}
+
+	var used []types.String
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, usedCIDRs.ElementsAs(ctx, &used, false)))
+	if resp.Error != nil {
+		return
+	}
+
+	usedPrefixes := make([]netip.Prefix, 0, len(used))
+
+	for i, usedCIDR := range used {
+		if usedCIDR.IsUnknown() {
+			resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewStringUnknown()))
+
+			return
+		}
+
+		if usedCIDR.IsNull() {
+			continue
+		}
+
+		prefix, err := parsePrefix(usedCIDR.ValueString())
+		if err != nil {
+			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, fmt.Sprintf("element %d: %s", i, err)))
+			resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewStringUnknown()))
+
+			return
+		}
+
+		usedPrefixes = append(usedPrefixes, prefix)
+	}
+
+	subnet, err := nextFreeSubnet(parent, usedPrefixes, int(prefixLength.ValueInt64()))
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(2, err.Error()))
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewStringUnknown()))
+
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, types.StringValue(subnet.String())))
+}
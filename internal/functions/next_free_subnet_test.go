@@ -0,0 +1,121 @@
+package functions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/functions"
+)
+
+func TestNextFreeSubnetFunctionRun(t *testing.T) {
+	t.Parallel()
+
+	stringList := func(values ...string) types.List {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, types.StringValue(value))
+		}
+
+		return types.ListValueMust(types.StringType, elements)
+	}
+
+	testCases := map[string]struct {
+		expected function.RunResponse
+		request  function.RunRequest
+	}{
+		"unknown-used": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("172.16.0.0/12"), types.ListUnknown(types.StringType), types.Int64Value(22)}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			},
+		},
+		"empty-vpc": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("172.16.0.0/12"), stringList(), types.Int64Value(22)}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.StringValue("172.16.0.0/22")),
+			},
+		},
+		"skip-used-subnets": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue("172.16.0.0/12"),
+					stringList("172.16.0.0/22", "172.16.4.0/24", "172.16.8.0/21"),
+					types.Int64Value(22),
+				}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.StringValue("172.16.16.0/22")),
+			},
+		},
+		"fill-gaps": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue("172.16.0.0/12"),
+					stringList("172.16.0.0/24", "172.16.2.0/24"),
+					types.Int64Value(24),
+				}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.StringValue("172.16.1.0/24")),
+			},
+		},
+		"ipv6": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue("fd46:78ab:30b8::/48"),
+					stringList("fd46:78ab:30b8::/64", "fd46:78ab:30b8:1::/64", "172.16.0.0/22"),
+					types.Int64Value(64),
+				}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.StringValue("fd46:78ab:30b8:2::/64")),
+			},
+		},
+		"full": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue("10.0.0.0/23"),
+					stringList("10.0.0.0/24", "10.0.1.0/25"),
+					types.Int64Value(24),
+				}),
+			},
+			expected: function.RunResponse{
+				Error:  function.NewArgumentFuncError(2, "no free /24 subnet left in 10.0.0.0/23"),
+				Result: function.NewResultData(types.StringUnknown()),
+			},
+		},
+		"prefix-too-short": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("10.0.0.0/16"), stringList(), types.Int64Value(8)}),
+			},
+			expected: function.RunResponse{
+				Error:  function.NewArgumentFuncError(2, "prefix length must be between 16 and 32"),
+				Result: function.NewResultData(types.StringUnknown()),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			functions.NewNextFreeSubnet().Run(context.Background(), testCase.request, &got)
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
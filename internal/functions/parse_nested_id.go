@@ -0,0 +1,85 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+)
+
+var _ function.Function = &ParseNestedID{}
+
+var parseNestedIDAttributeTypes = map[string]attr.Type{
+	"locality": types.StringType,
+	"inner_id": types.StringType,
+	"outer_id": types.StringType,
+}
+
+type ParseNestedID struct{}
+
+func NewParseNestedID() function.Function {
+	return &ParseNestedID{}
+}
+
+func (f *ParseNestedID) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_nested_id"
+}
+
+func (f *ParseNestedID) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a nested ID",
+		Description: "Given a nested ID string value `<locality>/<inner_id>/<outer_id>`, returns an object with its `locality`, `inner_id` and `outer_id`. " +
+			"The outer ID contains every part after the inner ID.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "nested id to parse",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parseNestedIDAttributeTypes,
+		},
+	}
+}
+
+func (f *ParseNestedID) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input types.String
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input))
+
+	if input.IsNull() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewObjectNull(parseNestedIDAttributeTypes)))
+
+		return
+	}
+
+	if input.IsUnknown() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewObjectUnknown(parseNestedIDAttributeTypes)))
+
+		return
+	}
+
+	loc, innerID, outerID, err := locality.ParseLocalizedNestedID(input.ValueString())
+	if err == nil {
+		err = checkLocality(loc)
+	}
+
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewObjectUnknown(parseNestedIDAttributeTypes)))
+
+		return
+	}
+
+	result, diags := basetypes.NewObjectValue(parseNestedIDAttributeTypes, map[string]attr.Value{
+		"locality": types.StringValue(loc),
+		"inner_id": types.StringValue(innerID),
+		"outer_id": types.StringValue(outerID),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
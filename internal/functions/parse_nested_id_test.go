@@ -0,0 +1,94 @@
+package functions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/functions"
+)
+
+func TestParseNestedIDFunctionRun(t *testing.T) {
+	t.Parallel()
+
+	attributeTypes := map[string]attr.Type{
+		"locality": types.StringType,
+		"inner_id": types.StringType,
+		"outer_id": types.StringType,
+	}
+
+	testCases := map[string]struct {
+		expected function.RunResponse
+		request  function.RunRequest
+	}{
+		"unknown": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringUnknown()}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(attributeTypes)),
+			},
+		},
+		"nested-id": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("fr-par/11111111-1111-1111-1111-111111111111/my-database")}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+					"locality": types.StringValue("fr-par"),
+					"inner_id": types.StringValue("11111111-1111-1111-1111-111111111111"),
+					"outer_id": types.StringValue("my-database"),
+				})),
+			},
+		},
+		"outer-id-with-slashes": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("fr-par-1/my-bucket/path/to/object")}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+					"locality": types.StringValue("fr-par-1"),
+					"inner_id": types.StringValue("my-bucket"),
+					"outer_id": types.StringValue("path/to/object"),
+				})),
+			},
+		},
+		"not-nested": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("fr-par/11111111-1111-1111-1111-111111111111")}),
+			},
+			expected: function.RunResponse{
+				Error:  function.NewArgumentFuncError(0, "cant parse localized id: fr-par/11111111-1111-1111-1111-111111111111"),
+				Result: function.NewResultData(types.ObjectUnknown(attributeTypes)),
+			},
+		},
+		"unknown-locality": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("xx-yyy/foo/bar")}),
+			},
+			expected: function.RunResponse{
+				Error:  function.NewArgumentFuncError(0, `unknown locality "xx-yyy", expected a zone or a region`),
+				Result: function.NewResultData(types.ObjectUnknown(attributeTypes)),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(attributeTypes)),
+			}
+
+			functions.NewParseNestedID().Run(context.Background(), testCase.request, &got)
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
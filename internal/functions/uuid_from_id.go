@@ -0,0 +1,58 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+)
+
+var _ function.Function = &UUIDFromID{}
+
+type UUIDFromID struct{}
+
+func NewUUIDFromID() function.Function {
+	return &UUIDFromID{}
+}
+
+func (f *UUIDFromID) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "uuid_from_id"
+}
+
+func (f *UUIDFromID) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Extract the UUID from the ID",
+		Description: "Given an ID string value, localized or not, returns the first UUID contained in the ID.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "id to extract the UUID from",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *UUIDFromID) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input types.String
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input))
+
+	if input.IsNull() || input.IsUnknown() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, input))
+
+		return
+	}
+
+	uuid, err := locality.ExtractUUID(input.ValueString())
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewStringUnknown()))
+
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, types.StringValue(uuid)))
+}
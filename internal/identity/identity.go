@@ -1,6 +1,7 @@
 package identity
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -167,3 +168,66 @@ func ParseMultiPartID(id string, keyOrder ...string) map[string]string {
 
 	return result
 }
+
+// ImportStatePassthrough returns an importer accepting either a resource ID or a resource identity.
+// When importing from an identity, the ID is built by joining the identity attributes in keyOrder with "/".
+// Empty optional attributes are skipped.
+func ImportStatePassthrough(keyOrder ...string) schema.StateContextFunc {
+	return func(_ context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+		if d.Id() != "" {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		identity, err := d.Identity()
+		if err != nil {
+			return nil, err
+		}
+
+		parts := make([]string, 0, len(keyOrder))
+
+		for _, key := range keyOrder {
+			value, ok := identity.GetOk(key)
+			if !ok {
+				continue
+			}
+
+			parts = append(parts, value.(string))
+		}
+
+		if len(parts) == 0 {
+			return nil, fmt.Errorf("cannot import resource: identity attributes %v are empty", keyOrder)
+		}
+
+		d.SetId(strings.Join(parts, "/"))
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// ImportStatePassthroughZonal imports resources using the DefaultZonal identity
+func ImportStatePassthroughZonal() schema.StateContextFunc {
+	return ImportStatePassthrough("zone", "id")
+}
+
+// ImportStatePassthroughRegional imports resources using the DefaultRegional identity
+func ImportStatePassthroughRegional() schema.StateContextFunc {
+	return ImportStatePassthrough("region", "id")
+}
+
+// SetIdentityAttributes sets identity attributes without changing the resource ID.
+// It should be used when the ID format does not match the identity attributes.
+func SetIdentityAttributes(d *schema.ResourceData, values map[string]string) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return err
+	}
+
+	for key, value := range values {
+		err = identity.Set(key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
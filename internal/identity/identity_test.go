@@ -3,8 +3,11 @@ package identity_test
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseMultiPartID(t *testing.T) {
@@ -90,3 +93,71 @@ func TestParseMultiPartID(t *testing.T) {
 		})
 	}
 }
+
+func TestImportStatePassthrough(t *testing.T) {
+	r := &schema.Resource{
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"name": {Type: schema.TypeString, Optional: true},
+			}
+		},
+		Identity: identity.WrapSchemaMap(map[string]*schema.Schema{
+			"region":      identity.DefaultRegionAttribute(),
+			"instance_id": {Type: schema.TypeString, RequiredForImport: true},
+			"name":        {Type: schema.TypeString, OptionalForImport: true},
+		}),
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		identity   map[string]string
+		expectedID string
+		expectErr  bool
+	}{
+		{
+			name:       "import by id",
+			id:         "fr-par/11111111-1111-1111-1111-111111111111/foo",
+			expectedID: "fr-par/11111111-1111-1111-1111-111111111111/foo",
+		},
+		{
+			name: "import by identity",
+			identity: map[string]string{
+				"region":      "fr-par",
+				"instance_id": "11111111-1111-1111-1111-111111111111",
+				"name":        "foo",
+			},
+			expectedID: "fr-par/11111111-1111-1111-1111-111111111111/foo",
+		},
+		{
+			name: "import by identity without optional attribute",
+			identity: map[string]string{
+				"region":      "fr-par",
+				"instance_id": "11111111-1111-1111-1111-111111111111",
+			},
+			expectedID: "fr-par/11111111-1111-1111-1111-111111111111",
+		},
+		{
+			name:      "empty identity",
+			identity:  map[string]string{},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := r.Data(&terraform.InstanceState{ID: tt.id, Identity: tt.identity})
+
+			res, err := identity.ImportStatePassthrough("region", "instance_id", "name")(t.Context(), d, nil)
+			if tt.expectErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, tt.expectedID, res[0].Id())
+		})
+	}
+}
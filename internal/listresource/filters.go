@@ -0,0 +1,171 @@
+package listresource
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+// ZonesAttribute returns the filter attribute used by zoned list resources
+func ZonesAttribute() schema.ListAttribute {
+	return schema.ListAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: "The zones to list resources from. Defaults to the provider zone",
+	}
+}
+
+// RegionsAttribute returns the filter attribute used by regional list resources
+func RegionsAttribute() schema.ListAttribute {
+	return schema.ListAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: "The regions to list resources from. Defaults to the provider region",
+	}
+}
+
+// ProjectIDAttribute returns the filter attribute on the project of the listed resources
+func ProjectIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "Only list resources of this project",
+		Validators: []validator.String{
+			verify.IsStringUUID(),
+		},
+	}
+}
+
+// NameAttribute returns the filter attribute on the name of the listed resources
+func NameAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "Only list resources whose name contains this value",
+	}
+}
+
+// TagsAttribute returns the filter attribute on the tags of the listed resources
+func TagsAttribute() schema.ListAttribute {
+	return schema.ListAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: "Only list resources with all these tags",
+	}
+}
+
+// CredentialsProfileAttribute returns the attribute selecting the profile of the Scaleway config file whose credentials list the resources
+func CredentialsProfileAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "The name of a profile of the Scaleway config file whose credentials are used instead of the provider ones",
+	}
+}
+
+// ExpandZones returns the zones to list from, falling back on the provider default zone
+func ExpandZones(m *meta.Meta, values []types.String) ([]scw.Zone, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if len(values) == 0 {
+		defaultZone, exists := m.ScwClient().GetDefaultZone()
+		if !exists {
+			diags.AddError("Missing zone", "The zones attribute is required to list resources. Please provide it explicitly or configure a default zone in the provider.")
+
+			return nil, diags
+		}
+
+		return []scw.Zone{defaultZone}, diags
+	}
+
+	zones := make([]scw.Zone, 0, len(values))
+
+	for _, value := range values {
+		zone, err := scw.ParseZone(value.ValueString())
+		if err != nil || !slices.Contains(zonal.AllZones(), zone.String()) {
+			diags.AddError("Invalid zone", fmt.Sprintf("%q is not a known zone", value.ValueString()))
+
+			continue
+		}
+
+		zones = append(zones, zone)
+	}
+
+	return zones, diags
+}
+
+// ExpandRegions returns the regions to list from, falling back on the provider default region
+func ExpandRegions(m *meta.Meta, values []types.String) ([]scw.Region, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if len(values) == 0 {
+		defaultRegion, exists := m.ScwClient().GetDefaultRegion()
+		if !exists {
+			diags.AddError("Missing region", "The regions attribute is required to list resources. Please provide it explicitly or configure a default region in the provider.")
+
+			return nil, diags
+		}
+
+		return []scw.Region{defaultRegion}, diags
+	}
+
+	regions := make([]scw.Region, 0, len(values))
+
+	for _, value := range values {
+		region, err := scw.ParseRegion(value.ValueString())
+		if err != nil || !slices.Contains(regional.AllRegions(), region.String()) {
+			diags.AddError("Invalid region", fmt.Sprintf("%q is not a known region", value.ValueString()))
+
+			continue
+		}
+
+		regions = append(regions, region)
+	}
+
+	return regions, diags
+}
+
+// ExpandStrings converts a list of framework strings, ignoring null and unknown values
+func ExpandStrings(values []types.String) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	res := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		res = append(res, value.ValueString())
+	}
+
+	return res
+}
+
+// ExpandStringPtr returns nil for a null, unknown or empty string
+func ExpandStringPtr(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return nil
+	}
+
+	return new(value.ValueString())
+}
+
+// HasTags checks that all the wanted tags are set on a resource
+func HasTags(tags []string, wanted []string) bool {
+	for _, tag := range wanted {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,225 @@
+package listresource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+)
+
+var (
+	_ list.ListResource                 = (*ListResource[scw.Zone, any])(nil)
+	_ list.ListResourceWithConfigure    = (*ListResource[scw.Zone, any])(nil)
+	_ list.ListResourceWithRawV6Schemas = (*ListResource[scw.Zone, any])(nil)
+)
+
+// Filters are the filters shared by every list resource
+type Filters struct {
+	ProjectID *string
+	Name      *string
+	Tags      []string
+}
+
+// Item is a listed resource
+type Item struct {
+	// DisplayName is shown to the user for the listed resource
+	DisplayName string
+	// SetIdentity must set the identity and the ID of the resource, usually through the helpers of the identity package
+	SetIdentity func(d *sdkschema.ResourceData) error
+}
+
+// Config describes a list resource of a SDKv2 resource
+type Config[L scw.Zone | scw.Region, T any] struct {
+	// TypeName is the name of the listed resource without the provider prefix, e.g. "instance_server"
+	TypeName    string
+	Description string
+	// Resource returns the listed SDKv2 resource, whose read function populates the listed resources when requested
+	Resource func() *sdkschema.Resource
+	// List returns the resources of one locality matching the filters
+	List func(ctx context.Context, client *scw.Client, locality L, filters Filters) ([]T, error)
+	// Flatten returns the display name and the identity of a listed resource
+	Flatten func(item T) Item
+}
+
+// ListResource lists the resources of a SDKv2 resource in the zones or regions given in its configuration
+type ListResource[L scw.Zone | scw.Region, T any] struct {
+	Config[L, T]
+
+	localitiesAttribute string
+	expandLocalities    func(m *meta.Meta, values []types.String) ([]L, diag.Diagnostics)
+	meta                *meta.Meta
+}
+
+// NewZonal returns a list resource listing zoned resources
+func NewZonal[T any](config Config[scw.Zone, T]) list.ListResource {
+	return &ListResource[scw.Zone, T]{
+		Config:              config,
+		localitiesAttribute: "zones",
+		expandLocalities:    ExpandZones,
+	}
+}
+
+// NewRegional returns a list resource listing regional resources
+func NewRegional[T any](config Config[scw.Region, T]) list.ListResource {
+	return &ListResource[scw.Region, T]{
+		Config:              config,
+		localitiesAttribute: "regions",
+		expandLocalities:    ExpandRegions,
+	}
+}
+
+func (r *ListResource[L, T]) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	m, ok := req.ProviderData.(*meta.Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *meta.Meta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.meta = m
+}
+
+func (r *ListResource[L, T]) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.TypeName
+}
+
+func (r *ListResource[L, T]) RawV6Schemas(ctx context.Context, _ list.RawV6SchemaRequest, resp *list.RawV6SchemaResponse) {
+	RawV6Schemas(ctx, r.Resource(), resp)
+}
+
+func (r *ListResource[L, T]) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	localities := ZonesAttribute()
+	if r.localitiesAttribute == "regions" {
+		localities = RegionsAttribute()
+	}
+
+	resp.Schema = schema.Schema{
+		Description:         r.Description,
+		MarkdownDescription: r.Description,
+		Attributes: map[string]schema.Attribute{
+			r.localitiesAttribute: localities,
+			"project_id":          ProjectIDAttribute(),
+			"name":                NameAttribute(),
+			"tags":                TagsAttribute(),
+			"credentials_profile": CredentialsProfileAttribute(),
+		},
+	}
+}
+
+func (r *ListResource[L, T]) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var (
+		localityValues []types.String
+		projectID      types.String
+		name           types.String
+		tags           []types.String
+		profile        types.String
+		diags          diag.Diagnostics
+	)
+
+	diags.Append(req.Config.GetAttribute(ctx, path.Root(r.localitiesAttribute), &localityValues)...)
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("project_id"), &projectID)...)
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("credentials_profile"), &profile)...)
+
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	if r.meta == nil {
+		diags.AddError(
+			"Unconfigured list resource",
+			"The list resource was not properly configured. The Scaleway client is missing. "+
+				"This is usually a bug in the provider. Please report it to the maintainers.",
+		)
+
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	m, _, err := r.meta.WithCredentialsProfile(profile.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("credentials_profile"), "Invalid credentials profile", err.Error())
+
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	localities, localitiesDiags := r.expandLocalities(m, localityValues)
+	if localitiesDiags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(localitiesDiags)
+
+		return
+	}
+
+	filters := Filters{
+		ProjectID: ExpandStringPtr(projectID),
+		Name:      ExpandStringPtr(name),
+		Tags:      ExpandStrings(tags),
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var (
+			count  int64
+			listed bool
+		)
+
+		for i, locality := range localities {
+			items, err := r.Config.List(ctx, m.ScwClient(), locality, filters)
+			if err != nil {
+				// A locality failing to list does not prevent listing the others,
+				// the failure is only an error when nothing could be listed at all
+				result := req.NewListResult(ctx)
+				summary := fmt.Sprintf("Error listing %s in %s", r.TypeName, locality)
+
+				if !listed && i == len(localities)-1 {
+					result.Diagnostics.AddError(summary, err.Error())
+				} else {
+					result.Diagnostics.AddWarning(summary, err.Error())
+				}
+
+				if !push(result) {
+					return
+				}
+
+				continue
+			}
+
+			listed = true
+
+			for _, item := range items {
+				if req.Limit > 0 && count >= req.Limit {
+					return
+				}
+
+				flattened := r.Flatten(item)
+
+				result := NewResult(ctx, req, m, r.Resource(), flattened.DisplayName, flattened.SetIdentity)
+				if !push(result) {
+					return
+				}
+
+				count++
+			}
+		}
+	}
+}
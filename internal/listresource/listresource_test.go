@@ -0,0 +1,199 @@
+package listresource_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/listresource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testResource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+			_ = d.Set("name", "listed")
+
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		Identity: identity.DefaultZonal(),
+	}
+}
+
+func testListRequest(includeResource bool) list.ListRequest {
+	return list.ListRequest{
+		IncludeResource: includeResource,
+		ResourceSchema: resourceschema.Schema{
+			Attributes: map[string]resourceschema.Attribute{
+				"id":   resourceschema.StringAttribute{Computed: true},
+				"name": resourceschema.StringAttribute{Optional: true},
+			},
+		},
+		ResourceIdentitySchema: identityschema.Schema{
+			Attributes: map[string]identityschema.Attribute{
+				"id":   identityschema.StringAttribute{RequiredForImport: true},
+				"zone": identityschema.StringAttribute{RequiredForImport: true},
+			},
+		},
+	}
+}
+
+func TestRawV6Schemas(t *testing.T) {
+	resp := &list.RawV6SchemaResponse{}
+	listresource.RawV6Schemas(t.Context(), testResource(), resp)
+
+	require.NotNil(t, resp.ProtoV6Schema)
+	require.NotNil(t, resp.ProtoV6IdentitySchema)
+
+	identityAttributes := make([]string, 0, len(resp.ProtoV6IdentitySchema.IdentityAttributes))
+	for _, attr := range resp.ProtoV6IdentitySchema.IdentityAttributes {
+		identityAttributes = append(identityAttributes, attr.Name)
+		assert.True(t, attr.RequiredForImport)
+	}
+
+	assert.ElementsMatch(t, []string{"id", "zone"}, identityAttributes)
+
+	resourceAttributes := make([]string, 0, len(resp.ProtoV6Schema.Block.Attributes))
+	for _, attr := range resp.ProtoV6Schema.Block.Attributes {
+		resourceAttributes = append(resourceAttributes, attr.Name)
+	}
+
+	assert.Contains(t, resourceAttributes, "name")
+}
+
+func TestNewResult(t *testing.T) {
+	setIdentity := func(d *schema.ResourceData) error {
+		return identity.SetZonalIdentity(d, scw.ZoneFrPar1, "11111111-1111-1111-1111-111111111111")
+	}
+
+	t.Run("identity only", func(t *testing.T) {
+		result := listresource.NewResult(t.Context(), testListRequest(false), nil, testResource(), "listed", setIdentity)
+		require.False(t, result.Diagnostics.HasError(), result.Diagnostics)
+
+		assert.Equal(t, "listed", result.DisplayName)
+		assert.Equal(t, tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"id":   tftypes.String,
+			"zone": tftypes.String,
+		}}, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, "11111111-1111-1111-1111-111111111111"),
+			"zone": tftypes.NewValue(tftypes.String, "fr-par-1"),
+		}), result.Identity.Raw)
+		assert.True(t, result.Resource.Raw.IsNull())
+	})
+
+	t.Run("with resource", func(t *testing.T) {
+		result := listresource.NewResult(t.Context(), testListRequest(true), nil, testResource(), "listed", setIdentity)
+		require.False(t, result.Diagnostics.HasError(), result.Diagnostics)
+
+		values := map[string]tftypes.Value{}
+		require.NoError(t, result.Resource.Raw.As(&values))
+
+		var id, name string
+		require.NoError(t, values["id"].As(&id))
+		require.NoError(t, values["name"].As(&name))
+
+		assert.Equal(t, "fr-par-1/11111111-1111-1111-1111-111111111111", id)
+		assert.Equal(t, "listed", name)
+	})
+}
+
+// listTestResource lists one resource per zone, except in the failing zones
+func listTestResource(t *testing.T, zones []string, failingZones ...scw.Zone) []list.ListResult {
+	t.Helper()
+
+	m, err := meta.NewMeta(t.Context(), &meta.Config{ForceZone: scw.ZoneFrPar1})
+	require.NoError(t, err)
+
+	listResource := listresource.NewZonal(listresource.Config[scw.Zone, scw.Zone]{
+		TypeName:    "test",
+		Description: "test",
+		Resource:    testResource,
+		List: func(_ context.Context, _ *scw.Client, zone scw.Zone, _ listresource.Filters) ([]scw.Zone, error) {
+			for _, failingZone := range failingZones {
+				if zone == failingZone {
+					return nil, errors.New("zone is unavailable")
+				}
+			}
+
+			return []scw.Zone{zone}, nil
+		},
+		Flatten: func(zone scw.Zone) listresource.Item {
+			return listresource.Item{
+				DisplayName: zone.String(),
+				SetIdentity: func(d *schema.ResourceData) error {
+					return identity.SetZonalIdentity(d, zone, "11111111-1111-1111-1111-111111111111")
+				},
+			}
+		},
+	})
+
+	configureResp := &resource.ConfigureResponse{}
+	listResource.(list.ListResourceWithConfigure).Configure(t.Context(), resource.ConfigureRequest{ProviderData: m}, configureResp)
+	require.False(t, configureResp.Diagnostics.HasError(), configureResp.Diagnostics)
+
+	schemaResp := &list.ListResourceSchemaResponse{}
+	listResource.ListResourceConfigSchema(t.Context(), list.ListResourceSchemaRequest{}, schemaResp)
+
+	zoneValues := make([]tftypes.Value, 0, len(zones))
+	for _, zone := range zones {
+		zoneValues = append(zoneValues, tftypes.NewValue(tftypes.String, zone))
+	}
+
+	req := testListRequest(false)
+	req.Config = tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(t.Context()), map[string]tftypes.Value{
+			"zones":               tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, zoneValues),
+			"project_id":          tftypes.NewValue(tftypes.String, nil),
+			"name":                tftypes.NewValue(tftypes.String, nil),
+			"tags":                tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+			"credentials_profile": tftypes.NewValue(tftypes.String, nil),
+		}),
+	}
+
+	stream := &list.ListResultsStream{}
+	listResource.List(t.Context(), req, stream)
+
+	results := []list.ListResult(nil)
+	for result := range stream.Results {
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func TestListResourceLocalityFailure(t *testing.T) {
+	// A failing zone is reported and the other zones are still listed
+	results := listTestResource(t, []string{"fr-par-1", "fr-par-2", "nl-ams-1"}, scw.ZoneFrPar2)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "fr-par-1", results[0].DisplayName)
+	assert.False(t, results[1].Diagnostics.HasError())
+	require.Len(t, results[1].Diagnostics, 1)
+	assert.Equal(t, "Error listing test in fr-par-2", results[1].Diagnostics[0].Summary())
+	assert.Equal(t, "nl-ams-1", results[2].DisplayName)
+
+	// Listing fails when no zone could be listed
+	results = listTestResource(t, []string{"fr-par-1", "fr-par-2"}, scw.ZoneFrPar1, scw.ZoneFrPar2)
+	require.Len(t, results, 2)
+	assert.False(t, results[0].Diagnostics.HasError())
+	assert.True(t, results[1].Diagnostics.HasError())
+}
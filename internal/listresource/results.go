@@ -0,0 +1,81 @@
+package listresource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	sdkdiag "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+)
+
+// NewResult builds a list result for a SDKv2 resource.
+// setIdentity must set the identity and the ID of the resource, usually through the helpers of the identity package.
+// When Terraform requests the full resource, its read function is called to populate the state.
+func NewResult(ctx context.Context, req list.ListRequest, m *meta.Meta, r *schema.Resource, displayName string, setIdentity func(d *schema.ResourceData) error) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = displayName
+
+	d := r.Data(&terraform.InstanceState{})
+
+	err := setIdentity(d)
+	if err != nil {
+		result.Diagnostics.AddError("Error setting resource identity", err.Error())
+
+		return result
+	}
+
+	if req.IncludeResource {
+		diags := readResource(ctx, r, d, m)
+		for _, diagnostic := range diags {
+			if diagnostic.Severity == sdkdiag.Error {
+				result.Diagnostics.AddError(diagnostic.Summary, diagnostic.Detail)
+			} else {
+				result.Diagnostics.AddWarning(diagnostic.Summary, diagnostic.Detail)
+			}
+		}
+
+		if diags.HasError() {
+			return result
+		}
+
+		if d.Id() == "" {
+			result.Diagnostics.AddError("Resource not found", fmt.Sprintf("%s disappeared while being listed", displayName))
+
+			return result
+		}
+
+		state, err := d.TfTypeResourceState()
+		if err != nil {
+			result.Diagnostics.AddError("Error converting resource state", err.Error())
+
+			return result
+		}
+
+		result.Resource.Raw = *state
+	}
+
+	identityState, err := d.TfTypeIdentityState()
+	if err != nil {
+		result.Diagnostics.AddError("Error converting resource identity", err.Error())
+
+		return result
+	}
+
+	result.Identity.Raw = *identityState
+
+	return result
+}
+
+func readResource(ctx context.Context, r *schema.Resource, d *schema.ResourceData, m *meta.Meta) sdkdiag.Diagnostics {
+	switch {
+	case r.ReadContext != nil:
+		return r.ReadContext(ctx, d, m)
+	case r.ReadWithoutTimeout != nil:
+		return r.ReadWithoutTimeout(ctx, d, m)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,95 @@
+package listresource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// RawV6Schemas fills the response with the ProtoV6 resource and identity schemas of a SDKv2 resource.
+// The framework needs them to build list results for resources it does not manage itself.
+func RawV6Schemas(ctx context.Context, r *schema.Resource, resp *list.RawV6SchemaResponse) {
+	resp.ProtoV6Schema = schemaToProtoV6(r.ProtoSchema(ctx)())
+
+	if identitySchema := r.ProtoIdentitySchema(ctx); identitySchema != nil {
+		resp.ProtoV6IdentitySchema = identitySchemaToProtoV6(identitySchema())
+	}
+}
+
+func schemaToProtoV6(s *tfprotov5.Schema) *tfprotov6.Schema {
+	if s == nil {
+		return nil
+	}
+
+	return &tfprotov6.Schema{
+		Version: s.Version,
+		Block:   blockToProtoV6(s.Block),
+	}
+}
+
+func blockToProtoV6(b *tfprotov5.SchemaBlock) *tfprotov6.SchemaBlock {
+	if b == nil {
+		return nil
+	}
+
+	attributes := make([]*tfprotov6.SchemaAttribute, 0, len(b.Attributes))
+	for _, attr := range b.Attributes {
+		attributes = append(attributes, &tfprotov6.SchemaAttribute{
+			Name:            attr.Name,
+			Type:            attr.Type,
+			Description:     attr.Description,
+			Required:        attr.Required,
+			Optional:        attr.Optional,
+			Computed:        attr.Computed,
+			Sensitive:       attr.Sensitive,
+			DescriptionKind: tfprotov6.StringKind(attr.DescriptionKind),
+			Deprecated:      attr.Deprecated,
+			WriteOnly:       attr.WriteOnly,
+		})
+	}
+
+	blockTypes := make([]*tfprotov6.SchemaNestedBlock, 0, len(b.BlockTypes))
+	for _, nested := range b.BlockTypes {
+		blockTypes = append(blockTypes, &tfprotov6.SchemaNestedBlock{
+			TypeName: nested.TypeName,
+			Block:    blockToProtoV6(nested.Block),
+			Nesting:  tfprotov6.SchemaNestedBlockNestingMode(nested.Nesting),
+			MinItems: nested.MinItems,
+			MaxItems: nested.MaxItems,
+		})
+	}
+
+	return &tfprotov6.SchemaBlock{
+		Version:         b.Version,
+		Attributes:      attributes,
+		BlockTypes:      blockTypes,
+		Description:     b.Description,
+		DescriptionKind: tfprotov6.StringKind(b.DescriptionKind),
+		Deprecated:      b.Deprecated,
+	}
+}
+
+func identitySchemaToProtoV6(s *tfprotov5.ResourceIdentitySchema) *tfprotov6.ResourceIdentitySchema {
+	if s == nil {
+		return nil
+	}
+
+	attributes := make([]*tfprotov6.ResourceIdentitySchemaAttribute, 0, len(s.IdentityAttributes))
+	for _, attr := range s.IdentityAttributes {
+		attributes = append(attributes, &tfprotov6.ResourceIdentitySchemaAttribute{
+			Name:              attr.Name,
+			Type:              attr.Type,
+			RequiredForImport: attr.RequiredForImport,
+			OptionalForImport: attr.OptionalForImport,
+			Description:       attr.Description,
+		})
+	}
+
+	return &tfprotov6.ResourceIdentitySchema{
+		Version:            s.Version,
+		IdentityAttributes: attributes,
+	}
+}
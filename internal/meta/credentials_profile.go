@@ -0,0 +1,87 @@
+package meta
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// credentialsProfileCache keeps the metas created for the credentials profiles so that each profile is loaded once
+type credentialsProfileCache struct {
+	mu    sync.Mutex
+	metas map[string]*Meta
+}
+
+func newCredentialsProfileCache() *credentialsProfileCache {
+	return &credentialsProfileCache{
+		metas: map[string]*Meta{},
+	}
+}
+
+// WithCredentialsProfile returns a copy of the meta using the credentials of the given profile of the Scaleway config file.
+// The settings missing from the profile, like its default zone, are taken from the provider.
+// The profile is loaded on first use only, loaded is true when it has just been loaded.
+func (m *Meta) WithCredentialsProfile(name string) (scoped *Meta, loaded bool, err error) {
+	if name == "" {
+		return m, false, nil
+	}
+
+	cache := m.credentialsProfiles
+	if cache == nil {
+		return nil, false, fmt.Errorf("cannot use credentials profile %q: the provider does not support credentials profiles", name)
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if scoped, exists := cache.metas[name]; exists {
+		return scoped, false, nil
+	}
+
+	config, err := scw.LoadConfig()
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot load credentials profile %q: %w", name, err)
+	}
+
+	profile, err := config.GetProfile(name)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot load credentials profile %q: %w", name, err)
+	}
+
+	mergedProfile := scw.MergeProfiles(m.profile, profile)
+
+	scwClient, err := scw.NewClient(
+		scw.WithUserAgent(m.userAgent),
+		scw.WithProfile(mergedProfile),
+		scw.WithHTTPClient(m.httpClient),
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot create a client for credentials profile %q: %w", name, err)
+	}
+
+	// The profile comes last as it overrides the provider credentials
+	credentialsSource := new(*m.credentialsSource)
+	credentialsSource.Variables = map[string][]string{}
+
+	for variable, sources := range m.credentialsSource.Variables {
+		credentialsSource.Variables[variable] = slices.Clone(sources)
+	}
+
+	credentialsSource.addProfile(profile, fmt.Sprintf("%s (%s)", CredentialsSourceResourceProfile, name))
+
+	scoped = &Meta{
+		scwClient:           scwClient,
+		httpClient:          m.httpClient,
+		credentialsSource:   credentialsSource,
+		defaultTags:         m.defaultTags,
+		ignoreTags:          m.ignoreTags,
+		profile:             mergedProfile,
+		userAgent:           m.userAgent,
+		credentialsProfiles: cache,
+	}
+	cache.metas[name] = scoped
+
+	return scoped, true, nil
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -24,6 +25,7 @@ const (
 	CredentialsSourceActiveProfile   = "Active Profile in config.yaml"
 	CredentialsSourceProviderProfile = "Profile defined in provider{} block"
 	CredentialsSourceInferred        = "CredentialsSourceInferred from default zone"
+	CredentialsSourceResourceProfile = "Profile defined in credentials_profile"
 )
 
 type CredentialsSource struct {
@@ -48,6 +50,16 @@ type Meta struct {
 	httpClient *http.Client
 	// credentialsSource stores information about the source (env, profile, etc.) of each credential
 	credentialsSource *CredentialsSource
+	// defaultTags are added to the tags of every taggable resource
+	defaultTags []string
+	// ignoreTags are the prefixes of the tags managed outside of Terraform
+	ignoreTags []string
+	// profile is the merged profile the scwClient has been created with
+	profile *scw.Profile
+	// userAgent is sent by the scwClient and the clients of the credentials profiles
+	userAgent string
+	// credentialsProfiles caches the metas of the profiles selected with the credentials_profile of resources
+	credentialsProfiles *credentialsProfileCache
 }
 
 // NewMeta creates the Meta object containing the SDK client.
@@ -92,13 +104,15 @@ func NewMeta(ctx context.Context, config *Config) (*Meta, error) {
 	// Create scaleway SDK client
 	////
 
-	httpClient := &http.Client{Transport: transport.NewRetryableTransport(http.DefaultTransport)}
+	httpClient := &http.Client{Transport: transport.NewRetryableTransportWithOptions(http.DefaultTransport, loadTransportOptions(config))}
 	if config.HTTPClient != nil {
 		httpClient = config.HTTPClient
 	}
 
+	userAgent := customizeUserAgent(version.Version, config.TerraformVersion)
+
 	opts := []scw.ClientOption{
-		scw.WithUserAgent(customizeUserAgent(version.Version, config.TerraformVersion)),
+		scw.WithUserAgent(userAgent),
 		scw.WithProfile(profile),
 		scw.WithHTTPClient(httpClient),
 	}
@@ -108,10 +122,17 @@ func NewMeta(ctx context.Context, config *Config) (*Meta, error) {
 		return nil, err
 	}
 
+	defaultTags, ignoreTags := loadTags(config)
+
 	return &Meta{
-		scwClient:         scwClient,
-		httpClient:        httpClient,
-		credentialsSource: credentialsSource,
+		scwClient:           scwClient,
+		httpClient:          httpClient,
+		credentialsSource:   credentialsSource,
+		defaultTags:         defaultTags,
+		ignoreTags:          ignoreTags,
+		profile:             profile,
+		userAgent:           userAgent,
+		credentialsProfiles: newCredentialsProfileCache(),
 	}, nil
 }
 
@@ -197,6 +218,13 @@ type Config struct {
 	ForceOrganizationID string
 	ForceAccessKey      string
 	ForceSecretKey      string
+	// DefaultTags and IgnoreTags are used when the provider schema is not available
+	DefaultTags []string
+	IgnoreTags  []string
+	// MaxRequestsPerSecond, MaxRetries and RetryWaitMax are used when the provider schema is not available
+	MaxRequestsPerSecond *float64
+	MaxRetries           *int
+	RetryWaitMax         *time.Duration
 }
 
 func customizeUserAgent(providerVersion string, terraformVersion string) string {
@@ -323,43 +351,45 @@ func GetCredentialsSource(defaultZoneProfile, activeProfile, providerProfile, en
 	credentialsSource.Variables = map[string][]string{}
 
 	for _, pair := range profilesInOrder {
-		source := pair.Source
-		profile := pair.Profile
+		credentialsSource.addProfile(pair.Profile, pair.Source)
+	}
 
-		if profile.AccessKey != nil {
-			credentialsSource.AccessKey = source
-			credentialsSource.Variables[scw.ScwAccessKeyEnv] = append(credentialsSource.Variables[scw.ScwAccessKeyEnv], source)
-		}
+	return credentialsSource
+}
 
-		if profile.SecretKey != nil {
-			credentialsSource.SecretKey = source
-			credentialsSource.Variables[scw.ScwSecretKeyEnv] = append(credentialsSource.Variables[scw.ScwSecretKeyEnv], source)
-		}
+// addProfile records the source of the variables set by a profile, it must be called in the priority order of the profiles
+func (cs *CredentialsSource) addProfile(profile *scw.Profile, source string) {
+	if profile.AccessKey != nil {
+		cs.AccessKey = source
+		cs.Variables[scw.ScwAccessKeyEnv] = append(cs.Variables[scw.ScwAccessKeyEnv], source)
+	}
 
-		if profile.DefaultProjectID != nil {
-			credentialsSource.ProjectID = source
-			credentialsSource.Variables[scw.ScwDefaultProjectIDEnv] = append(credentialsSource.Variables[scw.ScwDefaultProjectIDEnv], source)
-		}
+	if profile.SecretKey != nil {
+		cs.SecretKey = source
+		cs.Variables[scw.ScwSecretKeyEnv] = append(cs.Variables[scw.ScwSecretKeyEnv], source)
+	}
 
-		if profile.DefaultOrganizationID != nil {
-			credentialsSource.OrganizationID = source
-			credentialsSource.Variables[scw.ScwDefaultOrganizationIDEnv] = append(credentialsSource.Variables[scw.ScwDefaultOrganizationIDEnv], source)
-		}
+	if profile.DefaultProjectID != nil {
+		cs.ProjectID = source
+		cs.Variables[scw.ScwDefaultProjectIDEnv] = append(cs.Variables[scw.ScwDefaultProjectIDEnv], source)
+	}
 
-		if profile.DefaultRegion != nil {
-			credentialsSource.DefaultRegion = source
-			if source != CredentialsSourceDefault {
-				credentialsSource.Variables[scw.ScwDefaultRegionEnv] = append(credentialsSource.Variables[scw.ScwDefaultRegionEnv], source)
-			}
-		}
+	if profile.DefaultOrganizationID != nil {
+		cs.OrganizationID = source
+		cs.Variables[scw.ScwDefaultOrganizationIDEnv] = append(cs.Variables[scw.ScwDefaultOrganizationIDEnv], source)
+	}
 
-		if profile.DefaultZone != nil {
-			credentialsSource.DefaultZone = source
-			if source != CredentialsSourceDefault {
-				credentialsSource.Variables[scw.ScwDefaultZoneEnv] = append(credentialsSource.Variables[scw.ScwDefaultZoneEnv], source)
-			}
+	if profile.DefaultRegion != nil {
+		cs.DefaultRegion = source
+		if source != CredentialsSourceDefault {
+			cs.Variables[scw.ScwDefaultRegionEnv] = append(cs.Variables[scw.ScwDefaultRegionEnv], source)
 		}
 	}
 
-	return credentialsSource
+	if profile.DefaultZone != nil {
+		cs.DefaultZone = source
+		if source != CredentialsSourceDefault {
+			cs.Variables[scw.ScwDefaultZoneEnv] = append(cs.Variables[scw.ScwDefaultZoneEnv], source)
+		}
+	}
 }
@@ -1,10 +1,14 @@
 package meta_test
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"testing"
+	"time"
 
+	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -47,3 +51,91 @@ SCW_DEFAULT_PROJECT_ID	Active Profile in config.yaml, Environment variable	Envir
 `
 	assert.Equal(t, expectedMessage, message)
 }
+
+func TestWithCredentialsProfile(t *testing.T) {
+	scwConfigFile := `profiles:
+  landing-zone:
+    access_key: SCWYYYYYYYYYYYYYYYYY
+    secret_key: 11111111-1111-1111-1111-111111111111
+    default_project_id: 22222222-2222-2222-2222-222222222222
+`
+
+	dir := t.TempDir()
+
+	err := os.WriteFile(path.Join(dir, "config.yaml"), []byte(scwConfigFile), 0o644)
+	require.NoError(t, err)
+
+	t.Setenv("SCW_CONFIG_PATH", path.Join(dir, "config.yaml"))
+	t.Setenv("SCW_ACCESS_KEY", "SCWXXXXXXXXXXXXXXXXX")
+	t.Setenv("SCW_SECRET_KEY", "866F4A9A-D058-4D3C-A39F-86930849CCC0")
+	t.Setenv("SCW_DEFAULT_PROJECT_ID", "866F4A9A-D058-4D3C-A39F-86930849CCC0")
+	t.Setenv("SCW_DEFAULT_ZONE", "nl-ams-1")
+
+	m, err := meta.NewMeta(t.Context(), &meta.Config{})
+	require.NoError(t, err)
+
+	scoped, loaded, err := m.WithCredentialsProfile("")
+	require.NoError(t, err)
+	assert.False(t, loaded)
+	assert.Same(t, m, scoped)
+
+	scoped, loaded, err = m.WithCredentialsProfile("landing-zone")
+	require.NoError(t, err)
+	assert.True(t, loaded)
+
+	accessKey, _ := scoped.ScwClient().GetAccessKey()
+	assert.Equal(t, "SCWYYYYYYYYYYYYYYYYY", accessKey)
+
+	projectID, _ := scoped.ScwClient().GetDefaultProjectID()
+	assert.Equal(t, "22222222-2222-2222-2222-222222222222", projectID)
+
+	zone, _ := scoped.ScwClient().GetDefaultZone()
+	assert.Equal(t, scw.ZoneNlAms1, zone)
+
+	// The provider meta is left untouched
+	projectID, _ = m.ScwClient().GetDefaultProjectID()
+	assert.Equal(t, "866F4A9A-D058-4D3C-A39F-86930849CCC0", projectID)
+
+	multiple, message, err := scoped.HasMultipleVariableSources()
+	require.NoError(t, err)
+	assert.True(t, multiple)
+	assert.Contains(t, message, "Environment variable, Profile defined in credentials_profile (landing-zone)")
+
+	cached, loaded, err := m.WithCredentialsProfile("landing-zone")
+	require.NoError(t, err)
+	assert.False(t, loaded)
+	assert.Same(t, scoped, cached)
+
+	_, _, err = m.WithCredentialsProfile("unknown")
+	require.Error(t, err)
+}
+
+func TestTransportOptionsWithoutProviderSchema(t *testing.T) {
+	t.Setenv("SCW_ACCESS_KEY", "SCWXXXXXXXXXXXXXXXXX")
+	t.Setenv("SCW_SECRET_KEY", "866F4A9A-D058-4D3C-A39F-86930849CCC0")
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	for _, maxRetries := range []int{0, 2} {
+		requests = 0
+
+		m, err := meta.NewMeta(t.Context(), &meta.Config{
+			MaxRetries:   new(maxRetries),
+			RetryWaitMax: new(time.Millisecond),
+		})
+		require.NoError(t, err)
+
+		resp, err := m.HTTPClient().Get(server.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		assert.Equal(t, maxRetries+1, requests)
+	}
+}
@@ -0,0 +1,85 @@
+package meta
+
+import (
+	"slices"
+	"strings"
+)
+
+// loadTags returns the default and ignored tags from the provider block, falling back on the config
+func loadTags(config *Config) (defaultTags []string, ignoreTags []string) {
+	defaultTags = config.DefaultTags
+	ignoreTags = config.IgnoreTags
+
+	if config.ProviderSchema != nil {
+		if rawTags, exist := config.ProviderSchema.GetOk("default_tags"); exist {
+			defaultTags = expandTags(rawTags)
+		}
+
+		if rawTags, exist := config.ProviderSchema.GetOk("ignore_tags"); exist {
+			ignoreTags = expandTags(rawTags)
+		}
+	}
+
+	return defaultTags, ignoreTags
+}
+
+func expandTags(raw any) []string {
+	tags := []string(nil)
+
+	for _, tag := range raw.([]any) {
+		if tag == nil || tag.(string) == "" {
+			continue
+		}
+
+		tags = append(tags, tag.(string))
+	}
+
+	return tags
+}
+
+// DefaultTags returns the tags added by the provider to every taggable resource
+func (m Meta) DefaultTags() []string {
+	return m.defaultTags
+}
+
+// IgnoreTags returns the prefixes of the tags managed outside of Terraform
+func (m Meta) IgnoreTags() []string {
+	return m.ignoreTags
+}
+
+// IsTagIgnored returns true if the tag is managed outside of Terraform
+func (m Meta) IsTagIgnored(tag string) bool {
+	for _, prefix := range m.ignoreTags {
+		if strings.HasPrefix(tag, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MergeTags returns the tags that should be set on a resource.
+// They are the configured tags, followed by the provider default tags
+// and the ignored tags already set on the resource that are not configured yet.
+func (m Meta) MergeTags(configTags []string, currentTags []string) []string {
+	tags := make([]string, 0, len(configTags)+len(m.defaultTags))
+	tags = append(tags, configTags...)
+
+	appendTag := func(tag string) {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	for _, tag := range m.defaultTags {
+		appendTag(tag)
+	}
+
+	for _, tag := range currentTags {
+		if m.IsTagIgnored(tag) {
+			appendTag(tag)
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,58 @@
+package meta_test
+
+import (
+	"testing"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeTags(t *testing.T) {
+	t.Setenv("SCW_ACCESS_KEY", "SCWXXXXXXXXXXXXXXXXX")
+	t.Setenv("SCW_SECRET_KEY", "866F4A9A-D058-4D3C-A39F-86930849CCC0")
+	t.Setenv("SCW_DEFAULT_PROJECT_ID", "866F4A9A-D058-4D3C-A39F-86930849CCC0")
+
+	m, err := meta.NewMeta(t.Context(), &meta.Config{
+		DefaultTags: []string{"owner=platform", "cost-center=42"},
+		IgnoreTags:  []string{"k8s", "noprefix=manual"},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, m.IsTagIgnored("k8s-cluster=abc"))
+	assert.False(t, m.IsTagIgnored("owner=platform"))
+
+	tests := []struct {
+		name        string
+		configTags  []string
+		currentTags []string
+		expected    []string
+	}{
+		{
+			name:     "defaults only",
+			expected: []string{"owner=platform", "cost-center=42"},
+		},
+		{
+			name:       "config tags first",
+			configTags: []string{"env=prod"},
+			expected:   []string{"env=prod", "owner=platform", "cost-center=42"},
+		},
+		{
+			name:       "no duplicates",
+			configTags: []string{"cost-center=42", "env=prod"},
+			expected:   []string{"cost-center=42", "env=prod", "owner=platform"},
+		},
+		{
+			name:        "ignored tags are kept",
+			configTags:  []string{"env=prod"},
+			currentTags: []string{"env=dev", "k8s-cluster=abc", "noprefix=manual"},
+			expected:    []string{"env=prod", "owner=platform", "cost-center=42", "k8s-cluster=abc", "noprefix=manual"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, m.MergeTags(tt.configTags, tt.currentTags))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package meta
+
+import (
+	"time"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
+)
+
+// loadTransportOptions reads the throttling and retry settings of the provider block
+func loadTransportOptions(config *Config) transport.RetryableTransportOptions {
+	options := transport.RetryableTransportOptions{}
+
+	if config.ProviderSchema == nil {
+		options.MaxRequestsPerSecond = config.MaxRequestsPerSecond
+		options.RetryMax = config.MaxRetries
+		options.RetryWaitMax = config.RetryWaitMax
+
+		return options
+	}
+
+	if maxRequestsPerSecond, exist := config.ProviderSchema.GetOk("max_requests_per_second"); exist {
+		options.MaxRequestsPerSecond = new(maxRequestsPerSecond.(float64))
+	}
+
+	// max_retries may be set to 0 to disable retries
+	if maxRetries, exist := config.ProviderSchema.GetOkExists("max_retries"); exist { //nolint:staticcheck
+		options.RetryMax = new(maxRetries.(int))
+	}
+
+	if rawRetryWaitMax, exist := config.ProviderSchema.GetOk("retry_wait_max"); exist {
+		retryWaitMax, err := time.ParseDuration(rawRetryWaitMax.(string))
+		if err == nil {
+			options.RetryWaitMax = &retryWaitMax
+		}
+	}
+
+	return options
+}
@@ -2,6 +2,7 @@ package block
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -20,7 +21,9 @@ import (
 const (
 	defaultBlockTimeout       = 5 * time.Minute
 	defaultBlockRetryInterval = 5 * time.Second
-	BlockVolumeType           = instance.VolumeServerVolumeType("sbs_volume")
+	// defaultBlockSnapshotPolicyRunTimeout covers the snapshots of all the volumes of a policy and their copies
+	defaultBlockSnapshotPolicyRunTimeout = time.Hour
+	BlockVolumeType                      = instance.VolumeServerVolumeType("sbs_volume")
 )
 
 // blockAPIWithZone returns a new block API and the zone for a Create request
@@ -58,6 +61,42 @@ func customDiffCannotShrink(key string) schema.CustomizeDiffFunc {
 	})
 }
 
+// customDiffVolumeUpdateImpact shows in the plan the impact of a resize or an IOPS update of the volume.
+// Volumes of the bssd class are legacy b_ssd volumes, their IOPS cannot be changed.
+// Shrinking is left to customDiffCannotShrink which replaces the volume.
+func customDiffVolumeUpdateImpact(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	volumeType := instance.VolumeVolumeTypeSbsVolume
+	if diff.Get("storage_class").(string) == block.StorageClassBssd.String() {
+		volumeType = instance.VolumeVolumeTypeBSSD
+	}
+
+	oldSize, newSize := diff.GetChange("size_in_gb")
+	impact := instancehelpers.VolumeUpdateImpactNone
+
+	if diff.HasChanges("size_in_gb", "iops") {
+		impact = instancehelpers.ClassifyVolumeUpdate(instancehelpers.VolumeUpdate{
+			VolumeType: volumeType,
+			Size:       oldSize.(int),
+			NewSize:    newSize.(int),
+			IOPSChange: diff.HasChange("iops"),
+		})
+	}
+
+	if impact == instancehelpers.VolumeUpdateImpactDestructive {
+		if diff.HasChange("iops") && volumeType == instance.VolumeVolumeTypeBSSD {
+			return fmt.Errorf("the iops of %s volumes cannot be changed", block.StorageClassBssd)
+		}
+
+		return nil
+	}
+
+	return instancehelpers.SetPlannedUpdate(diff, "update_impact", impact.String(), impact == instancehelpers.VolumeUpdateImpactNone)
+}
+
 func customDiffSnapshot(key string) schema.CustomizeDiffFunc {
 	return func(ctx context.Context, diff *schema.ResourceDiff, i any) error {
 		if !diff.HasChange(key) {
@@ -9,6 +9,7 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
@@ -24,7 +25,7 @@ func ResourceSnapshot() *schema.Resource {
 		UpdateContext: ResourceBlockSnapshotUpdate,
 		DeleteContext: ResourceBlockSnapshotDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthroughZonal(),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create:  schema.DefaultTimeout(defaultBlockTimeout),
@@ -34,6 +35,7 @@ func ResourceSnapshot() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    snapshotSchema,
+		Identity:      identity.DefaultZonal(),
 	}
 }
 
@@ -153,7 +155,10 @@ func ResourceBlockSnapshotCreate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	d.SetId(zonal.NewIDString(zone, snapshot.ID))
+	err = identity.SetZonalIdentity(d, zone, snapshot.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	_, err = waitForBlockSnapshot(ctx, api, zone, snapshot.ID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
@@ -194,6 +199,11 @@ func ResourceBlockSnapshotRead(ctx context.Context, d *schema.ResourceData, m an
 		return diag.FromErr(err)
 	}
 
+	err = identity.SetZonalIdentity(d, zone, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_ = d.Set("name", snapshot.Name)
 	_ = d.Set("zone", snapshot.Zone)
 	_ = d.Set("project_id", snapshot.ProjectID)
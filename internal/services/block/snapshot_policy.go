@@ -0,0 +1,772 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/robfig/cron/v3"
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/object"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+const (
+	// snapshotPolicyTagPrefix tags the snapshots produced by a policy with the ID of the policy
+	snapshotPolicyTagPrefix = "snapshot-policy="
+	// snapshotPolicyVolumeTagPrefix tags the snapshots produced by a policy with the ID of their source volume,
+	// copies imported in another zone have no parent volume.
+	snapshotPolicyVolumeTagPrefix = "snapshot-policy-volume="
+	// The policy only exists in the state, its configuration is recorded in the tags of its snapshots to be imported
+	snapshotPolicyScheduleTagPrefix  = "snapshot-policy-schedule="
+	snapshotPolicyRetentionTagPrefix = "snapshot-policy-retention="
+	// snapshotPolicyCopyTagPrefix records the copy zone and bucket of the policy in the {zone}/{bucket} format
+	snapshotPolicyCopyTagPrefix = "snapshot-policy-copy="
+	// snapshotPolicyRunDateFormat is the format of the run date ending the names of the snapshots
+	snapshotPolicyRunDateFormat = "20060102-150405"
+)
+
+func ResourceSnapshotPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceBlockSnapshotPolicyCreate,
+		ReadContext:   ResourceBlockSnapshotPolicyRead,
+		UpdateContext: ResourceBlockSnapshotPolicyUpdate,
+		DeleteContext: ResourceBlockSnapshotPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBlockSnapshotPolicyImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultBlockTimeout),
+			Read:    schema.DefaultTimeout(defaultBlockTimeout),
+			Update:  schema.DefaultTimeout(defaultBlockSnapshotPolicyRunTimeout),
+			Delete:  schema.DefaultTimeout(defaultBlockTimeout),
+			Default: schema.DefaultTimeout(defaultBlockTimeout),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    snapshotPolicySchema,
+		Identity:      identity.DefaultZonal(),
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("volume_ids.#"),
+			customDiffSnapshotPolicyDue,
+		),
+	}
+}
+
+func snapshotPolicySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Optional:    true,
+			Description: "The name of the policy, used as a prefix of the snapshot names",
+		},
+		"volume_ids": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+			},
+			AtLeastOneOf: []string{"volume_ids", "volume_tags"},
+			Description:  "The IDs of the volumes to snapshot",
+		},
+		"volume_tags": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			AtLeastOneOf: []string{"volume_ids", "volume_tags"},
+			Description:  "Snapshot the volumes of the zone having at least one of these tags",
+		},
+		"schedule": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: verify.ValidateCronExpression(),
+			Description:      "The cron expression of the snapshot schedule, evaluated in UTC",
+		},
+		"retention": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "The number of snapshots kept for each volume, older snapshots are deleted",
+		},
+		"copy": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"zone": {
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: verify.ValidateStringInSliceWithWarning(zonal.AllZones(), "zone"),
+						Description:      "The zone to copy the snapshots to",
+					},
+					"bucket": {
+						Type:             schema.TypeString,
+						Required:         true,
+						Description:      "The Object Storage bucket the snapshots are exported to before being imported in the other zone",
+						DiffSuppressFunc: dsf.Locality,
+						StateFunc: func(i any) string {
+							return regional.ExpandID(i.(string)).ID
+						},
+					},
+				},
+			},
+			Description: "Copy the snapshots to another zone",
+		},
+		"tags": {
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional:    true,
+			Description: "The tags associated with the snapshots produced by the policy",
+		},
+		"snapshots": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The snapshots produced by the policy that are still retained, oldest first",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the snapshot",
+					},
+					"volume_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the volume the snapshot was taken from",
+					},
+					"zone": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The zone of the snapshot",
+					},
+					"is_copy": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether the snapshot is a copy in another zone",
+					},
+					"created_at": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The creation date of the snapshot",
+					},
+				},
+			},
+		},
+		"last_run_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date of the last run of the policy",
+		},
+		"next_run_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date from which the next run of the policy is due",
+		},
+		"zone":       zonal.Schema(),
+		"project_id": account.ProjectIDSchema(),
+	}
+}
+
+// snapshotPolicy is the configuration of a run of a scaleway_block_snapshot_policy
+type snapshotPolicy struct {
+	id         string
+	name       string
+	zone       scw.Zone
+	projectID  string
+	schedule   string
+	volumeIDs  []string
+	volumeTags []string
+	tags       []string
+	retention  int
+	copyZone   scw.Zone
+	copyBucket regional.ID
+}
+
+func expandSnapshotPolicy(d *schema.ResourceData, zone scw.Zone, id string) *snapshotPolicy {
+	policy := &snapshotPolicy{
+		id:         id,
+		name:       d.Get("name").(string),
+		zone:       zone,
+		projectID:  d.Get("project_id").(string),
+		schedule:   d.Get("schedule").(string),
+		volumeTags: types.ExpandStrings(d.Get("volume_tags")),
+		tags:       types.ExpandStrings(d.Get("tags")),
+		retention:  d.Get("retention").(int),
+	}
+
+	for _, volumeID := range d.Get("volume_ids").([]any) {
+		policy.volumeIDs = append(policy.volumeIDs, locality.ExpandID(volumeID))
+	}
+
+	if _, hasCopy := d.GetOk("copy"); hasCopy {
+		policy.copyZone = scw.Zone(d.Get("copy.0.zone").(string))
+		policy.copyBucket = regional.ExpandID(d.Get("copy.0.bucket"))
+	}
+
+	return policy
+}
+
+// snapshotPolicyTags returns the tags of a snapshot of a volume produced by the policy
+func snapshotPolicyTags(policy *snapshotPolicy, volumeID string) []string {
+	tags := []string{
+		snapshotPolicyTagPrefix + policy.id,
+		snapshotPolicyVolumeTagPrefix + volumeID,
+		snapshotPolicyScheduleTagPrefix + policy.schedule,
+		snapshotPolicyRetentionTagPrefix + strconv.Itoa(policy.retention),
+	}
+
+	if policy.copyZone != "" {
+		tags = append(tags, snapshotPolicyCopyTagPrefix+policy.copyZone.String()+"/"+policy.copyBucket.ID)
+	}
+
+	return append(tags, policy.tags...)
+}
+
+// snapshotPolicyTag returns the value of the policy tag having the given prefix
+func snapshotPolicyTag(tags []string, prefix string) (string, bool) {
+	for _, tag := range tags {
+		if value, found := strings.CutPrefix(tag, prefix); found {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// snapshotPolicyUserTags returns the tags of a snapshot that were set by the tags of the policy
+func snapshotPolicyUserTags(tags []string) []string {
+	userTags := []string{}
+
+	for _, tag := range tags {
+		switch {
+		case strings.HasPrefix(tag, snapshotPolicyTagPrefix),
+			strings.HasPrefix(tag, snapshotPolicyVolumeTagPrefix),
+			strings.HasPrefix(tag, snapshotPolicyScheduleTagPrefix),
+			strings.HasPrefix(tag, snapshotPolicyRetentionTagPrefix),
+			strings.HasPrefix(tag, snapshotPolicyCopyTagPrefix):
+			continue
+		}
+
+		userTags = append(userTags, tag)
+	}
+
+	return userTags
+}
+
+// snapshotPolicyCutRunDate splits the name of a snapshot produced by a policy into its prefix and the date of its run
+func snapshotPolicyCutRunDate(name string) (string, time.Time, bool) {
+	if len(name) <= len(snapshotPolicyRunDateFormat) {
+		return "", time.Time{}, false
+	}
+
+	prefix, date := name[:len(name)-len(snapshotPolicyRunDateFormat)], name[len(name)-len(snapshotPolicyRunDateFormat):]
+
+	runDate, err := time.Parse(snapshotPolicyRunDateFormat, date)
+	if err != nil || !strings.HasSuffix(prefix, "-") {
+		return "", time.Time{}, false
+	}
+
+	return strings.TrimSuffix(prefix, "-"), runDate, true
+}
+
+// snapshotPolicyNextRun returns the first scheduled run strictly after the given date
+func snapshotPolicyNextRun(expression string, after time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(expression)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return schedule.Next(after.UTC()).UTC(), nil
+}
+
+// snapshotPolicyIsDue returns whether the next run stored in the state has passed
+func snapshotPolicyIsDue(nextRunAt string, now time.Time) bool {
+	nextRun, err := time.Parse(time.RFC3339, nextRunAt)
+	if err != nil {
+		return false
+	}
+
+	return !now.Before(nextRun)
+}
+
+// snapshotPolicySourceVolumeID returns the ID of the volume a snapshot produced by a policy was taken from
+func snapshotPolicySourceVolumeID(snapshot *block.Snapshot) string {
+	if volumeID, found := snapshotPolicyTag(snapshot.Tags, snapshotPolicyVolumeTagPrefix); found {
+		return volumeID
+	}
+
+	if snapshot.ParentVolume != nil {
+		return snapshot.ParentVolume.ID
+	}
+
+	return ""
+}
+
+// snapshotPolicyExpiredSnapshots returns the snapshots exceeding the retention of their volume in their zone.
+// Snapshots that are being used or processed are kept, they will be deleted by a later run.
+func snapshotPolicyExpiredSnapshots(snapshots []*block.Snapshot, retention int) []*block.Snapshot {
+	groups := map[string][]*block.Snapshot{}
+
+	for _, snapshot := range snapshots {
+		key := snapshot.Zone.String() + "/" + snapshotPolicySourceVolumeID(snapshot)
+		groups[key] = append(groups[key], snapshot)
+	}
+
+	expired := []*block.Snapshot(nil)
+
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			return snapshotCreationDate(group[i]).After(snapshotCreationDate(group[j]))
+		})
+
+		for i := retention; i < len(group); i++ {
+			switch group[i].Status {
+			case block.SnapshotStatusAvailable, block.SnapshotStatusError:
+				expired = append(expired, group[i])
+			}
+		}
+	}
+
+	sort.Slice(expired, func(i, j int) bool {
+		return snapshotCreationDate(expired[i]).Before(snapshotCreationDate(expired[j]))
+	})
+
+	return expired
+}
+
+func snapshotCreationDate(snapshot *block.Snapshot) time.Time {
+	if snapshot.CreatedAt == nil {
+		return time.Time{}
+	}
+
+	return *snapshot.CreatedAt
+}
+
+func flattenSnapshotPolicySnapshots(policyZone scw.Zone, snapshots []*block.Snapshot) []map[string]any {
+	flattened := make([]map[string]any, 0, len(snapshots))
+
+	for _, snapshot := range snapshots {
+		flattened = append(flattened, map[string]any{
+			"id":         zonal.NewIDString(snapshot.Zone, snapshot.ID),
+			"volume_id":  zonal.NewIDString(policyZone, snapshotPolicySourceVolumeID(snapshot)),
+			"zone":       snapshot.Zone.String(),
+			"is_copy":    snapshot.Zone != policyZone,
+			"created_at": types.FlattenTime(snapshot.CreatedAt),
+		})
+	}
+
+	return flattened
+}
+
+// listSnapshotPolicySnapshots returns the snapshots produced by a policy in the given zones, oldest first
+func listSnapshotPolicySnapshots(ctx context.Context, api *block.API, policyID string, zones ...scw.Zone) ([]*block.Snapshot, error) {
+	snapshots := []*block.Snapshot(nil)
+
+	for _, zone := range zones {
+		if zone == "" {
+			continue
+		}
+
+		resp, err := api.ListSnapshots(&block.ListSnapshotsRequest{
+			Zone:    zone,
+			OrderBy: block.ListSnapshotsRequestOrderByCreatedAtAsc,
+			Tags:    []string{snapshotPolicyTagPrefix + policyID},
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, resp.Snapshots...)
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshotCreationDate(snapshots[i]).Before(snapshotCreationDate(snapshots[j]))
+	})
+
+	return snapshots, nil
+}
+
+// listSnapshotPolicyVolumes returns the volumes targeted by a policy, by ID or by tag
+func listSnapshotPolicyVolumes(ctx context.Context, api *block.API, policy *snapshotPolicy) ([]*block.Volume, error) {
+	volumes := []*block.Volume(nil)
+	seen := map[string]bool{}
+
+	for _, volumeID := range policy.volumeIDs {
+		volume, err := api.GetVolume(&block.GetVolumeRequest{
+			Zone:     policy.zone,
+			VolumeID: volumeID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get volume %s: %w", volumeID, err)
+		}
+
+		seen[volume.ID] = true
+		volumes = append(volumes, volume)
+	}
+
+	if len(policy.volumeTags) > 0 {
+		resp, err := api.ListVolumes(&block.ListVolumesRequest{
+			Zone:      policy.zone,
+			ProjectID: types.ExpandStringPtr(policy.projectID),
+			Tags:      policy.volumeTags,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, volume := range resp.Volumes {
+			if !seen[volume.ID] {
+				seen[volume.ID] = true
+				volumes = append(volumes, volume)
+			}
+		}
+	}
+
+	return volumes, nil
+}
+
+// runSnapshotPolicy snapshots the volumes of the policy, copies the snapshots to the other zone and applies the retention
+func runSnapshotPolicy(ctx context.Context, api *block.API, m any, policy *snapshotPolicy, now time.Time, timeout time.Duration) error {
+	volumes, err := listSnapshotPolicyVolumes(ctx, api, policy)
+	if err != nil {
+		return err
+	}
+
+	for _, volume := range volumes {
+		snapshot, err := api.CreateSnapshot(&block.CreateSnapshotRequest{
+			Zone:      policy.zone,
+			ProjectID: volume.ProjectID,
+			Name:      fmt.Sprintf("%s-%s-%s", policy.name, volume.Name, now.Format(snapshotPolicyRunDateFormat)),
+			VolumeID:  volume.ID,
+			Tags:      snapshotPolicyTags(policy, volume.ID),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to snapshot volume %s: %w", volume.ID, err)
+		}
+
+		snapshot, err = waitForBlockSnapshotToBeAvailable(ctx, api, policy.zone, snapshot.ID, timeout)
+		if err != nil {
+			return err
+		}
+
+		if policy.copyZone != "" {
+			err = copySnapshotPolicySnapshot(ctx, api, m, policy, snapshot, timeout)
+			if err != nil {
+				return fmt.Errorf("failed to copy snapshot %s to %s: %w", snapshot.ID, policy.copyZone, err)
+			}
+		}
+	}
+
+	return pruneSnapshotPolicySnapshots(ctx, api, policy, timeout)
+}
+
+// copySnapshotPolicySnapshot copies a snapshot to the copy zone of the policy by exporting it to the bucket
+// and importing it back. The exported object is removed once the copy is available or has failed.
+func copySnapshotPolicySnapshot(ctx context.Context, api *block.API, m any, policy *snapshotPolicy, snapshot *block.Snapshot, timeout time.Duration) (err error) {
+	key := fmt.Sprintf("%s/%s.qcow2", policy.id, snapshot.ID)
+
+	_, err = api.ExportSnapshotToObjectStorage(&block.ExportSnapshotToObjectStorageRequest{
+		Zone:       snapshot.Zone,
+		SnapshotID: snapshot.ID,
+		Bucket:     policy.copyBucket.ID,
+		Key:        key,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		deleteErr := deleteSnapshotPolicyExport(ctx, m, policy, snapshot.Zone, key)
+		if deleteErr != nil {
+			err = errors.Join(err, fmt.Errorf("exported object %s must be deleted manually from bucket %s: %w", key, policy.copyBucket.ID, deleteErr))
+		}
+	}()
+
+	_, err = waitForBlockSnapshotToBeAvailable(ctx, api, snapshot.Zone, snapshot.ID, timeout)
+	if err != nil {
+		return err
+	}
+
+	copied, err := api.ImportSnapshotFromObjectStorage(&block.ImportSnapshotFromObjectStorageRequest{
+		Zone:      policy.copyZone,
+		Bucket:    policy.copyBucket.ID,
+		Key:       key,
+		Name:      snapshot.Name,
+		ProjectID: snapshot.ProjectID,
+		Tags:      snapshot.Tags,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	_, err = waitForBlockSnapshotToBeAvailable(ctx, api, policy.copyZone, copied.ID, timeout)
+
+	return err
+}
+
+// deleteSnapshotPolicyExport deletes the object a snapshot was exported to, the bucket defaults to the region of the snapshot
+func deleteSnapshotPolicyExport(ctx context.Context, m any, policy *snapshotPolicy, snapshotZone scw.Zone, key string) error {
+	region := policy.copyBucket.Region
+	if region == "" {
+		var err error
+
+		region, err = snapshotZone.Region()
+		if err != nil {
+			return err
+		}
+	}
+
+	s3Client, err := object.NewS3ClientFromMeta(ctx, m.(*meta.Meta), region.String())
+	if err != nil {
+		return err
+	}
+
+	_, err = s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: new(policy.copyBucket.ID),
+		Key:    new(key),
+	})
+
+	return err
+}
+
+func pruneSnapshotPolicySnapshots(ctx context.Context, api *block.API, policy *snapshotPolicy, timeout time.Duration) error {
+	snapshots, err := listSnapshotPolicySnapshots(ctx, api, policy.id, policy.zone, policy.copyZone)
+	if err != nil {
+		return err
+	}
+
+	for _, snapshot := range snapshotPolicyExpiredSnapshots(snapshots, policy.retention) {
+		err = api.DeleteSnapshot(&block.DeleteSnapshotRequest{
+			Zone:       snapshot.Zone,
+			SnapshotID: snapshot.ID,
+		}, scw.WithContext(ctx))
+		if err != nil && !httperrors.Is404(err) {
+			return fmt.Errorf("failed to delete expired snapshot %s: %w", snapshot.ID, err)
+		}
+
+		_, err = waitForBlockSnapshot(ctx, api, snapshot.Zone, snapshot.ID, timeout)
+		if err != nil && !httperrors.Is404(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// customDiffSnapshotPolicyDue plans a run of the policy when its next run date has passed.
+// Terraform does not run in the background, the schedule is evaluated each time a plan is made.
+func customDiffSnapshotPolicyDue(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	if snapshotPolicyIsDue(diff.Get("next_run_at").(string), time.Now()) {
+		for _, key := range []string{"snapshots", "last_run_at", "next_run_at"} {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if diff.HasChange("schedule") {
+		return diff.SetNewComputed("next_run_at")
+	}
+
+	return nil
+}
+
+func ResourceBlockSnapshotPolicyCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	_, zone, err := blockAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nextRun, err := snapshotPolicyNextRun(d.Get("schedule").(string), time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("name", types.ExpandOrGenerateString(d.Get("name"), "snapshot-policy"))
+	_ = d.Set("last_run_at", "")
+	_ = d.Set("next_run_at", nextRun.Format(time.RFC3339))
+
+	err = identity.SetZonalIdentity(d, zone, uuid.New().String())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceBlockSnapshotPolicyRead(ctx, d, m)
+}
+
+func ResourceBlockSnapshotPolicyRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	api, zone, id, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	snapshots, err := listSnapshotPolicySnapshots(ctx, api, id, zone, scw.Zone(d.Get("copy.0.zone").(string)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = identity.SetZonalIdentity(d, zone, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("zone", zone)
+	_ = d.Set("snapshots", flattenSnapshotPolicySnapshots(zone, snapshots))
+
+	return nil
+}
+
+// resourceBlockSnapshotPolicyImport rebuilds a policy from the tags of the snapshots it produced in its zone,
+// a policy that has no snapshot left cannot be imported.
+func resourceBlockSnapshotPolicyImport(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	api, zone, id, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := listSnapshotPolicySnapshots(ctx, api, id, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("no snapshot produced by snapshot policy %s found in zone %s", id, zone)
+	}
+
+	// The snapshots of the last run hold the latest configuration of the policy
+	last := snapshots[len(snapshots)-1]
+
+	schedule, hasSchedule := snapshotPolicyTag(last.Tags, snapshotPolicyScheduleTagPrefix)
+	rawRetention, hasRetention := snapshotPolicyTag(last.Tags, snapshotPolicyRetentionTagPrefix)
+
+	if !hasSchedule || !hasRetention {
+		return nil, fmt.Errorf("the snapshots of snapshot policy %s do not record its schedule and retention, it cannot be imported", id)
+	}
+
+	retention, err := strconv.Atoi(rawRetention)
+	if err != nil {
+		return nil, fmt.Errorf("invalid retention tag on snapshot %s: %w", last.ID, err)
+	}
+
+	lastRun := snapshotCreationDate(last)
+
+	// The snapshots are named {policy name}-{volume name}-{run date}
+	name, runDate, hasRunDate := snapshotPolicyCutRunDate(last.Name)
+	if hasRunDate {
+		lastRun = runDate
+	}
+
+	nextRun, err := snapshotPolicyNextRun(schedule, lastRun)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeIDs := []string(nil)
+	seen := map[string]bool{}
+
+	for _, snapshot := range snapshots {
+		volumeID := snapshotPolicySourceVolumeID(snapshot)
+		if volumeID != "" && !seen[volumeID] {
+			seen[volumeID] = true
+			volumeIDs = append(volumeIDs, zonal.NewIDString(zone, volumeID))
+		}
+	}
+
+	if copyTag, hasCopy := snapshotPolicyTag(last.Tags, snapshotPolicyCopyTagPrefix); hasCopy {
+		copyZone, bucket, _ := strings.Cut(copyTag, "/")
+		_ = d.Set("copy", []map[string]any{{"zone": copyZone, "bucket": bucket}})
+	}
+
+	if hasRunDate && last.ParentVolume != nil {
+		_ = d.Set("name", strings.TrimSuffix(name, "-"+last.ParentVolume.Name))
+	}
+
+	_ = d.Set("schedule", schedule)
+	_ = d.Set("retention", retention)
+	_ = d.Set("volume_ids", volumeIDs)
+	_ = d.Set("tags", snapshotPolicyUserTags(last.Tags))
+	_ = d.Set("project_id", last.ProjectID)
+	_ = d.Set("last_run_at", lastRun.UTC().Format(time.RFC3339))
+	_ = d.Set("next_run_at", nextRun.Format(time.RFC3339))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func ResourceBlockSnapshotPolicyUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	api, zone, id, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policy := expandSnapshotPolicy(d, zone, id)
+	now := time.Now().UTC()
+	previousNextRun, _ := d.GetChange("next_run_at")
+
+	switch {
+	case snapshotPolicyIsDue(previousNextRun.(string), now):
+		err = runSnapshotPolicy(ctx, api, m, policy, now, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_ = d.Set("last_run_at", now.Format(time.RFC3339))
+	case d.HasChange("retention"):
+		err = pruneSnapshotPolicySnapshots(ctx, api, policy, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("schedule") || snapshotPolicyIsDue(previousNextRun.(string), now) {
+		nextRun, err := snapshotPolicyNextRun(d.Get("schedule").(string), now)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_ = d.Set("next_run_at", nextRun.Format(time.RFC3339))
+	} else {
+		_ = d.Set("next_run_at", previousNextRun)
+	}
+
+	return ResourceBlockSnapshotPolicyRead(ctx, d, m)
+}
+
+// ResourceBlockSnapshotPolicyDelete only removes the policy from the state, the snapshots it produced are kept
+// so that destroying the policy does not destroy the backups.
+func ResourceBlockSnapshotPolicyDelete(_ context.Context, _ *schema.ResourceData, _ any) diag.Diagnostics {
+	return nil
+}
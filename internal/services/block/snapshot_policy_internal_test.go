@@ -0,0 +1,88 @@
+package block
+
+import (
+	"testing"
+	"time"
+
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotPolicyNextRun(t *testing.T) {
+	after := time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC)
+
+	nextRun, err := snapshotPolicyNextRun("0 3 * * *", after)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 11, 3, 0, 0, 0, time.UTC), nextRun)
+
+	nextRun, err = snapshotPolicyNextRun("*/15 * * * *", after)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 10, 14, 45, 0, 0, time.UTC), nextRun)
+
+	_, err = snapshotPolicyNextRun("not a cron", after)
+	require.Error(t, err)
+}
+
+func TestSnapshotPolicyIsDue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC)
+
+	assert.True(t, snapshotPolicyIsDue("2026-03-10T14:30:00Z", now))
+	assert.True(t, snapshotPolicyIsDue("2026-03-09T03:00:00Z", now))
+	assert.False(t, snapshotPolicyIsDue("2026-03-11T03:00:00Z", now))
+	assert.False(t, snapshotPolicyIsDue("", now))
+}
+
+func TestSnapshotPolicyExpiredSnapshots(t *testing.T) {
+	day := func(d int) *time.Time {
+		return new(time.Date(2026, 3, d, 3, 0, 0, 0, time.UTC))
+	}
+	snapshot := func(id string, zone scw.Zone, volumeID string, createdAt *time.Time, status block.SnapshotStatus) *block.Snapshot {
+		return &block.Snapshot{
+			ID:        id,
+			Zone:      zone,
+			CreatedAt: createdAt,
+			Status:    status,
+			Tags:      []string{snapshotPolicyTagPrefix + "policy", snapshotPolicyVolumeTagPrefix + volumeID},
+		}
+	}
+
+	snapshots := []*block.Snapshot{
+		snapshot("a1", scw.ZoneFrPar1, "a", day(1), block.SnapshotStatusAvailable),
+		snapshot("a2", scw.ZoneFrPar1, "a", day(2), block.SnapshotStatusInUse),
+		snapshot("a3", scw.ZoneFrPar1, "a", day(3), block.SnapshotStatusAvailable),
+		snapshot("a4", scw.ZoneFrPar1, "a", day(4), block.SnapshotStatusAvailable),
+		snapshot("b1", scw.ZoneFrPar1, "b", day(1), block.SnapshotStatusAvailable),
+		snapshot("a1-copy", scw.ZoneFrPar2, "a", day(1), block.SnapshotStatusAvailable),
+		snapshot("a4-copy", scw.ZoneFrPar2, "a", day(4), block.SnapshotStatusAvailable),
+	}
+
+	expired := snapshotPolicyExpiredSnapshots(snapshots, 1)
+
+	expiredIDs := []string(nil)
+	for _, s := range expired {
+		expiredIDs = append(expiredIDs, s.ID)
+	}
+
+	// a2 is in use and cannot be deleted yet
+	assert.ElementsMatch(t, []string{"a1", "a3", "a1-copy"}, expiredIDs)
+	assert.Empty(t, snapshotPolicyExpiredSnapshots(snapshots, 4))
+}
+
+func TestSnapshotPolicySchema(t *testing.T) {
+	require.NoError(t, ResourceSnapshotPolicy().InternalValidate(nil, true))
+}
+
+func TestSnapshotPolicyCutRunDate(t *testing.T) {
+	prefix, runDate, ok := snapshotPolicyCutRunDate("nightly-data-20260310-030000")
+	require.True(t, ok)
+	assert.Equal(t, "nightly-data", prefix)
+	assert.Equal(t, time.Date(2026, 3, 10, 3, 0, 0, 0, time.UTC), runDate)
+
+	_, _, ok = snapshotPolicyCutRunDate("nightly-data")
+	assert.False(t, ok)
+
+	_, _, ok = snapshotPolicyCutRunDate("20260310-030000")
+	assert.False(t, ok)
+}
@@ -0,0 +1,112 @@
+package block_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	blockSDK "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// applySnapshotPolicy plans and applies the policy from prior to the configuration made of attributes
+func applySnapshotPolicy(t *testing.T, m *meta.Meta, prior *terraform.InstanceState, attributes map[string]any) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+
+	r := block.ResourceSnapshotPolicy()
+	ctx := t.Context()
+
+	// Terraform sends the blocks missing from the configuration as empty lists
+	for name, attribute := range r.SchemaMap() {
+		if _, isBlock := attribute.Elem.(*schema.Resource); isBlock && !attribute.Computed && attributes[name] == nil {
+			attributes[name] = []any{}
+		}
+	}
+
+	raw, err := json.Marshal(attributes)
+	require.NoError(t, err)
+
+	config, err := ctyjson.Unmarshal(raw, r.CoreConfigSchema().ImpliedType())
+	require.NoError(t, err)
+
+	if prior == nil {
+		prior = &terraform.InstanceState{RawState: cty.NullVal(r.CoreConfigSchema().ImpliedType())}
+	} else {
+		prior.RawState, err = prior.AttrsAsObjectValue(r.CoreConfigSchema().ImpliedType())
+		require.NoError(t, err)
+	}
+
+	diff, err := r.Diff(ctx, prior, terraform.NewResourceConfigShimmed(config, r.CoreConfigSchema()), m)
+	require.NoError(t, err)
+
+	diff.RawConfig = config
+
+	return r.Apply(ctx, prior, diff, m)
+}
+
+func TestSnapshotPolicyImport(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+	r := block.ResourceSnapshotPolicy()
+
+	volume, err := blockSDK.NewAPI(m.ScwClient()).CreateVolume(&blockSDK.CreateVolumeRequest{
+		Zone:      scw.ZoneFrPar1,
+		Name:      "data",
+		FromEmpty: &blockSDK.CreateVolumeRequestFromEmpty{Size: 20 * scw.GB},
+	})
+	require.NoError(t, err)
+
+	config := map[string]any{
+		"name":       "nightly",
+		"volume_ids": []any{zonal.NewIDString(scw.ZoneFrPar1, volume.ID)},
+		"schedule":   "0 3 * * *",
+		"retention":  2,
+		"tags":       []any{"backup"},
+	}
+
+	created, diags := applySnapshotPolicy(t, m, nil, config)
+	require.False(t, diags.HasError(), diags)
+
+	// A policy that never ran has no snapshot to be rebuilt from
+	d := r.Data(nil)
+	d.SetId(created.ID)
+	_, err = r.Importer.StateContext(t.Context(), d, m)
+	require.ErrorContains(t, err, "no snapshot produced by snapshot policy")
+
+	// The next run is due, the update takes the snapshots
+	created.Attributes["next_run_at"] = "2026-01-01T03:00:00Z"
+	ran, diags := applySnapshotPolicy(t, m, created, config)
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, "1", ran.Attributes["snapshots.#"])
+
+	d = r.Data(nil)
+	d.SetId(created.ID)
+	imported, err := r.Importer.StateContext(t.Context(), d, m)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+
+	assert.Equal(t, "nightly", imported[0].Get("name"))
+	assert.Equal(t, "0 3 * * *", imported[0].Get("schedule"))
+	assert.Equal(t, 2, imported[0].Get("retention"))
+	assert.Equal(t, []any{zonal.NewIDString(scw.ZoneFrPar1, volume.ID)}, imported[0].Get("volume_ids"))
+	assert.Equal(t, []any{"backup"}, imported[0].Get("tags"))
+	assert.Empty(t, imported[0].Get("copy"))
+	assert.Equal(t, ran.Attributes["last_run_at"], imported[0].Get("last_run_at"))
+	assert.Equal(t, ran.Attributes["next_run_at"], imported[0].Get("next_run_at"))
+
+	// An ID that is not the one of a policy is rejected
+	d = r.Data(nil)
+	d.SetId(zonal.NewIDString(scw.ZoneFrPar1, uuid.New().String()))
+	_, err = r.Importer.StateContext(t.Context(), d, m)
+	require.Error(t, err)
+}
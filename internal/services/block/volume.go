@@ -10,6 +10,7 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
@@ -34,9 +35,11 @@ func ResourceVolume() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    volumeSchema,
+		Identity:      identity.DefaultZonal(),
 		CustomizeDiff: customdiff.All(
 			customDiffSnapshot("snapshot_id"),
 			customDiffCannotShrink("size_in_gb"),
+			customDiffVolumeUpdateImpact,
 		),
 	}
 }
@@ -82,6 +85,16 @@ func volumeSchema() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "The tags associated with the volume",
 		},
+		"storage_class": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The storage class of the volume (sbs or bssd)",
+		},
+		"update_impact": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The impact of the planned update of the volume on the server it is attached to (online), cleared once the update is applied",
+		},
 		"zone":       zonal.Schema(),
 		"project_id": account.ProjectIDSchema(),
 	}
@@ -138,7 +151,10 @@ func ResourceBlockVolumeCreate(ctx context.Context, d *schema.ResourceData, m an
 		}
 	}
 
-	d.SetId(zonal.NewIDString(zone, volume.ID))
+	err = identity.SetZonalIdentity(d, zone, volume.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	_, err = waitForBlockVolume(ctx, api.BlockAPI, zone, volume.ID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
@@ -165,10 +181,18 @@ func ResourceBlockVolumeRead(ctx context.Context, d *schema.ResourceData, m any)
 		return diag.FromErr(err)
 	}
 
+	err = identity.SetZonalIdentity(d, volume.Zone, volume.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_ = d.Set("name", volume.Name)
+	// The update impact only describes the plan, it is not kept once the update is applied
+	_ = d.Set("update_impact", "")
 
 	if volume.Specs != nil {
 		_ = d.Set("iops", types.FlattenUint32Ptr(volume.Specs.PerfIops))
+		_ = d.Set("storage_class", volume.Specs.Class.String())
 	}
 
 	_ = d.Set("size_in_gb", int(volume.Size/scw.GB))
@@ -14,6 +14,7 @@ import (
 func DataSourceVolume() *schema.Resource {
 	// Generate datasource schema from resource
 	dsSchema := datasource.SchemaFromResourceSchema(ResourceVolume().SchemaFunc())
+	delete(dsSchema, "update_impact")
 
 	datasource.AddOptionalFieldsToSchema(dsSchema, "name", "zone", "project_id")
 
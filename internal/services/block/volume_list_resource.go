@@ -0,0 +1,45 @@
+package block
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/listresource"
+)
+
+//go:embed descriptions/volume_list_resource.md
+var volumeListResourceDescription string
+
+func NewVolumeListResource() list.ListResource {
+	return listresource.NewZonal(listresource.Config[scw.Zone, *block.Volume]{
+		TypeName:    "block_volume",
+		Description: volumeListResourceDescription,
+		Resource:    ResourceVolume,
+		List: func(ctx context.Context, client *scw.Client, zone scw.Zone, filters listresource.Filters) ([]*block.Volume, error) {
+			res, err := block.NewAPI(client).ListVolumes(&block.ListVolumesRequest{
+				Zone:      zone,
+				ProjectID: filters.ProjectID,
+				Name:      filters.Name,
+				Tags:      filters.Tags,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			return res.Volumes, nil
+		},
+		Flatten: func(volume *block.Volume) listresource.Item {
+			return listresource.Item{
+				DisplayName: volume.Name,
+				SetIdentity: func(d *sdkschema.ResourceData) error {
+					return identity.SetZonalIdentity(d, volume.Zone, volume.ID)
+				},
+			}
+		},
+	})
+}
@@ -11,19 +11,29 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 )
 
+func databaseIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":        identity.DefaultRegionAttribute(),
+		"deployment_id": {Type: schema.TypeString, Description: "The ID of the deployment (UUID format)", RequiredForImport: true},
+		"name":          {Type: schema.TypeString, Description: "The name of the database", RequiredForImport: true},
+	})
+}
+
 func ResourceDatabase() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceDatabaseCreate,
 		ReadContext:   resourceDatabaseRead,
 		DeleteContext: resourceDatabaseDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "deployment_id", "name"),
 		},
 		SchemaFunc: databaseSchema,
+		Identity:   databaseIdentity(),
 	}
 }
 
@@ -71,7 +81,14 @@ func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta an
 		return diag.FromErr(err)
 	}
 
-	d.SetId(ResourceDatabaseID(region, deploymentID, name))
+	err = identity.SetMultiPartIdentity(d, map[string]string{
+		"region":        region.String(),
+		"deployment_id": deploymentID,
+		"name":          name,
+	}, "region", "deployment_id", "name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceDatabaseRead(ctx, d, meta)
 }
@@ -119,7 +136,11 @@ func resourceDatabaseRead(ctx context.Context, d *schema.ResourceData, meta any)
 	_ = d.Set("name", found.Name)
 	_ = d.Set("size", int(found.Size))
 
-	return nil
+	return diag.FromErr(identity.SetMultiPartIdentity(d, map[string]string{
+		"region":        region.String(),
+		"deployment_id": deploymentID,
+		"name":          found.Name,
+	}, "region", "deployment_id", "name"))
 }
 
 func resourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
@@ -12,6 +12,7 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
@@ -26,7 +27,7 @@ func ResourceDeployment() *schema.Resource {
 		UpdateContext: resourceDeploymentUpdate,
 		DeleteContext: resourceDeploymentDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthroughRegional(),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
@@ -47,6 +48,7 @@ func ResourceDeployment() *schema.Resource {
 			},
 		),
 		SchemaFunc: deploymentSchema,
+		Identity:   identity.DefaultRegional(),
 	}
 }
 
@@ -257,7 +259,10 @@ func resourceDeploymentCreate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
-	d.SetId(regional.NewIDString(region, deployment.ID))
+	err = identity.SetRegionalIdentity(d, region, deployment.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceDeploymentRead(ctx, d, meta)
 }
@@ -287,6 +292,11 @@ func resourceDeploymentRead(ctx context.Context, d *schema.ResourceData, meta an
 		return diag.FromErr(err)
 	}
 
+	err = identity.SetRegionalIdentity(d, region, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_ = d.Set("region", string(deployment.Region))
 	_ = d.Set("project_id", deployment.ProjectID)
 	_ = d.Set("name", deployment.Name)
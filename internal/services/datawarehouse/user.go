@@ -11,10 +11,19 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 )
 
+func userIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":        identity.DefaultRegionAttribute(),
+		"deployment_id": {Type: schema.TypeString, Description: "The ID of the deployment (UUID format)", RequiredForImport: true},
+		"name":          {Type: schema.TypeString, Description: "The name of the user", RequiredForImport: true},
+	})
+}
+
 func ResourceUser() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceUserCreate,
@@ -22,9 +31,10 @@ func ResourceUser() *schema.Resource {
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "deployment_id", "name"),
 		},
 		SchemaFunc: userSchema,
+		Identity:   userIdentity(),
 	}
 }
 
@@ -83,7 +93,14 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta any) d
 		return diag.FromErr(err)
 	}
 
-	d.SetId(ResourceUserID(region, deploymentID, name))
+	err = identity.SetMultiPartIdentity(d, map[string]string{
+		"region":        region.String(),
+		"deployment_id": deploymentID,
+		"name":          name,
+	}, "region", "deployment_id", "name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceUserRead(ctx, d, meta)
 }
@@ -131,7 +148,11 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta any) dia
 	_ = d.Set("name", found.Name)
 	_ = d.Set("is_admin", found.IsAdmin)
 
-	return nil
+	return diag.FromErr(identity.SetMultiPartIdentity(d, map[string]string{
+		"region":        region.String(),
+		"deployment_id": deploymentID,
+		"name":          found.Name,
+	}, "region", "deployment_id", "name"))
 }
 
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
@@ -13,6 +13,7 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
@@ -27,7 +28,7 @@ func ResourceDeployment() *schema.Resource {
 		UpdateContext: ResourceDeploymentUpdate,
 		DeleteContext: ResourceDeploymentDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthroughRegional(),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create:  schema.DefaultTimeout(defaultInferenceDeploymentTimeout),
@@ -38,6 +39,7 @@ func ResourceDeployment() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    deploymentSchema,
+		Identity:      identity.DefaultRegional(),
 	}
 }
 
@@ -246,7 +248,10 @@ func ResourceDeploymentCreate(ctx context.Context, d *schema.ResourceData, m any
 		return diag.FromErr(err)
 	}
 
-	d.SetId(regional.NewIDString(region, deployment.ID))
+	err = identity.SetRegionalIdentity(d, region, deployment.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	_, err = waitForDeployment(ctx, api, region, deployment.ID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
@@ -303,6 +308,11 @@ func ResourceDeploymentRead(ctx context.Context, d *schema.ResourceData, m any)
 		return diag.FromErr(err)
 	}
 
+	err = identity.SetRegionalIdentity(d, region, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_ = d.Set("name", deployment.Name)
 	_ = d.Set("region", deployment.Region)
 	_ = d.Set("project_id", deployment.ProjectID)
@@ -10,6 +10,7 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/api/inference/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -25,7 +26,7 @@ func ResourceModel() *schema.Resource {
 		ReadContext:   ResourceModelRead,
 		DeleteContext: ResourceModelDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthroughRegional(),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultModelTimeout),
@@ -34,6 +35,7 @@ func ResourceModel() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    modelSchema,
+		Identity:      identity.DefaultRegional(),
 	}
 }
 
@@ -203,7 +205,10 @@ func ResourceModelCreate(ctx context.Context, d *schema.ResourceData, m any) dia
 		return diag.FromErr(err)
 	}
 
-	d.SetId(regional.NewIDString(region, model.ID))
+	err = identity.SetRegionalIdentity(d, region, model.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	model, err = waitForModel(ctx, api, region, model.ID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
@@ -236,6 +241,11 @@ func ResourceModelRead(ctx context.Context, d *schema.ResourceData, m any) diag.
 		return diag.FromErr(err)
 	}
 
+	err = identity.SetRegionalIdentity(d, region, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_ = d.Set("parameter_size_bits", int32(model.ParameterSizeBits))
 	_ = d.Set("size_bytes", int64(model.SizeBytes))
 	_ = d.Set("name", model.Name)
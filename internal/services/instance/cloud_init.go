@@ -0,0 +1,354 @@
+package instance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.yaml.in/yaml/v4"
+)
+
+// cloudInitMIMEBoundary is fixed so that rendering the same parts always gives the same document
+const cloudInitMIMEBoundary = "MIMEBOUNDARY"
+
+// cloudInitPart is one part of a multipart cloud-init document
+type cloudInitPart struct {
+	ContentType string
+	Filename    string
+	Content     string
+}
+
+// cloudInitContentType returns the MIME type of a cloud-init document from its first line
+func cloudInitContentType(content string) (string, error) {
+	firstLine, _, _ := strings.Cut(strings.TrimLeft(content, " \t\r\n"), "\n")
+	firstLine = strings.TrimSpace(firstLine)
+
+	switch {
+	case strings.HasPrefix(firstLine, "#cloud-config"):
+		return "text/cloud-config", nil
+	case strings.HasPrefix(firstLine, "#cloud-boothook"):
+		return "text/cloud-boothook", nil
+	case strings.HasPrefix(firstLine, "#include"):
+		return "text/x-include-url", nil
+	case strings.HasPrefix(firstLine, "#!"):
+		return "text/x-shellscript", nil
+	case strings.HasPrefix(strings.ToLower(firstLine), "content-type:"):
+		return "", errors.New("cloud-init is already a MIME document")
+	default:
+		return "", errors.New("cloud-init must start with #cloud-config, #cloud-boothook, #include or a shebang")
+	}
+}
+
+// renderCloudInitMultipart renders the parts into a multipart/mixed document, they are run by cloud-init in this order
+func renderCloudInitMultipart(parts []cloudInitPart) (string, error) {
+	buf := &bytes.Buffer{}
+	_, _ = fmt.Fprintf(buf, "Content-Type: multipart/mixed; boundary=%q\nMIME-Version: 1.0\n\n", cloudInitMIMEBoundary)
+
+	writer := multipart.NewWriter(buf)
+
+	err := writer.SetBoundary(cloudInitMIMEBoundary)
+	if err != nil {
+		return "", err
+	}
+
+	for _, part := range parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.ContentType+`; charset="utf-8"`)
+		header.Set("Content-Transfer-Encoding", "7bit")
+		header.Set("MIME-Version", "1.0")
+
+		if part.Filename != "" {
+			header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", part.Filename))
+		}
+
+		partWriter, err := writer.CreatePart(header)
+		if err != nil {
+			return "", err
+		}
+
+		_, err = partWriter.Write([]byte(part.Content))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// cloudInitMaxSize is the maximum size of a user data value accepted by the metadata service
+const cloudInitMaxSize = 127998
+
+// cloudInitContentTypes are the MIME types of the parts handled by cloud-init
+var cloudInitContentTypes = []string{
+	"text/cloud-config",
+	"text/cloud-boothook",
+	"text/x-include-url",
+	"text/x-shellscript",
+	"text/jinja2",
+	"text/part-handler",
+}
+
+func cloudInitConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The cloud-init configuration rendered as a multipart MIME document",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"part": {
+					Type:        schema.TypeList,
+					Required:    true,
+					MinItems:    1,
+					Description: "The parts of the cloud-init configuration, they are run by cloud-init in this order",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"content_type": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								Description:  "The MIME type of the part, it is detected from the first line of the content if not set",
+								ValidateFunc: validation.StringInSlice(cloudInitContentTypes, false),
+							},
+							"filename": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "The filename of the part",
+							},
+							"content": {
+								Type:             schema.TypeString,
+								Required:         true,
+								Description:      "The content of the part",
+								DiffSuppressFunc: diffSuppressCloudInitPartContent,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandCloudInitConfig returns the parts of a cloud_init_config block, the missing content types are detected from the content
+func expandCloudInitConfig(raw any) ([]cloudInitPart, error) {
+	rawConfig, ok := raw.([]any)
+	if !ok || len(rawConfig) == 0 || rawConfig[0] == nil {
+		return nil, nil
+	}
+
+	rawParts, _ := rawConfig[0].(map[string]any)["part"].([]any)
+	parts := make([]cloudInitPart, 0, len(rawParts))
+
+	for i, rawPart := range rawParts {
+		part, ok := rawPart.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		content := part["content"].(string)
+		contentType := part["content_type"].(string)
+
+		if contentType == "" {
+			detectedContentType, err := cloudInitContentType(content)
+			if err != nil {
+				return nil, fmt.Errorf("part %d: %w", i, err)
+			}
+
+			contentType = detectedContentType
+		}
+
+		parts = append(parts, cloudInitPart{
+			ContentType: contentType,
+			Filename:    part["filename"].(string),
+			Content:     content,
+		})
+	}
+
+	return parts, nil
+}
+
+// renderCloudInitConfig renders a cloud_init_config block into the multipart document sent as cloud-init user data
+func renderCloudInitConfig(raw any) (string, error) {
+	parts, err := expandCloudInitConfig(raw)
+	if err != nil {
+		return "", err
+	}
+
+	return renderCloudInitMultipart(parts)
+}
+
+// parseCloudInitMultipart returns the parts of a cloud-init document, a document that is not multipart is returned as a single part
+func parseCloudInitMultipart(document string) ([]cloudInitPart, error) {
+	message, err := mail.ReadMessage(strings.NewReader(document))
+	if err != nil {
+		contentType, err := cloudInitContentType(document)
+		if err != nil {
+			return nil, err
+		}
+
+		return []cloudInitPart{{ContentType: contentType, Content: document}}, nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+
+	if mediaType != "multipart/mixed" {
+		return nil, fmt.Errorf("unsupported cloud-init document of type %s", mediaType)
+	}
+
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	parts := []cloudInitPart(nil)
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+
+		partMediaType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, cloudInitPart{
+			ContentType: partMediaType,
+			Filename:    part.FileName(),
+			Content:     string(content),
+		})
+	}
+
+	return parts, nil
+}
+
+// normalizeCloudInitContent returns a canonical form of the content of a part so that
+// cloud-config documents which only differ by their formatting are equal.
+func normalizeCloudInitContent(contentType, content string) string {
+	if contentType != "text/cloud-config" {
+		return strings.TrimSpace(content)
+	}
+
+	var config any
+
+	// The #cloud-config header is a YAML comment and is dropped by the parser
+	err := yaml.Unmarshal([]byte(content), &config)
+	if err != nil {
+		return strings.TrimSpace(content)
+	}
+
+	normalized, err := yaml.Marshal(config)
+	if err != nil {
+		return strings.TrimSpace(content)
+	}
+
+	return string(normalized)
+}
+
+// cloudInitPartsEqual returns whether two parts are run the same way by cloud-init
+func cloudInitPartsEqual(a, b cloudInitPart) bool {
+	return a.ContentType == b.ContentType &&
+		a.Filename == b.Filename &&
+		normalizeCloudInitContent(a.ContentType, a.Content) == normalizeCloudInitContent(b.ContentType, b.Content)
+}
+
+func diffSuppressCloudInitPartContent(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	oldContentType, err := cloudInitContentType(oldValue)
+	if err != nil {
+		return false
+	}
+
+	newContentType, err := cloudInitContentType(newValue)
+	if err != nil {
+		return false
+	}
+
+	return oldContentType == newContentType &&
+		normalizeCloudInitContent(oldContentType, oldValue) == normalizeCloudInitContent(newContentType, newValue)
+}
+
+// flattenCloudInitConfig returns the cloud_init_config block of a cloud-init document.
+// The parts of the state are kept when they are semantically equal to the ones of the document,
+// so that only the parts that actually drifted show up in the plan.
+func flattenCloudInitConfig(document string, rawState any) ([]map[string]any, error) {
+	parts, err := parseCloudInitMultipart(document)
+	if err != nil {
+		return nil, err
+	}
+
+	stateParts, err := expandCloudInitConfig(rawState)
+	if err != nil {
+		stateParts = nil
+	}
+
+	rawStateParts := []any(nil)
+	if rawConfig, ok := rawState.([]any); ok && len(rawConfig) > 0 && rawConfig[0] != nil {
+		rawStateParts, _ = rawConfig[0].(map[string]any)["part"].([]any)
+	}
+
+	flatParts := make([]map[string]any, 0, len(parts))
+
+	for i, part := range parts {
+		if i < len(stateParts) && i < len(rawStateParts) && cloudInitPartsEqual(part, stateParts[i]) {
+			flatParts = append(flatParts, rawStateParts[i].(map[string]any))
+
+			continue
+		}
+
+		flatParts = append(flatParts, map[string]any{
+			"content_type": part.ContentType,
+			"filename":     part.Filename,
+			"content":      part.Content,
+		})
+	}
+
+	return []map[string]any{{"part": flatParts}}, nil
+}
+
+// customDiffCloudInitConfig checks during plan that the cloud_init_config block renders
+// into a document accepted by the metadata service.
+func customDiffCloudInitConfig(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	rawConfig, ok := diff.Get("cloud_init_config").([]any)
+	if !ok || len(rawConfig) == 0 {
+		return nil
+	}
+
+	for i := range diff.Get("cloud_init_config.0.part.#").(int) {
+		if !diff.NewValueKnown(fmt.Sprintf("cloud_init_config.0.part.%d.content", i)) {
+			return nil
+		}
+	}
+
+	document, err := renderCloudInitConfig(rawConfig)
+	if err != nil {
+		return fmt.Errorf("invalid cloud_init_config: %w", err)
+	}
+
+	if len(document) > cloudInitMaxSize {
+		return fmt.Errorf("cloud_init_config renders into a document of %d bytes, the maximum size of user data is %d bytes", len(document), cloudInitMaxSize)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderCloudInitConfig(t *testing.T) {
+	rawConfig := []any{map[string]any{
+		"part": []any{
+			map[string]any{
+				"content_type": "",
+				"filename":     "",
+				"content":      "#cloud-config\npackages:\n  - nginx\n",
+			},
+			map[string]any{
+				"content_type": "",
+				"filename":     "setup.sh",
+				"content":      "#!/bin/sh\necho ok\n",
+			},
+		},
+	}}
+
+	document, err := renderCloudInitConfig(rawConfig)
+	require.NoError(t, err)
+	assert.Contains(t, document, "Content-Type: multipart/mixed")
+
+	parts, err := parseCloudInitMultipart(document)
+	require.NoError(t, err)
+	assert.Equal(t, []cloudInitPart{
+		{ContentType: "text/cloud-config", Content: "#cloud-config\npackages:\n  - nginx\n"},
+		{ContentType: "text/x-shellscript", Filename: "setup.sh", Content: "#!/bin/sh\necho ok\n"},
+	}, parts)
+
+	// Rendering the same parts gives the same document
+	sameDocument, err := renderCloudInitConfig(rawConfig)
+	require.NoError(t, err)
+	assert.Equal(t, document, sameDocument)
+
+	_, err = renderCloudInitConfig([]any{map[string]any{
+		"part": []any{map[string]any{"content_type": "", "filename": "", "content": "packages: []"}},
+	}})
+	assert.Error(t, err, "the content type of a part without header cannot be detected")
+}
+
+func TestParseCloudInitMultipartSingleDocument(t *testing.T) {
+	parts, err := parseCloudInitMultipart("#cloud-config\nruncmd: []\n")
+	require.NoError(t, err)
+	assert.Equal(t, []cloudInitPart{{ContentType: "text/cloud-config", Content: "#cloud-config\nruncmd: []\n"}}, parts)
+}
+
+func TestCloudInitPartContentDiffSuppress(t *testing.T) {
+	assert.True(t, diffSuppressCloudInitPartContent("", "#cloud-config\npackages: [nginx]\nruncmd: [ls]\n", "#cloud-config\n# comment\nruncmd:\n  - ls\npackages:\n  - nginx\n", nil))
+	assert.False(t, diffSuppressCloudInitPartContent("", "#cloud-config\npackages: [nginx]\n", "#cloud-config\npackages: [apache2]\n", nil))
+	assert.False(t, diffSuppressCloudInitPartContent("", "#!/bin/sh\necho  ok\n", "#!/bin/sh\necho ok\n", nil))
+	assert.False(t, diffSuppressCloudInitPartContent("", "", "#cloud-config\n", nil))
+}
+
+func TestFlattenCloudInitConfig(t *testing.T) {
+	statePart := map[string]any{
+		"content_type": "",
+		"filename":     "",
+		"content":      "#cloud-config\npackages: [nginx]\n",
+	}
+	rawState := []any{map[string]any{
+		"part": []any{
+			statePart,
+			map[string]any{"content_type": "", "filename": "", "content": "#!/bin/sh\necho ok\n"},
+		},
+	}}
+
+	document, err := renderCloudInitMultipart([]cloudInitPart{
+		{ContentType: "text/cloud-config", Content: "#cloud-config\npackages:\n  - nginx\n"},
+		{ContentType: "text/x-shellscript", Content: "#!/bin/sh\necho changed\n"},
+	})
+	require.NoError(t, err)
+
+	flat, err := flattenCloudInitConfig(document, rawState)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{{
+		"part": []map[string]any{
+			statePart,
+			{"content_type": "text/x-shellscript", "filename": "", "content": "#!/bin/sh\necho changed\n"},
+		},
+	}}, flat)
+}
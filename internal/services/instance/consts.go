@@ -12,6 +12,11 @@ const (
 	// InstanceServerStateStandby transient state of the instance event waiting third action or rescue mode
 	InstanceServerStateStandby = "standby"
 
+	// InstanceServerReplacementHandover moves the IPs and volumes of the replaced server to the new one
+	InstanceServerReplacementHandover = "handover"
+	// InstanceServerReplacementStopFirst stops the replaced server before moving its IPs and volumes
+	InstanceServerReplacementStopFirst = "stop_first"
+
 	DefaultInstanceServerWaitTimeout        = 20 * time.Minute
 	defaultInstancePrivateNICWaitTimeout    = 10 * time.Minute
 	defaultInstanceVolumeDeleteTimeout      = 10 * time.Minute
@@ -4,17 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	blockSDK "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	ipamSDK "github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v2"
 	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	scwvalidation "github.com/scaleway/scaleway-sdk-go/validation"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
@@ -283,6 +289,11 @@ func preparePrivateNIC(
 				ServerID:         server.ID,
 				PrivateNetworkID: currentPN.ID,
 			}
+
+			if rawIPAMIPIDs, ok := r["ipam_ip_ids"].([]any); ok && len(rawIPAMIPIDs) > 0 {
+				query.IpamIPIDs = locality.ExpandIDs(rawIPAMIPIDs)
+			}
+
 			res = append(res, query)
 		}
 	}
@@ -338,13 +349,15 @@ func (ph *privateNICsHandler) detach(ctx context.Context, o any, timeout time.Du
 			if err != nil && !httperrors.Is404(err) {
 				return err
 			}
+
+			delete(ph.privateNICsMap, idPN)
 		}
 	}
 
 	return nil
 }
 
-func (ph *privateNICsHandler) attach(ctx context.Context, n any, timeout time.Duration) error {
+func (ph *privateNICsHandler) attach(ctx context.Context, n any, ipamIPIDs []string, timeout time.Duration) error {
 	if nPtr := types.ExpandStringPtr(n); nPtr != nil {
 		// check if new private network was already attached on instance server
 		privateNetworkID := locality.ExpandID(*nPtr)
@@ -353,6 +366,7 @@ func (ph *privateNICsHandler) attach(ctx context.Context, n any, timeout time.Du
 				Zone:             ph.zone,
 				ServerID:         ph.serverID,
 				PrivateNetworkID: privateNetworkID,
+				IpamIPIDs:        ipamIPIDs,
 			})
 			if err != nil {
 				return err
@@ -386,7 +400,11 @@ func (ph *privateNICsHandler) set(d *schema.ResourceData) error {
 			continue
 		}
 
-		privateNetworks = append(privateNetworks, keyRaw.(map[string]any))
+		// The IPAM IPs are only sent when the private NIC is created
+		privateNetwork := keyRaw.(map[string]any)
+		privateNetwork["ipam_ip_ids"] = d.Get(fmt.Sprintf("private_network.%d.ipam_ip_ids", index))
+
+		privateNetworks = append(privateNetworks, privateNetwork)
 	}
 
 	return d.Set("private_network", privateNetworks)
@@ -412,6 +430,30 @@ func (ph *privateNICsHandler) get(key string) (any, error) {
 	}, nil
 }
 
+// expandServerImageID returns the ID of the image of a server, an image label is looked up in the marketplace
+func expandServerImageID(m any, zone scw.Zone, commercialType string, image string, rootVolumeType instance.VolumeVolumeType) (string, error) {
+	if image == "" || scwvalidation.IsUUID(image) {
+		return image, nil
+	}
+
+	// Replace dashes with underscores ubuntu-focal -> ubuntu_focal
+	imageLabel := formatImageLabel(image)
+
+	marketPlaceAPI := marketplace.NewAPI(meta.ExtractScwClient(m))
+
+	localImage, err := marketPlaceAPI.GetLocalImageByLabel(&marketplace.GetLocalImageByLabelRequest{
+		CommercialType: commercialType,
+		Zone:           zone,
+		ImageLabel:     imageLabel,
+		Type:           volumeTypeToMarketplaceFilter(rootVolumeType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not get image '%s': %w", zonal.NewID(zone, imageLabel), err)
+	}
+
+	return localImage.ID, nil
+}
+
 func formatImageLabel(imageUUID string) string {
 	return strings.ReplaceAll(imageUUID, "-", "_")
 }
@@ -646,3 +688,445 @@ func FindIPInList(ipID string, ips []*instance.ServerIP) *instance.ServerIP {
 
 	return nil
 }
+
+// serverHandover moves to a new server the resources it requests that are still attached to the server it replaces
+type serverHandover struct {
+	// replacedServerID is the server replaced by the new one, resources attached to any other server are not handed over
+	replacedServerID string
+	stopFirst        bool
+	// ipIDs are the flexible IPs of the new server, attached once created when one of them is held by the replaced server
+	ipIDs   []string
+	heldIPs []string
+	// volumeIDs are the additional volumes of the new server, attached once created when one of them is held by the replaced server
+	volumeIDs   []string
+	heldVolumes map[string]*instancehelpers.UnknownVolume
+	// privateNetworks maps the private networks whose NIC on the replaced server holds requested IPAM IPs to these IPAM IPs
+	privateNetworks map[string][]string
+
+	// The state of the handover, used to give the resources back to the replaced server
+	replacedState        instance.ServerState
+	stopped              bool
+	movedIPs             []string
+	movedPrivateNetworks []string
+	movedVolumes         []*instancehelpers.UnknownVolume
+}
+
+// holdsResources returns true if the replaced server holds resources requested by the new server
+func (h *serverHandover) holdsResources() bool {
+	return len(h.heldIPs) > 0 || len(h.heldVolumes) > 0 || len(h.privateNetworks) > 0
+}
+
+// heldByReplacedServer returns true if a requested resource is attached to the replaced server.
+// A resource attached to another server is not handed over and fails the creation of the server.
+func (h *serverHandover) heldByReplacedServer(resource string, serverID string) (bool, error) {
+	switch serverID {
+	case "":
+		return false, nil
+	case h.replacedServerID:
+		return true, nil
+	default:
+		return false, fmt.Errorf("%s is attached to server %s, which is not the server replaced by this resource", resource, serverID)
+	}
+}
+
+// prepareServerHandover looks for the IPs, IPAM IPs and additional volumes of the server being created that are attached to the server it replaces
+func prepareServerHandover(ctx context.Context, d *schema.ResourceData, m any, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, stopFirst bool) (*serverHandover, error) {
+	h := &serverHandover{
+		replacedServerID: zonal.ExpandID(d.Get("replaced_server_id")).ID,
+		stopFirst:        stopFirst,
+		heldVolumes:      map[string]*instancehelpers.UnknownVolume{},
+		privateNetworks:  map[string][]string{},
+	}
+
+	ipIDs := locality.ExpandIDs(d.Get("ip_ids"))
+	if ipID, ok := d.GetOk("ip_id"); ok {
+		ipIDs = []string{zonal.ExpandID(ipID).ID}
+	}
+
+	for _, ipID := range ipIDs {
+		res, err := api.GetIP(&instance.GetIPRequest{
+			Zone: zone,
+			IP:   ipID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		if res.IP.Server == nil {
+			continue
+		}
+
+		held, err := h.heldByReplacedServer("IP "+ipID, res.IP.Server.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if held {
+			h.heldIPs = append(h.heldIPs, ipID)
+		}
+	}
+
+	if len(h.heldIPs) > 0 {
+		h.ipIDs = ipIDs
+	}
+
+	volumeIDs := locality.ExpandIDs(d.Get("additional_volume_ids"))
+
+	for _, volumeID := range volumeIDs {
+		volume, err := api.GetUnknownVolume(&instancehelpers.GetUnknownVolumeRequest{
+			VolumeID: volumeID,
+			Zone:     zone,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		if !volume.IsAttached() {
+			continue
+		}
+
+		held, err := h.heldByReplacedServer("volume "+volume.ID, *volume.ServerID)
+		if err != nil {
+			return nil, err
+		}
+
+		if held && volume.IsLocal() {
+			return nil, fmt.Errorf("local volume %s is attached to server %s and cannot be handed over", volume.ID, *volume.ServerID)
+		}
+
+		if held {
+			h.heldVolumes[volume.ID] = volume
+		}
+	}
+
+	if len(h.heldVolumes) > 0 {
+		h.volumeIDs = volumeIDs
+	}
+
+	region, err := zone.Region()
+	if err != nil {
+		return nil, err
+	}
+
+	ipamAPI := ipamSDK.NewAPI(meta.ExtractScwClient(m))
+
+	ipamIPIDs := expandServerPrivateNetworkIPAMIPIDs(d.Get("private_network"))
+
+	// The private NICs of the replaced server, listed once an IPAM IP is found attached
+	var replacedNICs map[string]*instance.PrivateNIC
+
+	for _, pnID := range expandServerPrivateNetworkIDs(d.Get("private_network")) {
+		for _, ipamIPID := range ipamIPIDs[pnID] {
+			ip, err := ipamAPI.GetIP(&ipamSDK.GetIPRequest{
+				Region: region,
+				IPID:   ipamIPID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			if ip.Resource == nil {
+				continue
+			}
+
+			if replacedNICs == nil {
+				replacedNICs, err = listReplacedServerPrivateNICs(ctx, api.API, zone, h.replacedServerID)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if _, ok := replacedNICs[ip.Resource.ID]; !ok {
+				return nil, fmt.Errorf("IPAM IP %s is attached to %s %s, which does not belong to the server replaced by this resource", ipamIPID, ip.Resource.Type, ip.Resource.ID)
+			}
+
+			h.privateNetworks[pnID] = append(h.privateNetworks[pnID], ipamIPID)
+		}
+	}
+
+	return h, nil
+}
+
+// listReplacedServerPrivateNICs returns the private NICs of the replaced server by ID
+func listReplacedServerPrivateNICs(ctx context.Context, api *instance.API, zone scw.Zone, serverID string) (map[string]*instance.PrivateNIC, error) {
+	nics := map[string]*instance.PrivateNIC{}
+	if serverID == "" {
+		return nics, nil
+	}
+
+	res, err := api.ListPrivateNICs(&instance.ListPrivateNICsRequest{
+		Zone:     zone,
+		ServerID: serverID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nics, nil
+		}
+
+		return nil, err
+	}
+
+	for _, nic := range res.PrivateNics {
+		nics[nic.ID] = nic
+	}
+
+	return nics, nil
+}
+
+// excludeFrom leaves the IPs and additional volumes out of the server creation request when some of them are handed over,
+// they are attached in their configured order once the new server is created.
+func (h *serverHandover) excludeFrom(req *instance.CreateServerRequest) {
+	if len(h.ipIDs) > 0 {
+		req.PublicIPs = nil
+	}
+
+	if len(h.volumeIDs) > 0 {
+		for index := range req.Volumes {
+			if index != "0" {
+				delete(req.Volumes, index)
+			}
+		}
+	}
+}
+
+// handover moves the resources held by the replaced server to the new server.
+// The replaced server is stopped first if requested, then its flexible IPs, private NICs and additional volumes are moved in this order.
+// The private NICs are only detached, they are created on the new server along with its other private NICs.
+func (h *serverHandover) handover(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, serverID string, timeout time.Duration) error {
+	if !h.holdsResources() {
+		return nil
+	}
+
+	replacedServer, err := waitForServer(ctx, api.API, zone, h.replacedServerID, timeout)
+	if err != nil {
+		return err
+	}
+
+	if h.stopFirst {
+		tflog.Debug(ctx, "stopping replaced server "+h.replacedServerID)
+
+		h.replacedState = replacedServer.State
+		h.stopped = true
+
+		err = reachState(ctx, api, zone, h.replacedServerID, instance.ServerStateStopped)
+		if err != nil {
+			return fmt.Errorf("failed to stop replaced server %s: %w", h.replacedServerID, err)
+		}
+	}
+
+	for _, ipID := range h.ipIDs {
+		tflog.Debug(ctx, fmt.Sprintf("attaching IP %s to server %s", ipID, serverID))
+
+		_, err := api.UpdateIP(&instance.UpdateIPRequest{
+			Zone:   zone,
+			IP:     ipID,
+			Server: &instance.NullableStringValue{Value: serverID},
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to attach IP %s to server %s: %w", ipID, serverID, err)
+		}
+
+		if slices.Contains(h.heldIPs, ipID) {
+			h.movedIPs = append(h.movedIPs, ipID)
+		}
+	}
+
+	if len(h.privateNetworks) > 0 {
+		ph, err := newPrivateNICHandler(api.API, h.replacedServerID, zone)
+		if err != nil {
+			return err
+		}
+
+		for _, pnID := range slices.Sorted(maps.Keys(h.privateNetworks)) {
+			tflog.Debug(ctx, fmt.Sprintf("removing private network %s from server %s", pnID, h.replacedServerID))
+
+			err = ph.detach(ctx, pnID, timeout)
+			if err != nil {
+				return fmt.Errorf("failed to detach private network %s from server %s: %w", pnID, h.replacedServerID, err)
+			}
+
+			h.movedPrivateNetworks = append(h.movedPrivateNetworks, pnID)
+		}
+	}
+
+	for _, volumeID := range h.volumeIDs {
+		if volume, ok := h.heldVolumes[volumeID]; ok {
+			tflog.Debug(ctx, fmt.Sprintf("detaching volume %s from server %s", volumeID, h.replacedServerID))
+
+			_, err := api.DetachVolume(&instance.DetachVolumeRequest{
+				Zone:          zone,
+				VolumeID:      volumeID,
+				IsBlockVolume: new(volume.IsBlockVolume()),
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return fmt.Errorf("failed to detach volume %s from server %s, the server may need to be stopped first: %w", volumeID, h.replacedServerID, err)
+			}
+
+			h.movedVolumes = append(h.movedVolumes, volume)
+
+			_, err = waitForServer(ctx, api.API, zone, h.replacedServerID, timeout)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := api.AttachVolume(&instance.AttachVolumeRequest{
+			Zone:     zone,
+			ServerID: serverID,
+			VolumeID: volumeID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to attach volume %s to server %s: %w", volumeID, serverID, err)
+		}
+
+		_, err = waitForServer(ctx, api.API, zone, serverID, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rollback gives the handed over resources back to the replaced server when the new server cannot be fully created
+func (h *serverHandover) rollback(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, serverID string, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	addError := func(resource string, err error) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("failed to give %s back to replaced server %s", resource, h.replacedServerID),
+			Detail:   err.Error(),
+		})
+	}
+
+	for _, volume := range h.movedVolumes {
+		err := h.giveVolumeBack(ctx, api, zone, serverID, volume, timeout)
+		if err != nil {
+			addError("volume "+volume.ID, err)
+		}
+	}
+
+	for _, pnID := range h.movedPrivateNetworks {
+		err := h.givePrivateNetworkBack(ctx, api.API, zone, serverID, pnID, timeout)
+		if err != nil {
+			addError("private network "+pnID, err)
+		}
+	}
+
+	for _, ipID := range h.movedIPs {
+		_, err := api.UpdateIP(&instance.UpdateIPRequest{
+			Zone:   zone,
+			IP:     ipID,
+			Server: &instance.NullableStringValue{Value: h.replacedServerID},
+		}, scw.WithContext(ctx))
+		if err != nil {
+			addError("IP "+ipID, err)
+		}
+	}
+
+	if h.stopped {
+		err := reachState(ctx, api, zone, h.replacedServerID, h.replacedState)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("failed to restore the state of replaced server %s", h.replacedServerID),
+				Detail:   err.Error(),
+			})
+		}
+	}
+
+	return diags
+}
+
+func (h *serverHandover) giveVolumeBack(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, serverID string, volume *instancehelpers.UnknownVolume, timeout time.Duration) error {
+	current, err := api.GetUnknownVolume(&instancehelpers.GetUnknownVolumeRequest{
+		VolumeID: volume.ID,
+		Zone:     zone,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	if current.IsAttached() && *current.ServerID == serverID {
+		_, err = api.DetachVolume(&instance.DetachVolumeRequest{
+			Zone:          zone,
+			VolumeID:      volume.ID,
+			IsBlockVolume: new(volume.IsBlockVolume()),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+
+		_, err = waitForServer(ctx, api.API, zone, serverID, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = api.AttachVolume(&instance.AttachVolumeRequest{
+		Zone:     zone,
+		ServerID: h.replacedServerID,
+		VolumeID: volume.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	_, err = waitForServer(ctx, api.API, zone, h.replacedServerID, timeout)
+
+	return err
+}
+
+func (h *serverHandover) givePrivateNetworkBack(ctx context.Context, api *instance.API, zone scw.Zone, serverID string, pnID string, timeout time.Duration) error {
+	ph, err := newPrivateNICHandler(api, serverID, zone)
+	if err != nil {
+		return err
+	}
+
+	err = ph.detach(ctx, pnID, timeout)
+	if err != nil {
+		return err
+	}
+
+	ph, err = newPrivateNICHandler(api, h.replacedServerID, zone)
+	if err != nil {
+		return err
+	}
+
+	return ph.attach(ctx, pnID, h.privateNetworks[pnID], timeout)
+}
+
+// serverIPsToDetach returns the flexible IPs to detach before deleting a server.
+// With a replacement strategy, only the IPs of ip_id and ip_ids still attached to the server are returned,
+// the ones handed over to the server replacing it are left out.
+func serverIPsToDetach(ctx context.Context, d *schema.ResourceData, api *instance.API, zone scw.Zone, serverID string) []string {
+	ipID, hasIPID := d.GetOk("ip_id")
+
+	if _, ok := d.GetOk("replacement_strategy"); !ok {
+		if hasIPID {
+			return []string{zonal.ExpandID(ipID).ID}
+		}
+
+		return nil
+	}
+
+	ipIDs := locality.ExpandIDs(d.Get("ip_ids"))
+	if hasIPID {
+		ipIDs = []string{zonal.ExpandID(ipID).ID}
+	}
+
+	attachedIPIDs := []string(nil)
+
+	for _, id := range ipIDs {
+		res, err := api.GetIP(&instance.GetIPRequest{
+			Zone: zone,
+			IP:   id,
+		}, scw.WithContext(ctx))
+		if err != nil || (res.IP.Server != nil && res.IP.Server.ID == serverID) {
+			attachedIPIDs = append(attachedIPIDs, id)
+		}
+	}
+
+	return attachedIPIDs
+}
@@ -0,0 +1,656 @@
+package instance
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	blockSDK "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/instancehelpers"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+)
+
+const (
+	// imageBuildStatusDone is written to the marker key once cloud-init completed successfully
+	imageBuildStatusDone = "done"
+	// imageBuildStatusError is written to the marker key when cloud-init reported an error
+	imageBuildStatusError = "error"
+
+	defaultImageBuildMarkerKey = "image-build-status"
+	imageBuildServerTag        = "image-build"
+)
+
+var imageBuildMarkerKeyRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+func ResourceImageBuild() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceInstanceImageBuildCreate,
+		ReadContext:   ResourceInstanceImageBuildRead,
+		UpdateContext: ResourceInstanceImageBuildUpdate,
+		DeleteContext: ResourceInstanceImageBuildDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultInstanceImageTimeout),
+			Read:    schema.DefaultTimeout(defaultInstanceImageTimeout),
+			Update:  schema.DefaultTimeout(defaultInstanceImageTimeout),
+			Delete:  schema.DefaultTimeout(defaultInstanceImageTimeout),
+			Default: schema.DefaultTimeout(defaultInstanceImageTimeout),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    imageBuildSchema,
+		Identity:      identity.DefaultZonal(),
+	}
+}
+
+func imageBuildSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The name of the image",
+		},
+		"base_image": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			Description:      "The UUID or the label of the image the build server boots from",
+			DiffSuppressFunc: dsf.Locality,
+		},
+		"type": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			Description:      "The commercial type of the build server",
+			DiffSuppressFunc: dsf.IgnoreCase,
+		},
+		"user_data": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "The user data of the build server, the cloud-init key is run by cloud-init before the image is captured",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"root_volume_size_in_gb": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "The size of the root volume of the build server, the default size of the base image is used if not set",
+		},
+		"additional_volumes": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "The block volumes attached to the build server and captured in the image",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"size_in_gb": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+						Description:  "The size of the volume",
+					},
+				},
+			},
+		},
+		"enable_dynamic_ip": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     true,
+			Description: "Give a public IP to the build server so that cloud-init can reach the internet",
+		},
+		"ready_marker_key": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      defaultImageBuildMarkerKey,
+			ValidateFunc: validation.StringMatch(imageBuildMarkerKeyRegexp, "must only contain letters, digits, dashes and underscores"),
+			Description:  "The user data key where the build server reports the completion of cloud-init",
+		},
+		"tags": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "List of tags [\"tag1\", \"tag2\", ...] attached to the image",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		// Computed
+		"architecture": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Architecture of the image",
+		},
+		"root_volume_snapshot_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the snapshot of the root volume",
+		},
+		"additional_volume_snapshot_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The IDs of the snapshots of the additional volumes",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"creation_date": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date and time of the creation of the image",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The state of the image [ available | creating | error ]",
+		},
+		// Common
+		"zone":            zonal.Schema(),
+		"project_id":      account.ProjectIDSchema(),
+		"organization_id": account.OrganizationIDSchema(),
+	}
+}
+
+// imageBuildCloudInit renders the cloud-init of the build server: the cloud-init of the user data
+// followed by a script reporting the result of cloud-init in the marker key through the metadata API.
+func imageBuildCloudInit(cloudInit string, markerKey string) (string, error) {
+	var parts []cloudInitPart
+
+	if cloudInit != "" {
+		contentType, err := cloudInitContentType(cloudInit)
+		if err != nil {
+			return "", err
+		}
+
+		parts = append(parts, cloudInitPart{
+			ContentType: contentType,
+			Filename:    "user-data",
+			Content:     cloudInit,
+		})
+	}
+
+	// The script runs while cloud-init is still running, it waits in the background for cloud-init to complete.
+	// Writing user data through the metadata API requires a source port below 1024.
+	parts = append(parts, cloudInitPart{
+		ContentType: "text/x-shellscript",
+		Filename:    "image-build-status.sh",
+		Content: fmt.Sprintf(`#!/bin/sh
+nohup sh -c '
+  if cloud-init status --wait >/dev/null 2>&1; then status=%[1]s; else status=%[2]s; fi
+  curl -s --retry 5 --local-port 1-1023 -X PATCH -H "Content-Type: text/plain" --data "$status" http://169.254.42.42/user_data/%[3]s
+' >/dev/null 2>&1 &
+`, imageBuildStatusDone, imageBuildStatusError, markerKey),
+	})
+
+	return renderCloudInitMultipart(parts)
+}
+
+// waitForImageBuildMarker waits for the build server to report the result of cloud-init in its user data
+func waitForImageBuildMarker(ctx context.Context, api *instanceSDK.API, zone scw.Zone, serverID string, markerKey string, timeout time.Duration) error {
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		value, err := api.GetServerUserData(&instanceSDK.GetServerUserDataRequest{
+			Zone:     zone,
+			ServerID: serverID,
+			Key:      markerKey,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if httperrors.Is404(err) {
+				return retry.RetryableError(fmt.Errorf("cloud-init has not completed yet on server %s", serverID))
+			}
+
+			return retry.NonRetryableError(err)
+		}
+
+		status, err := io.ReadAll(value)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		switch strings.TrimSpace(string(status)) {
+		case imageBuildStatusDone:
+			return nil
+		case imageBuildStatusError:
+			return retry.NonRetryableError(fmt.Errorf("cloud-init failed on server %s, run 'cloud-init status --long' on the server for details", serverID))
+		default:
+			return retry.RetryableError(fmt.Errorf("cloud-init has not completed yet on server %s", serverID))
+		}
+	})
+}
+
+// snapshotImageBuildVolume snapshots a volume of the build server and waits for the snapshot to be available
+func snapshotImageBuildVolume(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, volume *instanceSDK.VolumeServer, name string, timeout time.Duration) (string, error) {
+	if volume.VolumeType == instanceSDK.VolumeServerVolumeTypeSbsVolume {
+		snapshot, err := api.BlockAPI.CreateSnapshot(&blockSDK.CreateSnapshotRequest{
+			Zone:     zone,
+			VolumeID: volume.ID,
+			Name:     name,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return "", err
+		}
+
+		_, err = api.BlockAPI.WaitForSnapshot(&blockSDK.WaitForSnapshotRequest{
+			Zone:          zone,
+			SnapshotID:    snapshot.ID,
+			Timeout:       new(timeout),
+			RetryInterval: transport.DefaultWaitRetryInterval,
+		}, scw.WithContext(ctx))
+
+		return snapshot.ID, err
+	}
+
+	snapshot, err := api.CreateSnapshot(&instanceSDK.CreateSnapshotRequest{
+		Zone:     zone,
+		VolumeID: &volume.ID,
+		Name:     name,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	_, err = waitForSnapshot(ctx, api.API, zone, snapshot.Snapshot.ID, timeout)
+
+	return snapshot.Snapshot.ID, err
+}
+
+// deleteImageBuildServer deletes the build server and its volumes, it is called even if the build failed
+func deleteImageBuildServer(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, serverID string, timeout time.Duration) error {
+	res, err := api.GetServer(&instanceSDK.GetServerRequest{
+		Zone:     zone,
+		ServerID: serverID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	err = deleteServer(ctx, api, zone, serverID, timeout)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, volume := range res.Server.Volumes {
+		err = api.DeleteUnknownVolume(&instancehelpers.DeleteUnknownVolumeRequest{
+			Zone:     zone,
+			VolumeID: volume.ID,
+		}, scw.WithContext(ctx))
+		if err != nil && !httperrors.Is404(err) {
+			errs = append(errs, fmt.Errorf("failed to delete volume %s: %w", volume.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func ResourceInstanceImageBuildCreate(ctx context.Context, d *schema.ResourceData, m any) (diags diag.Diagnostics) {
+	api, zone, err := instancehelpers.InstanceAndBlockAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	name := types.ExpandOrGenerateString(d.Get("name"), "image")
+	commercialType := d.Get("type").(string)
+	markerKey := d.Get("ready_marker_key").(string)
+
+	userData := map[string]io.Reader{}
+	cloudInit := ""
+
+	for key, value := range d.Get("user_data").(map[string]any) {
+		if key == "cloud-init" {
+			cloudInit = value.(string)
+
+			continue
+		}
+
+		if key == markerKey {
+			return diag.Errorf("user_data must not define the ready marker key %q", markerKey)
+		}
+
+		userData[key] = bytes.NewBufferString(value.(string))
+	}
+
+	renderedCloudInit, err := imageBuildCloudInit(cloudInit, markerKey)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("invalid cloud-init in user_data: %w", err))
+	}
+
+	userData["cloud-init"] = bytes.NewBufferString(renderedCloudInit)
+
+	////
+	// Create the build server
+	////
+	serverType := getServerType(ctx, api.API, zone, commercialType)
+	if serverType == nil {
+		return diag.Errorf("could not find a server type associated with %s in zone %s", commercialType, zone)
+	}
+
+	volumes := map[string]*instanceSDK.VolumeServerTemplate{
+		"0": prepareRootVolume(map[string]any{
+			"volume_type": instanceSDK.VolumeVolumeTypeSbsVolume.String(),
+			"size_in_gb":  d.Get("root_volume_size_in_gb"),
+		}, serverType, d.Get("base_image").(string)).VolumeTemplate(),
+	}
+
+	for i, rawVolume := range d.Get("additional_volumes").([]any) {
+		volumes[fmt.Sprintf("%d", i+1)] = &instanceSDK.VolumeServerTemplate{
+			Name:       new(fmt.Sprintf("%s-vol-%d", name, i+1)),
+			VolumeType: instanceSDK.VolumeVolumeTypeSbsVolume,
+			Size:       new(scw.Size(uint64(rawVolume.(map[string]any)["size_in_gb"].(int)) * gb)),
+		}
+	}
+
+	imageID, err := expandServerImageID(m, zone, commercialType, locality.ExpandID(d.Get("base_image")), volumes["0"].VolumeType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := api.CreateServer(&instanceSDK.CreateServerRequest{
+		Zone:              zone,
+		Name:              name + "-build",
+		Project:           types.ExpandStringPtr(d.Get("project_id")),
+		CommercialType:    commercialType,
+		Image:             &imageID,
+		Volumes:           volumes,
+		DynamicIPRequired: new(d.Get("enable_dynamic_ip").(bool)),
+		Tags:              []string{imageBuildServerTag, name},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverID := res.Server.ID
+
+	var snapshotIDs []string
+
+	// The build server must not leak, it is deleted even if the build fails or is interrupted.
+	// The snapshots are only kept once the image using them has been created.
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), d.Timeout(schema.TimeoutDelete))
+		defer cancel()
+
+		err := deleteImageBuildServer(cleanupCtx, api, zone, serverID, d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("failed to delete build server %s, it must be deleted manually", zonal.NewIDString(zone, serverID)),
+				Detail:   err.Error(),
+			})
+		}
+
+		if !diags.HasError() || d.Id() != "" {
+			return
+		}
+
+		for _, snapshotID := range snapshotIDs {
+			err := deleteImageBuildSnapshot(cleanupCtx, api, zone, snapshotID)
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("failed to delete snapshot %s of the build server, it must be deleted manually", zonal.NewIDString(zone, snapshotID)),
+					Detail:   err.Error(),
+				})
+			}
+		}
+	}()
+
+	_, err = waitForServer(ctx, api.API, zone, serverID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = api.SetAllServerUserData(&instanceSDK.SetAllServerUserDataRequest{
+		Zone:     zone,
+		ServerID: serverID,
+		UserData: userData,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	////
+	// Run cloud-init
+	////
+	tflog.Debug(ctx, fmt.Sprintf("starting build server %s", serverID))
+
+	err = reachState(ctx, api, zone, serverID, instanceSDK.ServerStateRunning)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = waitForImageBuildMarker(ctx, api.API, zone, serverID, markerKey, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = reachState(ctx, api, zone, serverID, instanceSDK.ServerStateStopped)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	////
+	// Capture the image
+	////
+	server, err := waitForServer(ctx, api.API, zone, serverID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, index := range imageBuildVolumeIndexes(server.Volumes) {
+		snapshotID, err := snapshotImageBuildVolume(ctx, api, zone, server.Volumes[index], fmt.Sprintf("%s-%s", name, index), timeout)
+		if snapshotID != "" {
+			snapshotIDs = append(snapshotIDs, snapshotID)
+		}
+
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to snapshot volume %s: %w", server.Volumes[index].ID, err))
+		}
+	}
+
+	_ = d.Set("root_volume_snapshot_id", zonal.NewIDString(zone, snapshotIDs[0]))
+	_ = d.Set("additional_volume_snapshot_ids", zonal.NewIDStrings(zone, snapshotIDs[1:]))
+
+	imageRes, err := api.CreateImage(&instanceSDK.CreateImageRequest{
+		Zone:         zone,
+		Name:         name,
+		RootVolume:   snapshotIDs[0],
+		Arch:         server.Arch,
+		ExtraVolumes: expandImageExtraVolumesTemplates(snapshotIDs[1:]),
+		Project:      types.ExpandStringPtr(d.Get("project_id")),
+		Tags:         types.ExpandStrings(d.Get("tags")),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = identity.SetZonalIdentity(d, zone, imageRes.Image.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForImage(ctx, api.API, zone, imageRes.Image.ID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceInstanceImageBuildRead(ctx, d, m)
+}
+
+func ResourceInstanceImageBuildRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, id, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := instanceAPI.GetImage(&instanceSDK.GetImageRequest{
+		Zone:    zone,
+		ImageID: id,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	image := res.Image
+
+	additionalSnapshotIDs := make([]string, 0, len(image.ExtraVolumes))
+
+	for _, volume := range orderVolumes(image.ExtraVolumes) {
+		additionalSnapshotIDs = append(additionalSnapshotIDs, volume.ID)
+	}
+
+	_ = d.Set("name", image.Name)
+	_ = d.Set("architecture", image.Arch.String())
+	_ = d.Set("tags", image.Tags)
+	_ = d.Set("creation_date", types.FlattenTime(image.CreationDate))
+	_ = d.Set("state", image.State.String())
+	_ = d.Set("additional_volume_snapshot_ids", zonal.NewIDStrings(zone, additionalSnapshotIDs))
+	_ = d.Set("zone", image.Zone.String())
+	_ = d.Set("project_id", image.Project)
+	_ = d.Set("organization_id", image.Organization)
+
+	if image.RootVolume != nil {
+		_ = d.Set("root_volume_snapshot_id", zonal.NewIDString(zone, image.RootVolume.ID))
+	}
+
+	return nil
+}
+
+func ResourceInstanceImageBuildUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, id, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	image, err := waitForImage(ctx, instanceAPI, zone, id, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &instanceSDK.UpdateImageRequest{
+		Zone:    zone,
+		ImageID: id,
+		Name:    &image.Name,
+		Arch:    image.Arch,
+		Tags:    types.ExpandUpdatedStringsPtr(d.Get("tags")),
+	}
+
+	if d.HasChange("name") {
+		req.Name = types.ExpandStringPtr(d.Get("name"))
+	}
+
+	_, err = instanceAPI.UpdateImage(req, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't update image: %w", err))
+	}
+
+	return ResourceInstanceImageBuildRead(ctx, d, m)
+}
+
+func ResourceInstanceImageBuildDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	api, zone, id, err := instancehelpers.InstanceAndBlockAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForImage(ctx, api.API, zone, id, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	err = api.DeleteImage(&instanceSDK.DeleteImageRequest{
+		Zone:    zone,
+		ImageID: id,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	// The snapshots have been created by the build and are only used by the image
+	snapshotIDs := append([]string{d.Get("root_volume_snapshot_id").(string)}, types.ExpandStrings(d.Get("additional_volume_snapshot_ids"))...)
+
+	for _, snapshotID := range snapshotIDs {
+		if snapshotID == "" {
+			continue
+		}
+
+		err = deleteImageBuildSnapshot(ctx, api, zone, locality.ExpandID(snapshotID))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to delete snapshot %s: %w", snapshotID, err))
+		}
+	}
+
+	return nil
+}
+
+// imageBuildVolumeIndexes returns the indexes of the volumes of a server in numeric order, the root volume first
+func imageBuildVolumeIndexes(volumes map[string]*instanceSDK.VolumeServer) []string {
+	indexes := make([]string, 0, len(volumes))
+	for index := range volumes {
+		indexes = append(indexes, index)
+	}
+
+	slices.SortFunc(indexes, func(a, b string) int {
+		aIndex, errA := strconv.Atoi(a)
+		bIndex, errB := strconv.Atoi(b)
+
+		if errA != nil || errB != nil {
+			return strings.Compare(a, b)
+		}
+
+		return cmp.Compare(aIndex, bIndex)
+	})
+
+	return indexes
+}
+
+func deleteImageBuildSnapshot(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, snapshotID string) error {
+	err := api.DeleteUnknownSnapshot(&instancehelpers.DeleteUnknownSnapshotRequest{
+		Zone:       zone,
+		SnapshotID: snapshotID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package instance
+
+import (
+	"strconv"
+	"testing"
+
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageBuildVolumeIndexes(t *testing.T) {
+	volumes := map[string]*instanceSDK.VolumeServer{}
+	for index := range 12 {
+		volumes[strconv.Itoa(index)] = &instanceSDK.VolumeServer{}
+	}
+
+	// "10" and "11" would be sorted before "2" as strings
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"}, imageBuildVolumeIndexes(volumes))
+	assert.Empty(t, imageBuildVolumeIndexes(nil))
+}
@@ -0,0 +1,89 @@
+package instance_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	blockSDK "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageBuildSchema(t *testing.T) {
+	resource := instance.ResourceImageBuild()
+	require.NoError(t, resource.InternalValidate(nil, true))
+
+	schemaMap := resource.SchemaMap()
+
+	for _, key := range []string{"base_image", "type", "user_data", "root_volume_size_in_gb", "additional_volumes", "ready_marker_key"} {
+		assert.True(t, schemaMap[key].ForceNew, "%s must rebuild the image", key)
+	}
+
+	for _, key := range []string{"name", "tags"} {
+		assert.False(t, schemaMap[key].ForceNew, "%s must be updated in place", key)
+	}
+
+	_, errs := schemaMap["ready_marker_key"].ValidateFunc("build/status", "ready_marker_key")
+	assert.NotEmpty(t, errs, "the marker key is used in the metadata API path")
+}
+
+func TestImageBuildCleanupOnFailure(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+	client := m.ScwClient()
+
+	// The image quota of the project is reached, the image build fails once the volumes of the build server are snapshotted
+	volume, err := instanceSDK.NewAPI(client).CreateVolume(&instanceSDK.CreateVolumeRequest{
+		Zone:       scw.ZoneFrPar1,
+		Name:       "test-image-quota",
+		VolumeType: instanceSDK.VolumeVolumeTypeLSSD,
+		Size:       scw.SizePtr(10 * scw.GB),
+	})
+	require.NoError(t, err)
+
+	snapshot, err := instanceSDK.NewAPI(client).CreateSnapshot(&instanceSDK.CreateSnapshotRequest{
+		Zone:     scw.ZoneFrPar1,
+		Name:     "test-image-quota",
+		VolumeID: &volume.Volume.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = instanceSDK.NewAPI(client).CreateImage(&instanceSDK.CreateImageRequest{
+		Zone:       scw.ZoneFrPar1,
+		Name:       "test-image-quota",
+		RootVolume: snapshot.Snapshot.ID,
+		Arch:       instanceSDK.ArchX86_64,
+	})
+	require.NoError(t, err)
+
+	d := schema.TestResourceDataRaw(t, instance.ResourceImageBuild().SchemaMap(), map[string]any{
+		"name":               "test-image-build",
+		"base_image":         ubuntuJammyImageLabel,
+		"type":               "DEV1-S",
+		"additional_volumes": []any{map[string]any{"size_in_gb": 10}},
+	})
+
+	diags := instance.ResourceInstanceImageBuildCreate(t.Context(), d, m)
+	require.True(t, diags.HasError())
+	assert.Empty(t, d.Id())
+
+	for _, diagnostic := range diags {
+		assert.NotEqual(t, diag.Warning, diagnostic.Severity, diagnostic.Summary)
+	}
+
+	servers, err := instanceSDK.NewAPI(client).ListServers(&instanceSDK.ListServersRequest{Zone: scw.ZoneFrPar1})
+	require.NoError(t, err)
+	assert.Empty(t, servers.Servers, "the build server must be deleted")
+
+	volumes, err := blockSDK.NewAPI(client).ListVolumes(&blockSDK.ListVolumesRequest{Zone: scw.ZoneFrPar1})
+	require.NoError(t, err)
+	assert.Empty(t, volumes.Volumes, "the volumes of the build server must be deleted")
+
+	snapshots, err := blockSDK.NewAPI(client).ListSnapshots(&blockSDK.ListSnapshotsRequest{Zone: scw.ZoneFrPar1})
+	require.NoError(t, err)
+	assert.Empty(t, snapshots.Snapshots, "the snapshots of the build server must be deleted")
+}
@@ -304,3 +304,30 @@ func (api *BlockAndInstanceAPI) GetUnknownSnapshot(req *GetUnknownSnapshotReques
 
 	return snap, nil
 }
+
+type DeleteUnknownSnapshotRequest struct {
+	Zone       scw.Zone
+	SnapshotID string
+}
+
+func (api *BlockAndInstanceAPI) DeleteUnknownSnapshot(req *DeleteUnknownSnapshotRequest, opts ...scw.RequestOption) error {
+	unknownSnapshot, err := api.GetUnknownSnapshot(&GetUnknownSnapshotRequest{
+		Zone:       req.Zone,
+		SnapshotID: req.SnapshotID,
+	}, opts...)
+	if err != nil {
+		return err
+	}
+
+	if unknownSnapshot.VolumeType == instance.VolumeVolumeTypeSbsSnapshot {
+		return api.BlockAPI.DeleteSnapshot(&block.DeleteSnapshotRequest{
+			Zone:       req.Zone,
+			SnapshotID: req.SnapshotID,
+		}, opts...)
+	}
+
+	return api.DeleteSnapshot(&instance.DeleteSnapshotRequest{
+		Zone:       req.Zone,
+		SnapshotID: req.SnapshotID,
+	}, opts...)
+}
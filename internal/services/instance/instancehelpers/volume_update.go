@@ -0,0 +1,89 @@
+package instancehelpers
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+)
+
+// VolumeUpdateImpact is the impact of a volume update on the volume data and on the server it is attached to
+type VolumeUpdateImpact string
+
+const (
+	// VolumeUpdateImpactNone is for updates that do not change the volume itself
+	VolumeUpdateImpactNone = VolumeUpdateImpact("")
+	// VolumeUpdateImpactOnline is for updates applied while the server is running
+	VolumeUpdateImpactOnline = VolumeUpdateImpact("online")
+	// VolumeUpdateImpactServerStop is for updates that require to stop the server, the data of the volume is kept
+	VolumeUpdateImpactServerStop = VolumeUpdateImpact("server_stop")
+	// VolumeUpdateImpactDestructive is for updates that can only be done by replacing the volume, the data of the volume is lost
+	VolumeUpdateImpactDestructive = VolumeUpdateImpact("destructive")
+)
+
+func (impact VolumeUpdateImpact) String() string {
+	return string(impact)
+}
+
+// VolumeUpdate is a planned update of a volume, sizes are in GB
+type VolumeUpdate struct {
+	VolumeType    instance.VolumeVolumeType
+	NewVolumeType instance.VolumeVolumeType
+	Size          int
+	NewSize       int
+	IOPSChange    bool
+}
+
+// ClassifyVolumeUpdate returns the impact of a volume update, the most disruptive change of the update wins
+func ClassifyVolumeUpdate(update VolumeUpdate) VolumeUpdateImpact {
+	impact := VolumeUpdateImpactNone
+
+	if update.NewVolumeType != "" && update.NewVolumeType != update.VolumeType {
+		// A local volume is moved to SBS through a snapshot taken while the server is stopped
+		if update.VolumeType != instance.VolumeVolumeTypeLSSD || update.NewVolumeType != instance.VolumeVolumeTypeSbsVolume {
+			return VolumeUpdateImpactDestructive
+		}
+
+		impact = VolumeUpdateImpactServerStop
+	}
+
+	// The type the volume will have when it is resized
+	volumeType := update.VolumeType
+	if update.NewVolumeType != "" {
+		volumeType = update.NewVolumeType
+	}
+
+	switch {
+	case update.NewSize == update.Size || update.NewSize == 0:
+	case update.NewSize < update.Size:
+		return VolumeUpdateImpactDestructive
+	case volumeType == instance.VolumeVolumeTypeSbsVolume || volumeType == instance.VolumeVolumeTypeBSSD:
+		if impact == VolumeUpdateImpactNone {
+			impact = VolumeUpdateImpactOnline
+		}
+	default:
+		// Local and scratch volumes cannot be resized
+		return VolumeUpdateImpactDestructive
+	}
+
+	if update.IOPSChange {
+		// Only SBS volumes have a performance class that can be changed
+		if volumeType != instance.VolumeVolumeTypeSbsVolume {
+			return VolumeUpdateImpactDestructive
+		}
+
+		if impact == VolumeUpdateImpactNone {
+			impact = VolumeUpdateImpactOnline
+		}
+	}
+
+	return impact
+}
+
+// SetPlannedUpdate sets a computed attribute describing the planned update, like update_impact.
+// The attribute only describes the plan, Read clears it once the update is applied.
+func SetPlannedUpdate(diff *schema.ResourceDiff, key string, value any, isEmpty bool) error {
+	if isEmpty {
+		return nil
+	}
+
+	return diff.SetNew(key, value)
+}
@@ -0,0 +1,69 @@
+package instancehelpers_test
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/instancehelpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyVolumeUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		update instancehelpers.VolumeUpdate
+		want   instancehelpers.VolumeUpdateImpact
+	}{
+		{
+			name:   "No change",
+			update: instancehelpers.VolumeUpdate{VolumeType: instance.VolumeVolumeTypeSbsVolume, Size: 20, NewSize: 20},
+			want:   instancehelpers.VolumeUpdateImpactNone,
+		},
+		{
+			name:   "Grow block volume",
+			update: instancehelpers.VolumeUpdate{VolumeType: instance.VolumeVolumeTypeSbsVolume, Size: 20, NewSize: 50},
+			want:   instancehelpers.VolumeUpdateImpactOnline,
+		},
+		{
+			name:   "Change block volume IOPS",
+			update: instancehelpers.VolumeUpdate{VolumeType: instance.VolumeVolumeTypeSbsVolume, Size: 20, NewSize: 20, IOPSChange: true},
+			want:   instancehelpers.VolumeUpdateImpactOnline,
+		},
+		{
+			name:   "Change b_ssd volume IOPS",
+			update: instancehelpers.VolumeUpdate{VolumeType: instance.VolumeVolumeTypeBSSD, Size: 20, NewSize: 20, IOPSChange: true},
+			want:   instancehelpers.VolumeUpdateImpactDestructive,
+		},
+		{
+			name:   "Shrink block volume",
+			update: instancehelpers.VolumeUpdate{VolumeType: instance.VolumeVolumeTypeSbsVolume, Size: 50, NewSize: 20},
+			want:   instancehelpers.VolumeUpdateImpactDestructive,
+		},
+		{
+			name:   "Grow local volume",
+			update: instancehelpers.VolumeUpdate{VolumeType: instance.VolumeVolumeTypeLSSD, Size: 20, NewSize: 50},
+			want:   instancehelpers.VolumeUpdateImpactDestructive,
+		},
+		{
+			name:   "Migrate local volume to block",
+			update: instancehelpers.VolumeUpdate{VolumeType: instance.VolumeVolumeTypeLSSD, NewVolumeType: instance.VolumeVolumeTypeSbsVolume, Size: 20, NewSize: 20},
+			want:   instancehelpers.VolumeUpdateImpactServerStop,
+		},
+		{
+			name:   "Migrate and grow local volume",
+			update: instancehelpers.VolumeUpdate{VolumeType: instance.VolumeVolumeTypeLSSD, NewVolumeType: instance.VolumeVolumeTypeSbsVolume, Size: 20, NewSize: 50},
+			want:   instancehelpers.VolumeUpdateImpactServerStop,
+		},
+		{
+			name:   "Migrate block volume to local",
+			update: instancehelpers.VolumeUpdate{VolumeType: instance.VolumeVolumeTypeSbsVolume, NewVolumeType: instance.VolumeVolumeTypeLSSD, Size: 20, NewSize: 20},
+			want:   instancehelpers.VolumeUpdateImpactDestructive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, instancehelpers.ClassifyVolumeUpdate(tt.update))
+		})
+	}
+}
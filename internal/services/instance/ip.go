@@ -146,18 +146,7 @@ func ResourceInstanceIPUpdate(ctx context.Context, d *schema.ResourceData, m any
 }
 
 func setIPState(d *schema.ResourceData, ip *instanceSDK.IP) diag.Diagnostics {
-	address := ip.Address.String()
-
-	prefix := ip.Prefix.String()
-	if prefix == types.NetIPNil {
-		ipnet := scw.IPNet{}
-		_ = (&ipnet).UnmarshalJSON([]byte("\"" + ip.Address.String() + "\""))
-		prefix = ipnet.String()
-	}
-
-	if address == types.NetIPNil {
-		address = ip.Prefix.IP.String()
-	}
+	address, prefix := flattenIPAddressAndPrefix(ip)
 
 	_ = d.Set("address", address)
 	_ = d.Set("prefix", prefix)
@@ -0,0 +1,479 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+const (
+	// ipPoolTagPrefix tags the IPs of a pool with the ID of the pool
+	ipPoolTagPrefix = "ip-pool="
+	// ipPoolKeyTagPrefix tags the IPs of a pool with their assignment key
+	ipPoolKeyTagPrefix = "ip-pool-key="
+)
+
+func ResourceIPPool() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceInstanceIPPoolCreate,
+		ReadContext:   ResourceInstanceIPPoolRead,
+		UpdateContext: ResourceInstanceIPPoolUpdate,
+		DeleteContext: ResourceInstanceIPPoolDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: identity.ImportStatePassthroughZonal(),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultInstanceIPTimeout),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    ipPoolSchema,
+		Identity:      identity.DefaultZonal(),
+		CustomizeDiff: customDiffInstanceIPPoolKeys,
+	}
+}
+
+func ipPoolSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			Description:      "The type of the IPs of the pool",
+			ValidateDiagFunc: verify.ValidateEnum[instanceSDK.IPType](),
+		},
+		"keys": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			ExactlyOneOf: []string{"keys", "size"},
+			Description:  "The assignment keys of the IPs of the pool, an IP is reserved for each key",
+		},
+		"size": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			ExactlyOneOf: []string{"keys", "size"},
+			Description:  "The number of IPs of the pool, the assignment keys are the indexes from 0 to size - 1",
+		},
+		"tags": {
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional:    true,
+			Description: "The tags associated with the IPs of the pool",
+		},
+		"addresses": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Description: "The IP addresses of the pool by assignment key",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"ip_ids": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Description: "The IDs of the IPs of the pool by assignment key",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"ips": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The IPs of the pool, sorted by assignment key",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"key": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The assignment key of the IP",
+					},
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the IP",
+					},
+					"address": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The IP address",
+					},
+					"prefix": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The IP prefix",
+					},
+					"server_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The server associated with the IP",
+					},
+				},
+			},
+		},
+		"zone":            zonal.Schema(),
+		"organization_id": account.OrganizationIDSchema(),
+		"project_id":      account.ProjectIDSchema(),
+	}
+}
+
+// ipPoolSizeKeys returns the assignment keys of a pool defined by its size
+func ipPoolSizeKeys(size int) []string {
+	keys := make([]string, 0, size)
+	for i := range size {
+		keys = append(keys, strconv.Itoa(i))
+	}
+
+	return keys
+}
+
+// ipPoolSize returns the size of the pool when it is set in the configuration, a size of 0 releases all the IPs of the pool
+func ipPoolSize(rawConfig cty.Value) (size int, isSet bool, isKnown bool) {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return 0, false, rawConfig.IsKnown()
+	}
+
+	rawSize := rawConfig.GetAttr("size")
+	if rawSize.IsNull() {
+		return 0, false, true
+	}
+
+	if !rawSize.IsKnown() {
+		return 0, true, false
+	}
+
+	bigSize, _ := rawSize.AsBigFloat().Int64()
+
+	return int(bigSize), true, true
+}
+
+// expandIPPoolKeys returns the assignment keys of the pool, sorted
+func expandIPPoolKeys(d *schema.ResourceData) []string {
+	if size, isSet, _ := ipPoolSize(d.GetRawConfig()); isSet {
+		return ipPoolSizeKeys(size)
+	}
+
+	keys := types.ExpandStrings(d.Get("keys").(*schema.Set).List())
+	sort.Strings(keys)
+
+	return keys
+}
+
+// ipPoolKey returns the assignment key of an IP of a pool
+func ipPoolKey(ip *instanceSDK.IP) (string, bool) {
+	for _, tag := range ip.Tags {
+		if key, found := strings.CutPrefix(tag, ipPoolKeyTagPrefix); found {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+func ipPoolTags(poolID string, key string, tags []string) []string {
+	return append([]string{ipPoolTagPrefix + poolID, ipPoolKeyTagPrefix + key}, tags...)
+}
+
+// ipPoolUserTags returns the tags of an IP of a pool without the tags of the pool
+func ipPoolUserTags(tags []string) []string {
+	userTags := []string{}
+
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, ipPoolTagPrefix) && !strings.HasPrefix(tag, ipPoolKeyTagPrefix) {
+			userTags = append(userTags, tag)
+		}
+	}
+
+	return userTags
+}
+
+// ipPoolChanges returns the keys to reserve an IP for and the keys whose IP is released
+func ipPoolChanges(existing map[string]*instanceSDK.IP, keys []string) (toReserve []string, toRelease []string) {
+	for _, key := range keys {
+		if _, exists := existing[key]; !exists {
+			toReserve = append(toReserve, key)
+		}
+	}
+
+	for key := range existing {
+		if !slices.Contains(keys, key) {
+			toRelease = append(toRelease, key)
+		}
+	}
+
+	sort.Strings(toRelease)
+
+	return toReserve, toRelease
+}
+
+// customDiffInstanceIPPoolKeys plans the keys of a pool defined by its size, an IP released outside of terraform is reserved again
+func customDiffInstanceIPPoolKeys(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	size, isSet, isKnown := ipPoolSize(diff.GetRawConfig())
+	if !isSet {
+		return nil
+	}
+
+	if !isKnown {
+		return diff.SetNewComputed("keys")
+	}
+
+	keys := ipPoolSizeKeys(size)
+	sortedKeys := slices.Sorted(slices.Values(keys))
+
+	stateKeys := types.ExpandStrings(diff.Get("keys").(*schema.Set).List())
+	sort.Strings(stateKeys)
+
+	if slices.Equal(stateKeys, sortedKeys) {
+		return nil
+	}
+
+	return diff.SetNew("keys", keys)
+}
+
+// listIPPoolIPs returns the IPs of a pool by assignment key
+func listIPPoolIPs(ctx context.Context, api *instanceSDK.API, zone scw.Zone, poolID string) (map[string]*instanceSDK.IP, error) {
+	res, err := api.ListIPs(&instanceSDK.ListIPsRequest{
+		Zone: zone,
+		Tags: []string{ipPoolTagPrefix + poolID},
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(res.IPs, func(i, j int) bool {
+		return res.IPs[i].ID < res.IPs[j].ID
+	})
+
+	ips := make(map[string]*instanceSDK.IP, len(res.IPs))
+
+	for _, ip := range res.IPs {
+		key, hasKey := ipPoolKey(ip)
+		if !hasKey {
+			continue
+		}
+
+		if _, exists := ips[key]; !exists {
+			ips[key] = ip
+		}
+	}
+
+	return ips, nil
+}
+
+func flattenIPAddressAndPrefix(ip *instanceSDK.IP) (string, string) {
+	address := ip.Address.String()
+
+	prefix := ip.Prefix.String()
+	if prefix == types.NetIPNil {
+		ipnet := scw.IPNet{}
+		_ = (&ipnet).UnmarshalJSON([]byte("\"" + ip.Address.String() + "\""))
+		prefix = ipnet.String()
+	}
+
+	if address == types.NetIPNil {
+		address = ip.Prefix.IP.String()
+	}
+
+	return address, prefix
+}
+
+func reserveIPPoolIPs(ctx context.Context, api *instanceSDK.API, zone scw.Zone, d *schema.ResourceData, poolID string, keys []string) error {
+	for _, key := range keys {
+		_, err := api.CreateIP(&instanceSDK.CreateIPRequest{
+			Zone:    zone,
+			Project: types.ExpandStringPtr(d.Get("project_id")),
+			Type:    instanceSDK.IPType(d.Get("type").(string)),
+			Tags:    ipPoolTags(poolID, key, types.ExpandStrings(d.Get("tags"))),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to reserve IP for key %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func releaseIPPoolIPs(ctx context.Context, api *instanceSDK.API, zone scw.Zone, ips map[string]*instanceSDK.IP, keys []string) error {
+	for _, key := range keys {
+		err := api.DeleteIP(&instanceSDK.DeleteIPRequest{
+			IP:   ips[key].ID,
+			Zone: zone,
+		}, scw.WithContext(ctx))
+		// We check for 403 because instance API returns 403 for a deleted IP
+		if err != nil && !httperrors.Is404(err) && !httperrors.Is403(err) {
+			return fmt.Errorf("failed to release IP for key %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func ResourceInstanceIPPoolCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, err := newAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	poolID := uuid.New().String()
+
+	err = identity.SetZonalIdentity(d, zone, poolID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = reserveIPPoolIPs(ctx, instanceAPI, zone, d, poolID, expandIPPoolKeys(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceInstanceIPPoolRead(ctx, d, m)
+}
+
+func ResourceInstanceIPPoolRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, poolID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ips, err := listIPPoolIPs(ctx, instanceAPI, zone, poolID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = identity.SetZonalIdentity(d, zone, poolID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	keys := make([]string, 0, len(ips))
+	for key := range ips {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	addresses := make(map[string]any, len(ips))
+	ipIDs := make(map[string]any, len(ips))
+	flattenedIPs := make([]map[string]any, 0, len(ips))
+
+	for _, key := range keys {
+		ip := ips[key]
+		address, prefix := flattenIPAddressAndPrefix(ip)
+
+		serverID := ""
+		if ip.Server != nil {
+			serverID = zonal.NewIDString(ip.Zone, ip.Server.ID)
+		}
+
+		addresses[key] = address
+		ipIDs[key] = zonal.NewIDString(ip.Zone, ip.ID)
+		flattenedIPs = append(flattenedIPs, map[string]any{
+			"key":       key,
+			"id":        zonal.NewIDString(ip.Zone, ip.ID),
+			"address":   address,
+			"prefix":    prefix,
+			"server_id": serverID,
+		})
+
+		_ = d.Set("type", ip.Type.String())
+		_ = d.Set("organization_id", ip.Organization)
+		_ = d.Set("project_id", ip.Project)
+
+		_ = d.Set("tags", ipPoolUserTags(ip.Tags))
+	}
+
+	_ = d.Set("zone", zone)
+	_ = d.Set("keys", keys)
+	_ = d.Set("addresses", addresses)
+	_ = d.Set("ip_ids", ipIDs)
+	_ = d.Set("ips", flattenedIPs)
+
+	return nil
+}
+
+func ResourceInstanceIPPoolUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, poolID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ips, err := listIPPoolIPs(ctx, instanceAPI, zone, poolID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	toReserve, toRelease := ipPoolChanges(ips, expandIPPoolKeys(d))
+
+	err = releaseIPPoolIPs(ctx, instanceAPI, zone, ips, toRelease)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("tags") {
+		for key, ip := range ips {
+			if slices.Contains(toRelease, key) {
+				continue
+			}
+
+			_, err = instanceAPI.UpdateIP(&instanceSDK.UpdateIPRequest{
+				IP:   ip.ID,
+				Zone: zone,
+				Tags: new(ipPoolTags(poolID, key, types.ExpandStrings(d.Get("tags")))),
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	err = reserveIPPoolIPs(ctx, instanceAPI, zone, d, poolID, toReserve)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceInstanceIPPoolRead(ctx, d, m)
+}
+
+func ResourceInstanceIPPoolDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, poolID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ips, err := listIPPoolIPs(ctx, instanceAPI, zone, poolID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, toRelease := ipPoolChanges(ips, nil)
+
+	err = releaseIPPoolIPs(ctx, instanceAPI, zone, ips, toRelease)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
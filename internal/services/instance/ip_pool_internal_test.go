@@ -0,0 +1,47 @@
+package instance
+
+import (
+	"testing"
+
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPPoolChanges(t *testing.T) {
+	existing := map[string]*instanceSDK.IP{
+		"web-1": {ID: "1", Tags: ipPoolTags("pool", "web-1", nil)},
+		"web-2": {ID: "2", Tags: ipPoolTags("pool", "web-2", nil)},
+		"web-3": {ID: "3", Tags: ipPoolTags("pool", "web-3", nil)},
+	}
+
+	// Removing a key only releases its IP, the other keys keep theirs
+	toReserve, toRelease := ipPoolChanges(existing, []string{"web-1", "web-3", "web-4"})
+	assert.Equal(t, []string{"web-4"}, toReserve)
+	assert.Equal(t, []string{"web-2"}, toRelease)
+
+	toReserve, toRelease = ipPoolChanges(existing, nil)
+	assert.Empty(t, toReserve)
+	assert.Equal(t, []string{"web-1", "web-2", "web-3"}, toRelease)
+}
+
+func TestIPPoolTags(t *testing.T) {
+	ip := &instanceSDK.IP{Tags: ipPoolTags("pool", "egress", []string{"team=infra"})}
+
+	key, hasKey := ipPoolKey(ip)
+	assert.True(t, hasKey)
+	assert.Equal(t, "egress", key)
+	assert.Equal(t, []string{"team=infra"}, ipPoolUserTags(ip.Tags))
+
+	_, hasKey = ipPoolKey(&instanceSDK.IP{Tags: []string{"team=infra"}})
+	assert.False(t, hasKey)
+}
+
+func TestIPPoolSizeKeys(t *testing.T) {
+	assert.Empty(t, ipPoolSizeKeys(0))
+	assert.Equal(t, []string{"0", "1", "2"}, ipPoolSizeKeys(3))
+}
+
+func TestIPPoolSchema(t *testing.T) {
+	require.NoError(t, ResourceIPPool().InternalValidate(nil, true))
+}
@@ -0,0 +1,367 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func ResourcePlacementGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceInstancePlacementGroupMembershipCreate,
+		ReadContext:   ResourceInstancePlacementGroupMembershipRead,
+		UpdateContext: ResourceInstancePlacementGroupMembershipUpdate,
+		DeleteContext: ResourceInstancePlacementGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: identity.ImportStatePassthroughZonal(),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultInstancePlacementGroupTimeout),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    placementGroupMembershipSchema,
+		Identity:      identity.DefaultZonal(),
+	}
+}
+
+func placementGroupMembershipSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"placement_group_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			// The ID of the membership is the ID of the placement group, only one membership is allowed per placement group.
+			ForceNew:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			DiffSuppressFunc: dsf.Locality,
+			Description:      "The placement group to set the servers of",
+		},
+		"server_ids": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			},
+			Set: func(v any) int {
+				return schema.HashString(locality.ExpandID(v))
+			},
+			Description: "The full list of servers of the placement group",
+		},
+		"members": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The servers of the placement group",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"server_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the server",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the server",
+					},
+					"policy_respected": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "True when the placement group policy is respected for this server",
+					},
+				},
+			},
+		},
+		"policy_respected": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "True when the placement group policy is respected for all the servers",
+		},
+		"zone": zonal.ComputedSchema(),
+	}
+}
+
+func expandPlacementGroupMembershipServerIDs(raw any) []string {
+	serverIDs := []string{}
+
+	if raw == nil {
+		return serverIDs
+	}
+
+	for _, serverID := range raw.(*schema.Set).List() {
+		serverIDs = append(serverIDs, locality.ExpandID(serverID))
+	}
+
+	sort.Strings(serverIDs)
+
+	return serverIDs
+}
+
+func flattenPlacementGroupMembers(zone scw.Zone, servers []*instance.PlacementGroupServer) ([]string, []map[string]any) {
+	serverIDs := make([]string, 0, len(servers))
+	members := make([]map[string]any, 0, len(servers))
+
+	for _, server := range servers {
+		serverID := zonal.NewIDString(zone, server.ID)
+		serverIDs = append(serverIDs, serverID)
+		members = append(members, map[string]any{
+			"server_id":        serverID,
+			"name":             server.Name,
+			"policy_respected": server.PolicyRespected,
+		})
+	}
+
+	return serverIDs, members
+}
+
+// placementGroupPolicyViolations returns the names of the servers for which the placement group policy is not respected
+func placementGroupPolicyViolations(servers []*instance.PlacementGroupServer) []string {
+	violations := []string(nil)
+
+	for _, server := range servers {
+		if !server.PolicyRespected {
+			violations = append(violations, server.Name)
+		}
+	}
+
+	sort.Strings(violations)
+
+	return violations
+}
+
+// getPlacementGroupServerIDs returns the IDs of the servers currently in a placement group
+func getPlacementGroupServerIDs(ctx context.Context, api *instance.API, zone scw.Zone, placementGroupID string) ([]string, error) {
+	res, err := api.GetPlacementGroupServers(&instance.GetPlacementGroupServersRequest{
+		Zone:             zone,
+		PlacementGroupID: placementGroupID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	serverIDs := make([]string, 0, len(res.Servers))
+	for _, server := range res.Servers {
+		serverIDs = append(serverIDs, server.ID)
+	}
+
+	return serverIDs, nil
+}
+
+// checkPlacementGroupServersStopped returns an error naming the servers joining or leaving a placement group that are not stopped,
+// a server must be stopped to change its placement group.
+func checkPlacementGroupServersStopped(ctx context.Context, api *instance.API, zone scw.Zone, placementGroupID string, serverIDs []string, previousServerIDs []string) error {
+	running := []string(nil)
+
+	for _, serverID := range append(slices.Clone(serverIDs), previousServerIDs...) {
+		if slices.Contains(serverIDs, serverID) && slices.Contains(previousServerIDs, serverID) {
+			continue
+		}
+
+		res, err := api.GetServer(&instance.GetServerRequest{
+			Zone:     zone,
+			ServerID: serverID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+
+		if res.Server.State != instance.ServerStateStopped {
+			running = append(running, res.Server.Name)
+		}
+	}
+
+	if len(running) > 0 {
+		sort.Strings(running)
+
+		return fmt.Errorf("servers %s must be stopped to join or leave placement group %s", strings.Join(running, ", "), placementGroupID)
+	}
+
+	return nil
+}
+
+// setPlacementGroupServers sets the full server list of a placement group. When the policy of the placement group is enforced
+// and cannot be respected, the previous server list is restored and an error is returned.
+func setPlacementGroupServers(ctx context.Context, api *instance.API, zone scw.Zone, placementGroupID string, serverIDs []string, previousServerIDs []string) error {
+	err := checkPlacementGroupServersStopped(ctx, api, zone, placementGroupID, serverIDs, previousServerIDs)
+	if err != nil {
+		return err
+	}
+
+	res, err := api.SetPlacementGroupServers(&instance.SetPlacementGroupServersRequest{
+		Zone:             zone,
+		PlacementGroupID: placementGroupID,
+		Servers:          serverIDs,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	violations := placementGroupPolicyViolations(res.Servers)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	placementGroup, err := api.GetPlacementGroup(&instance.GetPlacementGroupRequest{
+		Zone:             zone,
+		PlacementGroupID: placementGroupID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	if placementGroup.PlacementGroup.PolicyMode != instance.PlacementGroupPolicyModeEnforced {
+		return nil
+	}
+
+	_, err = api.SetPlacementGroupServers(&instance.SetPlacementGroupServersRequest{
+		Zone:             zone,
+		PlacementGroupID: placementGroupID,
+		Servers:          previousServerIDs,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("enforced policy of placement group %s is not respected for servers %s and previous servers could not be restored: %w",
+			placementGroupID, strings.Join(violations, ", "), err)
+	}
+
+	return fmt.Errorf("enforced policy of placement group %s is not respected for servers %s, previous servers have been restored",
+		placementGroupID, strings.Join(violations, ", "))
+}
+
+func ResourceInstancePlacementGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, err := newAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	placementGroupID := zonal.ExpandID(d.Get("placement_group_id"))
+	if placementGroupID.Zone != "" {
+		zone = placementGroupID.Zone
+	}
+
+	previousServerIDs, err := getPlacementGroupServerIDs(ctx, instanceAPI, zone, placementGroupID.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = setPlacementGroupServers(ctx, instanceAPI, zone, placementGroupID.ID, expandPlacementGroupMembershipServerIDs(d.Get("server_ids")), previousServerIDs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = identity.SetZonalIdentity(d, zone, placementGroupID.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceInstancePlacementGroupMembershipRead(ctx, d, m)
+}
+
+func ResourceInstancePlacementGroupMembershipRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, ID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	placementGroup, err := instanceAPI.GetPlacementGroup(&instance.GetPlacementGroupRequest{
+		Zone:             zone,
+		PlacementGroupID: ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	res, err := instanceAPI.GetPlacementGroupServers(&instance.GetPlacementGroupServersRequest{
+		Zone:             zone,
+		PlacementGroupID: ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = identity.SetZonalIdentity(d, zone, ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverIDs, members := flattenPlacementGroupMembers(zone, res.Servers)
+
+	_ = d.Set("placement_group_id", zonal.NewIDString(zone, ID))
+	_ = d.Set("server_ids", serverIDs)
+	_ = d.Set("members", members)
+	_ = d.Set("policy_respected", placementGroup.PlacementGroup.PolicyRespected)
+	_ = d.Set("zone", zone)
+
+	return nil
+}
+
+func ResourceInstancePlacementGroupMembershipUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, ID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("server_ids") {
+		// Servers added out of band since the last refresh are restored along with the others on rollback
+		previousServerIDs, err := getPlacementGroupServerIDs(ctx, instanceAPI, zone, ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = setPlacementGroupServers(ctx, instanceAPI, zone, ID, expandPlacementGroupMembershipServerIDs(d.Get("server_ids")), previousServerIDs)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ResourceInstancePlacementGroupMembershipRead(ctx, d, m)
+}
+
+func ResourceInstancePlacementGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, ID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverIDs, err := getPlacementGroupServerIDs(ctx, instanceAPI, zone, ID)
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	err = checkPlacementGroupServersStopped(ctx, instanceAPI, zone, ID, []string{}, serverIDs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = instanceAPI.SetPlacementGroupServers(&instance.SetPlacementGroupServersRequest{
+		Zone:             zone,
+		PlacementGroupID: ID,
+		Servers:          []string{},
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlacementGroupMembershipServerIDs(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, placementGroupMembershipSchema(), map[string]any{
+		"placement_group_id": "fr-par-1/11111111-1111-1111-1111-111111111111",
+		"server_ids": []any{
+			"fr-par-1/33333333-3333-3333-3333-333333333333",
+			"22222222-2222-2222-2222-222222222222",
+			"33333333-3333-3333-3333-333333333333",
+		},
+	})
+
+	// Zoned and plain IDs of the same server are the same member
+	assert.Equal(t, []string{
+		"22222222-2222-2222-2222-222222222222",
+		"33333333-3333-3333-3333-333333333333",
+	}, expandPlacementGroupMembershipServerIDs(d.Get("server_ids")))
+}
+
+func TestPlacementGroupPolicyViolations(t *testing.T) {
+	servers := []*instance.PlacementGroupServer{
+		{ID: "1", Name: "web-b", PolicyRespected: false},
+		{ID: "2", Name: "web-a", PolicyRespected: false},
+		{ID: "3", Name: "db", PolicyRespected: true},
+	}
+
+	assert.Equal(t, []string{"web-a", "web-b"}, placementGroupPolicyViolations(servers))
+	assert.Empty(t, placementGroupPolicyViolations(servers[2:]))
+
+	serverIDs, members := flattenPlacementGroupMembers(scw.ZoneFrPar1, servers[2:])
+	assert.Equal(t, []string{"fr-par-1/3"}, serverIDs)
+	assert.Equal(t, []map[string]any{{"server_id": "fr-par-1/3", "name": "db", "policy_respected": true}}, members)
+}
+
+func TestPlacementGroupMembershipSchema(t *testing.T) {
+	require.NoError(t, ResourcePlacementGroupMembership().InternalValidate(nil, true))
+}
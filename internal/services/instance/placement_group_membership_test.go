@@ -0,0 +1,113 @@
+package instance_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlacementGroupMembershipEnforcedPolicy(t *testing.T) {
+	ctx := t.Context()
+	m := acctest.NewMockedMeta(t)
+	api := instanceSDK.NewAPI(m.ScwClient())
+	r := instance.ResourcePlacementGroupMembership()
+
+	group, err := api.CreatePlacementGroup(&instanceSDK.CreatePlacementGroupRequest{
+		Zone:       scw.ZoneFrPar1,
+		PolicyMode: instanceSDK.PlacementGroupPolicyModeEnforced,
+		PolicyType: instanceSDK.PlacementGroupPolicyTypeMaxAvailability,
+	})
+	require.NoError(t, err)
+
+	placementGroupID := zonal.NewIDString(scw.ZoneFrPar1, group.PlacementGroup.ID)
+	serverIDs := []string(nil)
+
+	for _, name := range []string{"srv-1", "srv-2", "srv-3", "srv-4"} {
+		created, err := api.CreateServer(&instanceSDK.CreateServerRequest{Zone: scw.ZoneFrPar1, Name: name, CommercialType: "DEV1-S"})
+		require.NoError(t, err)
+
+		serverIDs = append(serverIDs, created.Server.ID)
+	}
+
+	// apply plans the membership of the servers from prior and applies it
+	apply := func(prior *terraform.InstanceState, serverIDs ...string) (*terraform.InstanceState, diag.Diagnostics) {
+		values := []cty.Value(nil)
+		for _, serverID := range serverIDs {
+			values = append(values, cty.StringVal(serverID))
+		}
+
+		config := cty.ObjectVal(map[string]cty.Value{
+			"placement_group_id": cty.StringVal(placementGroupID),
+			"server_ids":         cty.SetVal(values),
+		})
+
+		if prior == nil {
+			prior = &terraform.InstanceState{RawState: cty.NullVal(r.CoreConfigSchema().ImpliedType())}
+		} else {
+			var err error
+
+			prior.RawState, err = prior.AttrsAsObjectValue(r.CoreConfigSchema().ImpliedType())
+			require.NoError(t, err)
+		}
+
+		diff, err := r.Diff(ctx, prior, terraform.NewResourceConfigShimmed(config, r.CoreConfigSchema()), m)
+		require.NoError(t, err)
+
+		diff.RawConfig = config
+
+		return r.Apply(ctx, prior, diff, m)
+	}
+
+	members := func() []string {
+		res, err := api.GetPlacementGroupServers(&instanceSDK.GetPlacementGroupServersRequest{Zone: scw.ZoneFrPar1, PlacementGroupID: group.PlacementGroup.ID})
+		require.NoError(t, err)
+
+		ids := []string(nil)
+		for _, server := range res.Servers {
+			ids = append(ids, server.ID)
+		}
+
+		return ids
+	}
+
+	state, diags := apply(nil, serverIDs[0])
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, serverIDs[:1], members())
+
+	// A server joins the placement group out of band
+	_, err = api.SetPlacementGroupServers(&instanceSDK.SetPlacementGroupServersRequest{
+		Zone:             scw.ZoneFrPar1,
+		PlacementGroupID: group.PlacementGroup.ID,
+		Servers:          serverIDs[:2],
+	})
+	require.NoError(t, err)
+
+	// The policy cannot be respected for three servers, the live servers are restored
+	_, diags = apply(state.DeepCopy(), serverIDs[0], serverIDs[2], serverIDs[3])
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "previous servers have been restored")
+	assert.Equal(t, serverIDs[:2], members())
+
+	// Running servers cannot join the placement group
+	_, err = api.ServerAction(&instanceSDK.ServerActionRequest{Zone: scw.ZoneFrPar1, ServerID: serverIDs[2], Action: instanceSDK.ServerActionPoweron})
+	require.NoError(t, err)
+
+	_, diags = apply(state.DeepCopy(), serverIDs[0], serverIDs[2])
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "servers srv-3 must be stopped")
+	assert.Equal(t, serverIDs[:2], members())
+
+	d := r.Data(state)
+	diags = r.DeleteContext(ctx, d, m)
+	require.False(t, diags.HasError(), diags)
+	assert.Empty(t, members())
+}
@@ -0,0 +1,274 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func ResourceSecurityGroupRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceInstanceSecurityGroupRuleCreate,
+		ReadContext:   ResourceInstanceSecurityGroupRuleRead,
+		UpdateContext: ResourceInstanceSecurityGroupRuleUpdate,
+		DeleteContext: ResourceInstanceSecurityGroupRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: identity.ImportStatePassthrough("zone", "security_group_id", "rule_id"),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultInstanceSecurityGroupRuleTimeout),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    securityGroupRuleResourceSchema,
+		Identity:      securityGroupRuleIdentity(),
+		CustomizeDiff: cdf.LocalityCheck("security_group_id"),
+	}
+}
+
+// securityGroupRuleIdentity identifies a rule by its zone, its security group and its own ID, the parts of the resource ID
+func securityGroupRuleIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"zone":              identity.DefaultZoneAttribute(),
+		"security_group_id": {Type: schema.TypeString, Description: "The ID of the security group of the rule (UUID format)", RequiredForImport: true},
+		"rule_id":           {Type: schema.TypeString, Description: "The ID of the rule (UUID format)", RequiredForImport: true},
+	})
+}
+
+// setSecurityGroupRuleIdentity sets the identity of a rule and its {zone}/{security_group_id}/{rule_id} ID
+func setSecurityGroupRuleIdentity(d *schema.ResourceData, zone scw.Zone, securityGroupID string, ruleID string) error {
+	return identity.SetMultiPartIdentity(d, map[string]string{
+		"zone":              zone.String(),
+		"security_group_id": securityGroupID,
+		"rule_id":           ruleID,
+	}, "zone", "security_group_id", "rule_id")
+}
+
+// securityGroupRuleResourceSchema reuses the rule block of the security group,
+// the fields that cannot be patched on an existing rule force a new one.
+func securityGroupRuleResourceSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"security_group_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			Description:      "The security group to add the rule to",
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+		},
+		"direction": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			Description:      "Direction of the traffic matched by the rule (inbound or outbound)",
+			ValidateDiagFunc: verify.ValidateEnum[instanceSDK.SecurityGroupRuleDirection](),
+		},
+		"position": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Position of the rule in the security group",
+		},
+		"zone": zonal.Schema(),
+	}
+
+	for key, ruleSchema := range securityGroupRuleSchema().Schema {
+		if ruleSchema.Deprecated != "" {
+			continue
+		}
+
+		switch key {
+		case "protocol", "port", "port_range":
+			ruleSchema.ForceNew = true
+		}
+
+		s[key] = ruleSchema
+	}
+
+	return s
+}
+
+// newAPIWithZoneAndSecurityGroupRuleID returns an instance API with the zone, security group ID and rule ID extracted from a {zone}/{security_group_id}/{rule_id} ID
+func newAPIWithZoneAndSecurityGroupRuleID(m any, id string) (*instanceSDK.API, scw.Zone, string, string, error) {
+	rawZone, securityGroupID, ruleID, err := locality.ParseLocalizedNestedID(id)
+	if err != nil {
+		return nil, "", "", "", err
+	}
+
+	zone, err := scw.ParseZone(rawZone)
+	if err != nil {
+		return nil, "", "", "", err
+	}
+
+	return instanceSDK.NewAPI(meta.ExtractScwClient(m)), zone, securityGroupID, ruleID, nil
+}
+
+// expandSecurityGroupRuleResource builds the API rule described by the resource with the security group rule expander
+func expandSecurityGroupRuleResource(d *schema.ResourceData) (*instanceSDK.SecurityGroupRule, error) {
+	rule, err := securityGroupRuleExpand(map[string]any{
+		"action":     d.Get("action"),
+		"protocol":   d.Get("protocol"),
+		"port":       d.Get("port"),
+		"port_range": d.Get("port_range"),
+		"ip":         "",
+		"ip_range":   d.Get("ip_range"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Direction = instanceSDK.SecurityGroupRuleDirection(d.Get("direction").(string))
+
+	return rule, nil
+}
+
+func ResourceInstanceSecurityGroupRuleCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, err := newAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	securityGroupID := locality.ExpandID(d.Get("security_group_id").(string))
+
+	rule, err := expandSecurityGroupRuleResource(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := instanceAPI.CreateSecurityGroupRule(&instanceSDK.CreateSecurityGroupRuleRequest{
+		Zone:            zone,
+		SecurityGroupID: securityGroupID,
+		Protocol:        rule.Protocol,
+		Direction:       rule.Direction,
+		Action:          rule.Action,
+		IPRange:         rule.IPRange,
+		DestPortFrom:    rule.DestPortFrom,
+		DestPortTo:      rule.DestPortTo,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = setSecurityGroupRuleIdentity(d, zone, securityGroupID, res.Rule.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceInstanceSecurityGroupRuleRead(ctx, d, m)
+}
+
+func ResourceInstanceSecurityGroupRuleRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, securityGroupID, ruleID, err := newAPIWithZoneAndSecurityGroupRuleID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := instanceAPI.GetSecurityGroupRule(&instanceSDK.GetSecurityGroupRuleRequest{
+		Zone:                zone,
+		SecurityGroupID:     securityGroupID,
+		SecurityGroupRuleID: ruleID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	err = setSecurityGroupRuleIdentity(d, zone, securityGroupID, res.Rule.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("security_group_id", zonal.NewIDString(zone, securityGroupID))
+	_ = d.Set("direction", res.Rule.Direction.String())
+	_ = d.Set("position", int(res.Rule.Position))
+	_ = d.Set("zone", zone.String())
+
+	// Only the rule owned by this resource is compared, other rules of the security group are ignored.
+	// As with the inline rules, we keep the state rule if it matches the api one to avoid a diff between port and port_range.
+	stateRule, err := expandSecurityGroupRuleResource(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if ok, _ := SecurityGroupRuleEquals(stateRule, res.Rule); !ok {
+		flatRule, err := securityGroupRuleFlatten(res.Rule)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_ = d.Set("port", 0)
+
+		if res.Rule.DestPortFrom == nil {
+			flatRule["port_range"] = ""
+		}
+
+		for key, value := range flatRule {
+			_ = d.Set(key, value)
+		}
+	}
+
+	return nil
+}
+
+func ResourceInstanceSecurityGroupRuleUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, securityGroupID, ruleID, err := newAPIWithZoneAndSecurityGroupRuleID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rule, err := expandSecurityGroupRuleResource(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &instanceSDK.UpdateSecurityGroupRuleRequest{
+		Zone:                zone,
+		SecurityGroupID:     securityGroupID,
+		SecurityGroupRuleID: ruleID,
+	}
+
+	if d.HasChange("action") {
+		req.Action = rule.Action
+	}
+
+	if d.HasChange("ip_range") {
+		req.IPRange = &rule.IPRange
+	}
+
+	_, err = instanceAPI.UpdateSecurityGroupRule(req, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceInstanceSecurityGroupRuleRead(ctx, d, m)
+}
+
+func ResourceInstanceSecurityGroupRuleDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, securityGroupID, ruleID, err := newAPIWithZoneAndSecurityGroupRuleID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = instanceAPI.DeleteSecurityGroupRule(&instanceSDK.DeleteSecurityGroupRuleRequest{
+		Zone:                zone,
+		SecurityGroupID:     securityGroupID,
+		SecurityGroupRuleID: ruleID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
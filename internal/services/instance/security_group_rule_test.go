@@ -0,0 +1,137 @@
+package instance_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityGroupRuleSchema(t *testing.T) {
+	resource := instance.ResourceSecurityGroupRule()
+	require.NoError(t, resource.InternalValidate(nil, true))
+
+	schemaMap := resource.SchemaMap()
+	assert.NotContains(t, schemaMap, "ip", "deprecated rule fields must not be exposed")
+
+	for _, key := range []string{"security_group_id", "direction", "protocol", "port", "port_range"} {
+		assert.True(t, schemaMap[key].ForceNew, "%s must force a new rule", key)
+	}
+
+	for _, key := range []string{"action", "ip_range"} {
+		assert.False(t, schemaMap[key].ForceNew, "%s must be updated in place", key)
+	}
+}
+
+func TestSecurityGroupRuleLifecycle(t *testing.T) {
+	ctx := t.Context()
+	m := acctest.NewMockedMeta(t)
+	api := instanceSDK.NewAPI(m.ScwClient())
+	r := instance.ResourceSecurityGroupRule()
+
+	group, err := api.CreateSecurityGroup(&instanceSDK.CreateSecurityGroupRequest{
+		Zone: scw.ZoneFrPar1,
+		Name: "test-security-group-rule",
+	})
+	require.NoError(t, err)
+
+	d := r.Data(nil)
+	_ = d.Set("security_group_id", "fr-par-1/"+group.SecurityGroup.ID)
+	_ = d.Set("direction", "inbound")
+	_ = d.Set("action", "accept")
+	_ = d.Set("protocol", "TCP")
+	_ = d.Set("port", 22)
+	_ = d.Set("ip_range", "10.0.0.0/8")
+
+	diags := r.CreateContext(ctx, d, m)
+	require.False(t, diags.HasError(), diags)
+
+	rules, err := api.ListSecurityGroupRules(&instanceSDK.ListSecurityGroupRulesRequest{
+		Zone:            scw.ZoneFrPar1,
+		SecurityGroupID: group.SecurityGroup.ID,
+	}, scw.WithAllPages())
+	require.NoError(t, err)
+	require.Len(t, rules.Rules, 1)
+
+	ruleID := rules.Rules[0].ID
+
+	assert.Equal(t, "fr-par-1/"+group.SecurityGroup.ID+"/"+ruleID, d.Id())
+	assert.Equal(t, 1, d.Get("position"))
+	assert.Equal(t, 22, d.Get("port"))
+
+	identity, err := d.Identity()
+	require.NoError(t, err)
+	assert.Equal(t, "fr-par-1", identity.Get("zone"))
+	assert.Equal(t, group.SecurityGroup.ID, identity.Get("security_group_id"))
+	assert.Equal(t, ruleID, identity.Get("rule_id"))
+
+	// A rule imported from its identity gets the same ID and is read with its identity
+	imported := r.Data(&terraform.InstanceState{})
+	importedIdentity, err := imported.Identity()
+	require.NoError(t, err)
+	require.NoError(t, importedIdentity.Set("zone", "fr-par-1"))
+	require.NoError(t, importedIdentity.Set("security_group_id", group.SecurityGroup.ID))
+	require.NoError(t, importedIdentity.Set("rule_id", ruleID))
+
+	results, err := r.Importer.StateContext(ctx, imported, m)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, d.Id(), results[0].Id())
+
+	// State written by a version identifying rules by zone and id only
+	legacy := r.Data(&terraform.InstanceState{ID: d.Id()})
+
+	diags = r.ReadContext(ctx, legacy, m)
+	require.False(t, diags.HasError(), diags)
+
+	legacyIdentity, err := legacy.Identity()
+	require.NoError(t, err)
+	assert.Equal(t, group.SecurityGroup.ID, legacyIdentity.Get("security_group_id"))
+	assert.Equal(t, ruleID, legacyIdentity.Get("rule_id"))
+	assert.Equal(t, "inbound", legacy.Get("direction"))
+	assert.Equal(t, "10.0.0.0/8", legacy.Get("ip_range"))
+
+	// The action is updated in place
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"security_group_id": "fr-par-1/" + group.SecurityGroup.ID,
+		"direction":         "inbound",
+		"action":            "drop",
+		"protocol":          "TCP",
+		"port":              22,
+		"ip_range":          "10.0.0.0/8",
+	})
+
+	state := d.State()
+	state.RawState, err = state.AttrsAsObjectValue(r.CoreConfigSchema().ImpliedType())
+	require.NoError(t, err)
+
+	diff, err := r.Diff(ctx, state, config, m)
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+
+	state, diags = r.Apply(ctx, state, diff, m)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, d.Id(), state.ID)
+
+	rule, err := api.GetSecurityGroupRule(&instanceSDK.GetSecurityGroupRuleRequest{
+		Zone:                scw.ZoneFrPar1,
+		SecurityGroupID:     group.SecurityGroup.ID,
+		SecurityGroupRuleID: ruleID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, instanceSDK.SecurityGroupRuleActionDrop, rule.Rule.Action)
+	assert.Equal(t, instanceSDK.SecurityGroupRuleProtocolTCP, rule.Rule.Protocol)
+
+	diags = r.DeleteContext(ctx, d, m)
+	require.False(t, diags.HasError(), diags)
+
+	// A rule deleted outside of terraform is removed from the state
+	diags = r.ReadContext(ctx, d, m)
+	require.False(t, diags.HasError(), diags)
+	assert.Empty(t, d.Id())
+}
@@ -3,15 +3,16 @@ package instance
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -28,6 +29,7 @@ import (
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
@@ -36,10 +38,14 @@ import (
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/instancehelpers"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/ipam"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
 )
 
+// ResourceServer is kept on SDKv2 rather than the plugin framework: the Optional+Computed root_volume block is used
+// both as a block and through root_volume.0.* references when omitted, which neither framework blocks (never computed)
+// nor nested attributes (no block syntax) can express without breaking existing configurations.
 func ResourceServer() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: ResourceInstanceServerCreate,
@@ -58,6 +64,7 @@ func ResourceServer() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    serverSchema,
+		Identity:      identity.DefaultZonal(),
 		CustomizeDiff: customdiff.All(
 			cdf.LocalityCheck(
 				"placement_group_id",
@@ -67,6 +74,14 @@ func ResourceServer() *schema.Resource {
 			customDiffInstanceServerType,
 			customDiffInstanceServerImage,
 			customDiffInstanceRootVolumeSize,
+			customDiffInstanceServerUpdateImpact,
+			customDiffInstanceServerReplacedServer,
+			customDiffInstanceServerPublicIPs,
+			customDiffCloudInitConfig,
+			customDiffInstanceServerCloudInitConfig,
+			customdiff.ComputedIf("user_data_wo_keys", func(_ context.Context, diff *schema.ResourceDiff, _ any) bool {
+				return diff.HasChange("user_data_wo_version")
+			}),
 		),
 	}
 }
@@ -104,6 +119,43 @@ func serverSchema() map[string]*schema.Schema {
 			Default:     false,
 			Description: "Delete and re-create server if type change",
 		},
+		"replacement_strategy": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "How the resources of the server are moved to its replacement when the server is re-created",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"mode": {
+						Type:     schema.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							InstanceServerReplacementHandover,
+							InstanceServerReplacementStopFirst,
+						}, false),
+						Description: "Move the IPs, private NICs and additional volumes of the replaced server to the new one once created (handover), optionally stopping the replaced server first (stop_first)",
+					},
+				},
+			},
+		},
+		"replaced_server_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the server replaced by this one, the replacement strategy only hands over the resources attached to it",
+		},
+		"update_impact": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The impact of the planned update on the server (online or server_stop), cleared once the update is applied",
+		},
+		"servers_to_stop": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The servers stopped by the planned update, cleared once the update is applied",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
 		"tags": {
 			Type: schema.TypeList,
 			Elem: &schema.Schema{
@@ -154,8 +206,7 @@ func serverSchema() map[string]*schema.Schema {
 						Type:        schema.TypeString,
 						Optional:    true,
 						Computed:    true,
-						ForceNew:    true,
-						Description: "Volume type of the root volume",
+						Description: "Volume type of the root volume, a local root volume can be migrated to sbs_volume",
 						ValidateDiagFunc: func(i any, path cty.Path) diag.Diagnostics {
 							diags := verify.ValidateEnum[instanceSDK.VolumeVolumeType]()(i, path)
 							if i.(string) == "b_ssd" {
@@ -283,8 +334,9 @@ func serverSchema() map[string]*schema.Schema {
 			Optional:     true,
 			Computed:     true,
 			Description:  "The cloud init script associated with this server",
-			ValidateFunc: validation.StringLenBetween(0, 127998),
+			ValidateFunc: validation.StringLenBetween(0, cloudInitMaxSize),
 		},
+		"cloud_init_config": serverCloudInitConfigSchema(),
 		"user_data": {
 			Type:        schema.TypeMap,
 			Optional:    true,
@@ -297,6 +349,30 @@ func serverSchema() map[string]*schema.Schema {
 				return k == "user_data.ssh-host-fingerprints"
 			},
 		},
+		"user_data_wo": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        true,
+			WriteOnly:        true,
+			Description:      "The user data of the server in [write-only](https://developer.hashicorp.com/terraform/language/manage-sensitive-data/write-only) mode, as a JSON object of keys and values (e.g. `jsonencode({ secret = \"value\" })`). Keys must not also be set in `user_data`. `user_data_wo` will not be set in the Terraform state. To update the `user_data_wo`, you must also update the `user_data_wo_version`.",
+			ValidateDiagFunc: validateServerUserDataWO,
+			RequiredWith:     []string{"user_data_wo_version"},
+		},
+		"user_data_wo_version": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "The version of the [write-only](https://developer.hashicorp.com/terraform/language/manage-sensitive-data/write-only) user data. To update the `user_data_wo`, you must also update the `user_data_wo_version`.",
+			RequiredWith: []string{"user_data_wo"},
+		},
+		"user_data_wo_keys": {
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Description: "The user data keys set through `user_data_wo`, they are left out of `user_data`",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
 		"private_network": {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -312,7 +388,7 @@ func serverSchema() map[string]*schema.Schema {
 						Required:         true,
 						ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
 						Description:      "The Private Network ID",
-						DiffSuppressFunc: dsf.Locality,
+						DiffSuppressFunc: diffSuppressPrivateNetworkOrder,
 					},
 					// Computed
 					"mac_address": {
@@ -330,6 +406,17 @@ func serverSchema() map[string]*schema.Schema {
 						Computed:    true,
 						Description: "The ID of the NIC",
 					},
+					"ipam_ip_ids": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Computed: true,
+						Elem: &schema.Schema{
+							Type:             schema.TypeString,
+							ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+							DiffSuppressFunc: dsf.Locality,
+						},
+						Description: "IPAM IDs of the IPs to attach to the NIC",
+					},
 					"zone": zonal.Schema(),
 				},
 			},
@@ -412,7 +499,7 @@ func serverSchema() map[string]*schema.Schema {
 }
 
 //gocyclo:ignore
-func ResourceInstanceServerCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+func ResourceInstanceServerCreate(ctx context.Context, d *schema.ResourceData, m any) (diags diag.Diagnostics) {
 	api, zone, err := instancehelpers.InstanceAndBlockAPIWithZone(d, m)
 	if err != nil {
 		return diag.FromErr(err)
@@ -488,27 +575,25 @@ func ResourceInstanceServerCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	if imageUUID != "" && !scwvalidation.IsUUID(imageUUID) {
-		// Replace dashes with underscores ubuntu-focal -> ubuntu_focal
-		imageLabel := formatImageLabel(imageUUID)
+	imageUUID, err = expandServerImageID(m, zone, commercialType, imageUUID, req.Volumes["0"].VolumeType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if imageUUID != "" {
+		req.Image = new(imageUUID)
+	}
 
-		marketPlaceAPI := marketplace.NewAPI(meta.ExtractScwClient(m))
+	// Look for the resources still attached to the server being replaced, e.g. with create_before_destroy
+	var handover *serverHandover
 
-		image, err := marketPlaceAPI.GetLocalImageByLabel(&marketplace.GetLocalImageByLabelRequest{
-			CommercialType: commercialType,
-			Zone:           zone,
-			ImageLabel:     imageLabel,
-			Type:           volumeTypeToMarketplaceFilter(req.Volumes["0"].VolumeType),
-		})
+	if mode, ok := d.GetOk("replacement_strategy.0.mode"); ok {
+		handover, err = prepareServerHandover(ctx, d, m, api, zone, mode.(string) == InstanceServerReplacementStopFirst)
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("could not get image '%s': %w", zonal.NewID(zone, imageLabel), err))
+			return diag.FromErr(fmt.Errorf("failed to find the resources to hand over: %w", err))
 		}
 
-		imageUUID = image.ID
-	}
-
-	if imageUUID != "" {
-		req.Image = new(imageUUID)
+		handover.excludeFrom(req)
 	}
 
 	res, err := api.CreateServer(req, scw.WithContext(ctx))
@@ -516,18 +601,33 @@ func ResourceInstanceServerCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	d.SetId(zonal.NewID(zone, res.Server.ID).String())
+	err = identity.SetZonalIdentity(d, zone, res.Server.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	_, err = waitForServer(ctx, api.API, zone, res.Server.ID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return diag.FromErr(err)
+		return serverErrorWithDiagnostic(ctx, m, api.API, zone, res.Server.ID, err)
+	}
+
+	if handover != nil {
+		// Give the resources back to the replaced server if the new server cannot be fully created
+		defer func() {
+			if diags.HasError() {
+				diags = append(diags, handover.rollback(ctx, api, zone, res.Server.ID, d.Timeout(schema.TimeoutCreate))...)
+			}
+		}()
+
+		err = handover.handover(ctx, api, zone, res.Server.ID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	////
 	// Configure Volumes
 	////
-	var diags diag.Diagnostics
-
 	if iops, ok := d.GetOk("root_volume.0.sbs_iops"); ok {
 		updateDiags := ResourceInstanceServerUpdateRootVolumeIOPS(ctx, api, zone, res.Server.ID, types.ExpandUint32Ptr(iops))
 		if len(updateDiags) > 0 {
@@ -560,6 +660,22 @@ func ResourceInstanceServerCreate(ctx context.Context, d *schema.ResourceData, m
 		userDataRequests.UserData["cloud-init"] = bytes.NewBufferString(cloudInit.(string))
 	}
 
+	if cloudInitConfig, ok := d.GetOk("cloud_init_config"); ok {
+		cloudInit, err := renderCloudInitConfig(cloudInitConfig)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		userDataRequests.UserData["cloud-init"] = bytes.NewBufferString(cloudInit)
+	}
+
+	userDataWOKeys, err := addServerUserDataWO(d, userDataRequests.UserData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("user_data_wo_keys", userDataWOKeys)
+
 	if len(userDataRequests.UserData) > 0 {
 		_, err := waitForServer(ctx, api.API, zone, res.Server.ID, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
@@ -579,7 +695,7 @@ func ResourceInstanceServerCreate(ctx context.Context, d *schema.ResourceData, m
 
 	err = reachState(ctx, api, zone, res.Server.ID, targetState)
 	if err != nil {
-		return diag.FromErr(err)
+		return serverErrorWithDiagnostic(ctx, m, api.API, zone, res.Server.ID, err)
 	}
 
 	///
@@ -668,6 +784,11 @@ func ResourceInstanceServerRead(ctx context.Context, d *schema.ResourceData, m a
 		return diag.FromErr(err)
 	}
 
+	err = identity.SetZonalIdentity(d, zone, server.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	////
 	// Read Server
 	////
@@ -677,6 +798,9 @@ func ResourceInstanceServerRead(ctx context.Context, d *schema.ResourceData, m a
 	}
 
 	_ = d.Set("state", state)
+	// The update impact only describes the plan, it is not kept once the update is applied
+	_ = d.Set("update_impact", "")
+	_ = d.Set("servers_to_stop", []string{})
 	_ = d.Set("zone", string(zone))
 	_ = d.Set("name", server.Name)
 	_ = d.Set("boot_type", server.BootType)
@@ -784,6 +908,28 @@ func ResourceInstanceServerRead(ctx context.Context, d *schema.ResourceData, m a
 		userData[key] = string(userDataValue)
 	}
 
+	// The cloud-init of a cloud_init_config block is tracked by the block and not by user_data
+	if cloudInitConfig, ok := d.GetOk("cloud_init_config"); ok {
+		cloudInit, exists := userData["cloud-init"]
+		if !exists {
+			_ = d.Set("cloud_init_config", nil)
+		} else {
+			flatCloudInitConfig, err := flattenCloudInitConfig(cloudInit.(string), cloudInitConfig)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("failed to parse cloud-init of server: %w", err))
+			}
+
+			_ = d.Set("cloud_init_config", flatCloudInitConfig)
+		}
+
+		delete(userData, "cloud-init")
+	}
+
+	// Keep write-only user data out of the state, other keys set outside of Terraform are still read
+	for _, key := range d.Get("user_data_wo_keys").(*schema.Set).List() {
+		delete(userData, key.(string))
+	}
+
 	_ = d.Set("user_data", userData)
 
 	////
@@ -848,6 +994,7 @@ You can check the full list of compatible server types:
 
 	// Read server's private IPs if possible
 	allPrivateIPs := []map[string]any(nil)
+	ipamIPIDs := make(map[string][]string, len(privateNICIDs))
 	resourceType := ipamAPI.ResourceTypeInstancePrivateNic
 
 	region, err := zone.Region()
@@ -871,6 +1018,11 @@ You can check the full list of compatible server types:
 		switch {
 		case err == nil:
 			allPrivateIPs = append(allPrivateIPs, privateIPs...)
+
+			ipamIPIDs[nicID] = []string{}
+			for _, privateIP := range privateIPs {
+				ipamIPIDs[nicID] = append(ipamIPIDs[nicID], privateIP["id"].(string))
+			}
 		case httperrors.Is403(err):
 			return append(diags, diag.Diagnostic{
 				Severity:      diag.Warning,
@@ -889,6 +1041,7 @@ You can check the full list of compatible server types:
 	}
 
 	_ = d.Set("private_ips", allPrivateIPs)
+	_ = d.Set("private_network", flattenServerPrivateNetworkIPAMIPIDs(d.Get("private_network"), ipamIPIDs))
 
 	return diags
 }
@@ -909,6 +1062,15 @@ func ResourceInstanceServerUpdate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	if serversToStop := types.ExpandStrings(d.Get("servers_to_stop")); len(serversToStop) > 0 {
+		warnings = append(warnings, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "servers are stopped to apply the update",
+			Detail:   fmt.Sprintf("the update has a %s impact, the servers %s are stopped and started again", d.Get("update_impact"), strings.Join(serversToStop, ", ")),
+		})
+	}
+
 	////
 	// Construct UpdateServerRequest
 	////
@@ -946,6 +1108,13 @@ func ResourceInstanceServerUpdate(ctx context.Context, d *schema.ResourceData, m
 		updateRequest.Protected = types.ExpandBoolPtr(d.Get("protected").(bool))
 	}
 
+	if d.HasChange("root_volume.0.volume_type") {
+		err := ResourceInstanceServerMigrateRootVolume(ctx, d, api, zone, id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChanges("additional_volume_ids", "root_volume") {
 		volumes, err := instanceServerVolumesUpdate(ctx, d, api, zone, isStopped)
 		if err != nil {
@@ -1016,7 +1185,7 @@ func ResourceInstanceServerUpdate(ctx context.Context, d *schema.ResourceData, m
 	////
 	// Update server user data
 	////
-	if d.HasChanges("user_data") {
+	if d.HasChanges("user_data", "user_data_wo_version", "cloud_init_config") {
 		userDataRequests := &instanceSDK.SetAllServerUserDataRequest{
 			Zone:     zone,
 			ServerID: id,
@@ -1028,16 +1197,31 @@ func ResourceInstanceServerUpdate(ctx context.Context, d *schema.ResourceData, m
 			for key, value := range userDataMap {
 				userDataRequests.UserData[key] = bytes.NewBufferString(value.(string))
 			}
+		}
 
-			if !isStopped && d.HasChange("user_data.cloud-init") {
-				warnings = append(warnings, diag.Diagnostic{
-					Severity: diag.Warning,
-					Summary:  "instance may need to be rebooted to use the new cloud init config",
-				})
+		if cloudInitConfig, ok := d.GetOk("cloud_init_config"); ok {
+			cloudInit, err := renderCloudInitConfig(cloudInitConfig)
+			if err != nil {
+				return diag.FromErr(err)
 			}
+
+			userDataRequests.UserData["cloud-init"] = bytes.NewBufferString(cloudInit)
 		}
 
-		_, err := waitForServer(ctx, api.API, zone, id, d.Timeout(schema.TimeoutUpdate))
+		if !isStopped && d.HasChanges("user_data.cloud-init", "cloud_init_config") {
+			warnings = append(warnings, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "instance may need to be rebooted to use the new cloud init config",
+			})
+		}
+
+		// Write-only user data is not in the state and must be sent again as all the user data is replaced
+		userDataWOKeys, err := addServerUserDataWO(d, userDataRequests.UserData)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = waitForServer(ctx, api.API, zone, id, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -1046,6 +1230,8 @@ func ResourceInstanceServerUpdate(ctx context.Context, d *schema.ResourceData, m
 		if err != nil {
 			return diag.FromErr(err)
 		}
+
+		_ = d.Set("user_data_wo_keys", userDataWOKeys)
 	}
 
 	////
@@ -1081,49 +1267,50 @@ func ResourceInstanceServerUpdate(ctx context.Context, d *schema.ResourceData, m
 	if d.HasChanges("private_network") {
 		ph, err := newPrivateNICHandler(api.API, id, zone)
 		if err != nil {
-			diag.FromErr(err)
-		}
-
-		if raw, ok := d.GetOk("private_network"); ok {
-			// retrieve all current private network interfaces
-			for index := range raw.([]any) {
-				pnKey := fmt.Sprintf("private_network.%d.pn_id", index)
-				if d.HasChange(pnKey) {
-					o, n := d.GetChange(pnKey)
-					if !cmp.Equal(n, o) {
-						_, err := waitForServer(ctx, api.API, zone, id, d.Timeout(schema.TimeoutUpdate))
-						if err != nil {
-							return diag.FromErr(err)
-						}
-
-						err = ph.detach(ctx, o, d.Timeout(schema.TimeoutUpdate))
-						if err != nil {
-							return diag.FromErr(err)
-						}
-
-						err = ph.attach(ctx, n, d.Timeout(schema.TimeoutUpdate))
-						if err != nil {
-							return diag.FromErr(err)
-						}
-					}
-				}
+			return diag.FromErr(err)
+		}
+
+		// Private NICs are not ordered, only the added and removed private networks are handled
+		// so that reordering or removing an element does not detach the following ones.
+		oldPNs, newPNs := d.GetChange("private_network")
+		oldPNIDs := expandServerPrivateNetworkIDs(oldPNs)
+		newPNIDs := expandServerPrivateNetworkIDs(newPNs)
+		// A private NIC is re-created when its IPAM IPs change
+		oldIPAMIPIDs := expandServerPrivateNetworkIPAMIPIDs(oldPNs)
+		newIPAMIPIDs := expandServerPrivateNetworkIPAMIPIDs(newPNs)
+		ipamIPsChanged := func(pnID string) bool {
+			return !types.CompareStringListsIgnoringOrder(oldIPAMIPIDs[pnID], newIPAMIPIDs[pnID])
+		}
+
+		for _, pnID := range oldPNIDs {
+			if slices.Contains(newPNIDs, pnID) && !ipamIPsChanged(pnID) {
+				continue
 			}
-		} else {
-			// retrieve old private network config
-			o, _ := d.GetChange("private_network")
-			for _, raw := range o.([]any) {
-				pn, pnExist := raw.(map[string]any)
-				if pnExist {
-					_, err := waitForServer(ctx, api.API, zone, id, d.Timeout(schema.TimeoutUpdate))
-					if err != nil {
-						return diag.FromErr(err)
-					}
-
-					err = ph.detach(ctx, pn["pn_id"], d.Timeout(schema.TimeoutUpdate))
-					if err != nil {
-						return diag.FromErr(err)
-					}
-				}
+
+			_, err := waitForServer(ctx, api.API, zone, id, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			err = ph.detach(ctx, pnID, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		for _, pnID := range newPNIDs {
+			if slices.Contains(oldPNIDs, pnID) && !ipamIPsChanged(pnID) {
+				continue
+			}
+
+			_, err := waitForServer(ctx, api.API, zone, id, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			err = ph.attach(ctx, pnID, newIPAMIPIDs[pnID], d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -1180,10 +1367,10 @@ func ResourceInstanceServerDelete(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 	// detach eip to ensure to free eip even if instance won't stop
-	if ipID, ok := d.GetOk("ip_id"); ok {
+	for _, ipID := range serverIPsToDetach(ctx, d, api.API, zone, id) {
 		_, err := api.UpdateIP(&instanceSDK.UpdateIPRequest{
 			Zone:   zone,
-			IP:     zonal.ExpandID(ipID).ID,
+			IP:     ipID,
 			Server: &instanceSDK.NullableStringValue{Null: true},
 		}, scw.WithContext(ctx))
 		if err != nil {
@@ -1400,8 +1587,11 @@ func customDiffInstanceRootVolumeSize(_ context.Context, diff *schema.ResourceDi
 		return fmt.Errorf("failed to check server root volume type: %w", err)
 	}
 
+	// A local root volume migrated to a block volume is resized once migrated
+	migratedToSBS := diff.Get("root_volume.0.volume_type").(string) == instanceSDK.VolumeVolumeTypeSbsVolume.String()
+
 	if rootVolume, hasRootVolume := resp.Server.Volumes["0"]; hasRootVolume {
-		if rootVolume.VolumeType == instanceSDK.VolumeServerVolumeTypeLSSD {
+		if rootVolume.VolumeType == instanceSDK.VolumeServerVolumeTypeLSSD && !migratedToSBS {
 			return diff.ForceNew("root_volume.0.size_in_gb")
 		}
 	}
@@ -1527,6 +1717,279 @@ func ResourceInstanceServerMigrate(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
+// ResourceInstanceServerMigrateRootVolume moves the local root volume of the server to a block volume, keeping its data.
+// The server is stopped, its root volume is snapshotted and the snapshot is migrated to SBS.
+// The server then uses a block volume created from the snapshot, which is kept as a backup.
+// If the migration fails before the server uses the block volume, the snapshot and the block volume are deleted.
+func ResourceInstanceServerMigrateRootVolume(ctx context.Context, d *schema.ResourceData, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, id string) (err error) {
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	var snapshotID, volumeID string
+
+	defer func() {
+		if err != nil && (snapshotID != "" || volumeID != "") {
+			err = errors.Join(err, cleanupRootVolumeMigration(ctx, api, zone, snapshotID, volumeID, d.Timeout(schema.TimeoutDelete)))
+		}
+	}()
+
+	server, err := waitForServer(ctx, api.API, zone, id, timeout)
+	if err != nil {
+		return fmt.Errorf("failed to wait for server before migrating root volume: %w", err)
+	}
+
+	rootVolume, hasRootVolume := server.Volumes["0"]
+	if !hasRootVolume {
+		return errors.New("server has no root volume to migrate")
+	}
+
+	newVolumeType := d.Get("root_volume.0.volume_type").(string)
+	if rootVolume.VolumeType != instanceSDK.VolumeServerVolumeTypeLSSD || newVolumeType != instanceSDK.VolumeVolumeTypeSbsVolume.String() {
+		return fmt.Errorf("root volume of type %s cannot be migrated to %s", rootVolume.VolumeType, newVolumeType)
+	}
+
+	beginningState := server.State
+
+	rootVolumeName := types.NewRandomName("vol")
+	if rootVolume.Name != nil {
+		rootVolumeName = *rootVolume.Name
+	}
+
+	err = reachState(ctx, api, zone, id, instanceSDK.ServerStateStopped)
+	if err != nil {
+		return fmt.Errorf("failed to stop server before migrating root volume: %w", err)
+	}
+
+	snapshot, err := api.CreateSnapshot(&instanceSDK.CreateSnapshotRequest{
+		Zone:     zone,
+		VolumeID: &rootVolume.ID,
+		Name:     rootVolumeName + "-sbs-migration",
+		Project:  &server.Project,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to snapshot root volume: %w", err)
+	}
+
+	snapshotID = snapshot.Snapshot.ID
+
+	_, err = waitForSnapshot(ctx, api.API, zone, snapshotID, timeout)
+	if err != nil {
+		return err
+	}
+
+	plan, err := api.PlanBlockMigration(&instanceSDK.PlanBlockMigrationRequest{
+		Zone:       zone,
+		SnapshotID: &snapshotID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to plan migration of snapshot %s: %w", snapshotID, err)
+	}
+
+	err = api.ApplyBlockMigration(&instanceSDK.ApplyBlockMigrationRequest{
+		Zone:          zone,
+		SnapshotID:    &snapshotID,
+		ValidationKey: plan.ValidationKey,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to migrate snapshot %s: %w", snapshotID, err)
+	}
+
+	_, err = api.BlockAPI.WaitForSnapshot(&block.WaitForSnapshotRequest{
+		Zone:          zone,
+		SnapshotID:    snapshotID,
+		Timeout:       new(timeout),
+		RetryInterval: transport.DefaultWaitRetryInterval,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	volume, err := api.BlockAPI.CreateVolume(&block.CreateVolumeRequest{
+		Zone:      zone,
+		Name:      rootVolumeName,
+		ProjectID: server.Project,
+		PerfIops:  types.ExpandUint32Ptr(d.Get("root_volume.0.sbs_iops")),
+		FromSnapshot: &block.CreateVolumeRequestFromSnapshot{
+			SnapshotID: snapshotID,
+		},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to create block volume from snapshot %s: %w", snapshotID, err)
+	}
+
+	volumeID = volume.ID
+
+	_, err = api.BlockAPI.WaitForVolumeAndReferences(&block.WaitForVolumeAndReferencesRequest{
+		Zone:          zone,
+		VolumeID:      volume.ID,
+		Timeout:       new(timeout),
+		RetryInterval: transport.DefaultWaitRetryInterval,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	volumes := make(map[string]*instanceSDK.VolumeServerTemplate, len(server.Volumes))
+
+	for index, serverVolume := range server.Volumes {
+		unknownVolume := &instancehelpers.UnknownVolume{
+			ID:                 serverVolume.ID,
+			Name:               types.FlattenStringPtr(serverVolume.Name).(string),
+			InstanceVolumeType: instanceSDK.VolumeVolumeType(serverVolume.VolumeType),
+			Boot:               new(serverVolume.Boot),
+		}
+
+		if index == "0" {
+			unknownVolume.ID = volume.ID
+			unknownVolume.InstanceVolumeType = instanceSDK.VolumeVolumeTypeSbsVolume
+		}
+
+		volumes[index] = unknownVolume.VolumeTemplate()
+	}
+
+	_, err = api.UpdateServer(&instanceSDK.UpdateServerRequest{
+		Zone:     zone,
+		ServerID: id,
+		Volumes:  &volumes,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to replace root volume with block volume %s: %w", volume.ID, err)
+	}
+
+	// The server uses the block volume, the snapshot is kept as a backup
+	snapshotID, volumeID = "", ""
+
+	_, err = waitForServer(ctx, api.API, zone, id, timeout)
+	if err != nil {
+		return err
+	}
+
+	err = api.DeleteVolume(&instanceSDK.DeleteVolumeRequest{
+		Zone:     zone,
+		VolumeID: rootVolume.ID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return fmt.Errorf("failed to delete migrated local volume %s: %w", rootVolume.ID, err)
+	}
+
+	// The following volume updates must use the new root volume
+	rootVolumeState := d.Get("root_volume.0").(map[string]any)
+	rootVolumeState["volume_id"] = zonal.NewIDString(zone, volume.ID)
+	_ = d.Set("root_volume", []map[string]any{rootVolumeState})
+
+	err = reachState(ctx, api, zone, id, beginningState)
+	if err != nil {
+		return fmt.Errorf("failed to start server after migrating root volume: %w", err)
+	}
+
+	return nil
+}
+
+// cleanupRootVolumeMigration deletes the block volume and the snapshot created by a root volume migration that failed,
+// the resources that cannot be deleted are reported in the returned error.
+func cleanupRootVolumeMigration(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, snapshotID string, volumeID string, timeout time.Duration) error {
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	var errs []error
+
+	if volumeID != "" {
+		err := api.BlockAPI.DeleteVolume(&block.DeleteVolumeRequest{
+			Zone:     zone,
+			VolumeID: volumeID,
+		}, scw.WithContext(cleanupCtx))
+		if err != nil && !httperrors.Is404(err) {
+			errs = append(errs, fmt.Errorf("block volume %s created for the migration must be deleted manually: %w", zonal.NewIDString(zone, volumeID), err))
+		}
+	}
+
+	if snapshotID != "" {
+		err := api.DeleteUnknownSnapshot(&instancehelpers.DeleteUnknownSnapshotRequest{
+			Zone:       zone,
+			SnapshotID: snapshotID,
+		}, scw.WithContext(cleanupCtx))
+		if err != nil && !httperrors.Is404(err) {
+			errs = append(errs, fmt.Errorf("snapshot %s created for the migration must be deleted manually: %w", zonal.NewIDString(zone, snapshotID), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// customDiffInstanceServerUpdateImpact shows in the plan whether the update resizes a volume while the server is running
+// or stops the server, and replaces the server when its root volume type cannot be changed in place.
+func customDiffInstanceServerUpdateImpact(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	impact := instancehelpers.VolumeUpdateImpactNone
+
+	if diff.HasChanges("root_volume.0.volume_type", "root_volume.0.size_in_gb", "root_volume.0.sbs_iops") {
+		oldType, newType := diff.GetChange("root_volume.0.volume_type")
+		oldSize, newSize := diff.GetChange("root_volume.0.size_in_gb")
+
+		impact = instancehelpers.ClassifyVolumeUpdate(instancehelpers.VolumeUpdate{
+			VolumeType:    instanceSDK.VolumeVolumeType(oldType.(string)),
+			NewVolumeType: instanceSDK.VolumeVolumeType(newType.(string)),
+			Size:          oldSize.(int),
+			NewSize:       newSize.(int),
+			IOPSChange:    diff.HasChange("root_volume.0.sbs_iops"),
+		})
+
+		if impact == instancehelpers.VolumeUpdateImpactDestructive {
+			// Resizes are checked by customDiffInstanceRootVolumeSize
+			if diff.HasChange("root_volume.0.volume_type") && newType.(string) != "" {
+				return diff.ForceNew("root_volume.0.volume_type")
+			}
+
+			return nil
+		}
+	}
+
+	// Changing the type of the server stops it, unless it is replaced
+	if diff.HasChange("type") && !diff.Get("replace_on_type_change").(bool) {
+		impact = instancehelpers.VolumeUpdateImpactServerStop
+	}
+
+	serversToStop := []string{}
+
+	if state, _ := diff.GetChange("state"); impact == instancehelpers.VolumeUpdateImpactServerStop && state.(string) != InstanceServerStateStopped {
+		serversToStop = append(serversToStop, diff.Id())
+	}
+
+	err := instancehelpers.SetPlannedUpdate(diff, "update_impact", impact.String(), impact == instancehelpers.VolumeUpdateImpactNone)
+	if err != nil {
+		return err
+	}
+
+	return instancehelpers.SetPlannedUpdate(diff, "servers_to_stop", serversToStop, len(serversToStop) == 0)
+}
+
+// customDiffInstanceServerReplacedServer records in the plan the server being replaced,
+// so that the replacement strategy only hands over the resources attached to it.
+func customDiffInstanceServerReplacedServer(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	// The diff of a replacement is computed again without the prior state, its ID is only left in the raw state
+	if diff.Id() != "" {
+		return nil
+	}
+
+	if _, ok := diff.GetOk("replacement_strategy"); !ok {
+		return nil
+	}
+
+	rawState := diff.GetRawState()
+	if rawState.IsNull() || !rawState.IsKnown() {
+		return nil
+	}
+
+	replacedID := rawState.GetAttr("id")
+	if replacedID.IsNull() || !replacedID.IsKnown() {
+		return nil
+	}
+
+	return diff.SetNew("replaced_server_id", replacedID.AsString())
+}
+
 func ResourceInstanceServerUpdateIPs(ctx context.Context, d *schema.ResourceData, instanceAPI *instanceSDK.API, zone scw.Zone, id string, attribute string) error {
 	server, err := waitForServer(ctx, instanceAPI, zone, id, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
@@ -1719,6 +2182,181 @@ func renameRootVolumeIfNeeded(d *schema.ResourceData, api *instancehelpers.Block
 	return nil
 }
 
+// customDiffInstanceServerPublicIPs marks the public IPs as unknown when the attached IPs are about to change
+func customDiffInstanceServerPublicIPs(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if diff.Id() == "" || !diff.HasChanges("ip_id", "ip_ids", "enable_dynamic_ip") {
+		return nil
+	}
+
+	return diff.SetNewComputed("public_ips")
+}
+
+func serverCloudInitConfigSchema() *schema.Schema {
+	s := cloudInitConfigSchema()
+	s.ConflictsWith = []string{"cloud_init"}
+
+	return s
+}
+
+// customDiffInstanceServerCloudInitConfig rejects a cloud_init_config block along with a cloud-init key in user_data.
+// The raw config is checked as the cloud-init key of a previous user_data may still be in the state.
+func customDiffInstanceServerCloudInitConfig(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if _, ok := diff.GetOk("cloud_init_config"); !ok {
+		return nil
+	}
+
+	rawConfig := diff.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+
+	rawUserData := rawConfig.GetAttr("user_data")
+	if rawUserData.IsNull() || !rawUserData.IsKnown() {
+		return nil
+	}
+
+	for key := range rawUserData.AsValueMap() {
+		if key == "cloud-init" {
+			return errors.New("the cloud-init key of user_data cannot be set along with cloud_init_config")
+		}
+	}
+
+	return nil
+}
+
+// diffSuppressPrivateNetworkOrder suppresses the diff of a private network ID when the private networks
+// only have been reordered, as the private NICs of a server are not ordered.
+func diffSuppressPrivateNetworkOrder(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	if dsf.Locality(k, oldValue, newValue, d) {
+		return true
+	}
+
+	oldPNs, newPNs := d.GetChange("private_network")
+
+	return types.CompareStringListsIgnoringOrder(expandServerPrivateNetworkIDs(oldPNs), expandServerPrivateNetworkIDs(newPNs))
+}
+
+// expandServerPrivateNetworkIDs returns the IDs without locality of the private_network list
+func expandServerPrivateNetworkIDs(raw any) []string {
+	rawPNs, _ := raw.([]any)
+	pnIDs := make([]string, 0, len(rawPNs))
+
+	for _, rawPN := range rawPNs {
+		pn, ok := rawPN.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if pnID, ok := pn["pn_id"].(string); ok && pnID != "" {
+			pnIDs = append(pnIDs, locality.ExpandID(pnID))
+		}
+	}
+
+	return pnIDs
+}
+
+// expandServerPrivateNetworkIPAMIPIDs returns the IPAM IP IDs without locality of each private network of the private_network list
+func expandServerPrivateNetworkIPAMIPIDs(raw any) map[string][]string {
+	rawPNs, _ := raw.([]any)
+	ipamIPIDs := make(map[string][]string, len(rawPNs))
+
+	for _, rawPN := range rawPNs {
+		pn, ok := rawPN.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if pnID, ok := pn["pn_id"].(string); ok && pnID != "" {
+			rawIPAMIPIDs, _ := pn["ipam_ip_ids"].([]any)
+			ipamIPIDs[locality.ExpandID(pnID)] = locality.ExpandIDs(rawIPAMIPIDs)
+		}
+	}
+
+	return ipamIPIDs
+}
+
+// flattenServerPrivateNetworkIPAMIPIDs sets the IPAM IP IDs of each private network of the private_network list from the IPs of its private NIC.
+// The private networks whose IPs could not be listed keep their IPAM IP IDs.
+func flattenServerPrivateNetworkIPAMIPIDs(raw any, ipamIPIDs map[string][]string) []any {
+	rawPNs, _ := raw.([]any)
+
+	for _, rawPN := range rawPNs {
+		pn, ok := rawPN.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		pnicID, _ := pn["pnic_id"].(string)
+		if ids, ok := ipamIPIDs[pnicID]; ok {
+			pn["ipam_ip_ids"] = ids
+		}
+	}
+
+	return rawPNs
+}
+
+// expandServerUserDataWO parses the JSON object of the write-only user data
+func expandServerUserDataWO(raw string) (map[string]string, error) {
+	userData := map[string]string{}
+
+	err := json.Unmarshal([]byte(raw), &userData)
+	if err != nil {
+		return nil, fmt.Errorf("user_data_wo must be a JSON object of string values: %w", err)
+	}
+
+	return userData, nil
+}
+
+func validateServerUserDataWO(i any, path cty.Path) diag.Diagnostics {
+	raw, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "expected type of user_data_wo to be string",
+			AttributePath: path,
+		}}
+	}
+
+	_, err := expandServerUserDataWO(raw)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid user_data_wo",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
+
+// addServerUserDataWO adds the write-only user data of the configuration to the user data sent to the API.
+// It returns the keys of the write-only user data.
+func addServerUserDataWO(d *schema.ResourceData, userData map[string]io.Reader) ([]string, error) {
+	rawUserDataWO := d.GetRawConfig().GetAttr("user_data_wo")
+	if rawUserDataWO.IsNull() || !rawUserDataWO.IsKnown() {
+		return []string{}, nil
+	}
+
+	userDataWO, err := expandServerUserDataWO(rawUserDataWO.AsString())
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(userDataWO))
+
+	for key, value := range userDataWO {
+		if _, exists := userData[key]; exists {
+			return nil, fmt.Errorf("user data key %q cannot be set in both user_data_wo and user_data or cloud_init", key)
+		}
+
+		userData[key] = bytes.NewBufferString(value)
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
 func errorCheck(err error, message string) bool {
 	return strings.Contains(err.Error(), message)
 }
@@ -16,6 +16,13 @@ func DataSourceServer() *schema.Resource {
 	// Generate datasource schema from resource
 	dsSchema := datasource.SchemaFromResourceSchema(ResourceServer().SchemaFunc())
 
+	// Write-only user data is never read back
+	delete(dsSchema, "user_data_wo")
+	delete(dsSchema, "user_data_wo_version")
+	delete(dsSchema, "user_data_wo_keys")
+	delete(dsSchema, "update_impact")
+	delete(dsSchema, "servers_to_stop")
+
 	// Set 'Optional' schema elements
 	datasource.AddOptionalFieldsToSchema(dsSchema, "name", "zone", "project_id")
 
@@ -0,0 +1,415 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	audittrail "github.com/scaleway/scaleway-sdk-go/api/audit_trail/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+const (
+	serverDiagnosticRedacted         = "<redacted>"
+	serverDiagnosticDefaultMaxEvents = 20
+	// serverDiagnosticErrorMaxEvents is the number of events appended to the error of a failed server operation
+	serverDiagnosticErrorMaxEvents = 5
+	// serverDiagnosticTimeout bounds the diagnostic fetched when a server operation fails, the context of the operation may be expired
+	serverDiagnosticTimeout = 30 * time.Second
+)
+
+// serverDiagnosticSecretKeys are the parts of the keys whose values are redacted from the request bodies of the events
+var serverDiagnosticSecretKeys = []string{
+	"password",
+	"secret",
+	"token",
+	"private_key",
+	"api_key",
+	"access_key",
+	"authorization",
+	"user_data",
+	"cloud-init",
+	"cloud_init",
+}
+
+func DataSourceServerDiagnostic() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceInstanceServerDiagnosticRead,
+		SchemaFunc:  serverDiagnosticSchema,
+	}
+}
+
+func serverDiagnosticSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"server_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			Description:      "The ID of the server",
+		},
+		"max_events": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      serverDiagnosticDefaultMaxEvents,
+			ValidateFunc: validation.IntBetween(0, 100),
+			Description:  "The maximum number of Audit Trail events to return, most recent first",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the server",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The state of the server",
+		},
+		"state_detail": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The detailed state of the server, as reported by the hypervisor",
+		},
+		"boot_type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The boot type of the server",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The commercial type of the server",
+		},
+		"creation_date": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The creation date of the server",
+		},
+		"modification_date": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The last modification date of the server",
+		},
+		"maintenances": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The reasons of the maintenances planned on the server",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"user_data_keys": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The keys of the user data of the server, values are not returned as they may contain secrets",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"events": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The Audit Trail events of the server, most recent first",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "ID of the event",
+					},
+					"recorded_at": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Timestamp of the event",
+					},
+					"principal_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "ID of the user or IAM application at the origin of the event",
+					},
+					"method_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "API method called to trigger the event",
+					},
+					"status_code": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "HTTP status code resulting of the API call",
+					},
+					"request_body": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Request at the origin of the event, with secrets redacted",
+					},
+				},
+			},
+		},
+		"zone": zonal.Schema(),
+	}
+}
+
+// serverDiagnostic gathers what is known about a server to understand why it did not reach its expected state
+type serverDiagnostic struct {
+	server       *instance.Server
+	userDataKeys []string
+	events       []*audittrail.Event
+	// eventsErr is set when the Audit Trail events could not be listed, the rest of the diagnostic is still valid
+	eventsErr error
+}
+
+func fetchServerDiagnostic(ctx context.Context, m any, api *instance.API, zone scw.Zone, serverID string, maxEvents int) (*serverDiagnostic, error) {
+	res, err := api.GetServer(&instance.GetServerRequest{
+		Zone:     zone,
+		ServerID: serverID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	diagnostic := &serverDiagnostic{
+		server: res.Server,
+	}
+
+	userData, err := api.ListServerUserData(&instance.ListServerUserDataRequest{
+		Zone:     zone,
+		ServerID: serverID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	diagnostic.userDataKeys = userData.UserData
+
+	if maxEvents > 0 {
+		diagnostic.events, diagnostic.eventsErr = listServerAuditTrailEvents(ctx, m, res.Server, maxEvents)
+	}
+
+	return diagnostic, nil
+}
+
+func listServerAuditTrailEvents(ctx context.Context, m any, server *instance.Server, maxEvents int) ([]*audittrail.Event, error) {
+	region, err := server.Zone.Region()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := audittrail.NewAPI(meta.ExtractScwClient(m)).ListEvents(&audittrail.ListEventsRequest{
+		Region:         region,
+		OrganizationID: server.Organization,
+		ResourceType:   audittrail.ResourceTypeInstanceServer,
+		ResourceID:     new(server.ID),
+		RecordedAfter:  server.CreationDate,
+		OrderBy:        audittrail.ListEventsRequestOrderByRecordedAtDesc,
+		PageSize:       new(uint32(maxEvents)),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Events, nil
+}
+
+// redactServerDiagnosticSecrets returns a copy of a request body where the values of the secret keys
+// and the private keys are replaced
+func redactServerDiagnosticSecrets(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+
+		for key, nested := range v {
+			if isServerDiagnosticSecretKey(key) && nested != nil {
+				redacted[key] = serverDiagnosticRedacted
+			} else {
+				redacted[key] = redactServerDiagnosticSecrets(nested)
+			}
+		}
+
+		return redacted
+	case scw.JSONObject:
+		return redactServerDiagnosticSecrets(map[string]any(v))
+	case []any:
+		redacted := make([]any, 0, len(v))
+		for _, nested := range v {
+			redacted = append(redacted, redactServerDiagnosticSecrets(nested))
+		}
+
+		return redacted
+	case string:
+		if strings.Contains(v, "PRIVATE KEY-----") {
+			return serverDiagnosticRedacted
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+func isServerDiagnosticSecretKey(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, secretKey := range serverDiagnosticSecretKeys {
+		if strings.Contains(key, secretKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func flattenServerDiagnosticEvents(events []*audittrail.Event) ([]map[string]any, error) {
+	flattened := make([]map[string]any, 0, len(events))
+
+	for _, event := range events {
+		requestBody := ""
+
+		if event.RequestBody != nil {
+			body, err := scw.EncodeJSONObject(redactServerDiagnosticSecrets(*event.RequestBody).(map[string]any), scw.NoEscape)
+			if err != nil {
+				return nil, err
+			}
+
+			requestBody = body
+		}
+
+		principalID := ""
+		if event.Principal != nil {
+			principalID = event.Principal.ID
+		}
+
+		flattened = append(flattened, map[string]any{
+			"id":           event.ID,
+			"recorded_at":  types.FlattenTime(event.RecordedAt),
+			"principal_id": principalID,
+			"method_name":  event.MethodName,
+			"status_code":  int(event.StatusCode),
+			"request_body": requestBody,
+		})
+	}
+
+	return flattened, nil
+}
+
+// String summarizes the diagnostic to be appended to the error of a failed server operation
+func (diagnostic *serverDiagnostic) String() string {
+	server := diagnostic.server
+	summary := &strings.Builder{}
+
+	_, _ = fmt.Fprintf(summary, "server %s (%s) is %s", server.Name, server.ID, server.State)
+	if server.StateDetail != "" {
+		_, _ = fmt.Fprintf(summary, " with state detail %q", server.StateDetail)
+	}
+
+	_, _ = fmt.Fprintf(summary, ", boot type is %s", server.BootType)
+
+	if len(server.Maintenances) > 0 {
+		reasons := make([]string, 0, len(server.Maintenances))
+		for _, maintenance := range server.Maintenances {
+			reasons = append(reasons, maintenance.Reason)
+		}
+
+		_, _ = fmt.Fprintf(summary, ", planned maintenances: %s", strings.Join(reasons, ", "))
+	}
+
+	if len(diagnostic.userDataKeys) > 0 {
+		_, _ = fmt.Fprintf(summary, ", user data keys: %s", strings.Join(diagnostic.userDataKeys, ", "))
+	}
+
+	if len(diagnostic.events) > 0 {
+		summary.WriteString("\nlatest events:")
+
+		for _, event := range diagnostic.events {
+			recordedAt := ""
+			if event.RecordedAt != nil {
+				recordedAt = event.RecordedAt.Format(time.RFC3339)
+			}
+
+			_, _ = fmt.Fprintf(summary, "\n  %s %s %d", recordedAt, event.MethodName, event.StatusCode)
+		}
+	}
+
+	summary.WriteString("\nUse the scaleway_instance_server_diagnostic data source or the serial console of the server for more details.")
+
+	return summary.String()
+}
+
+// serverErrorWithDiagnostic returns the error of a failed server operation with the diagnostic of the server as detail.
+// The diagnostic is best effort, the error is returned as is if it cannot be fetched.
+func serverErrorWithDiagnostic(ctx context.Context, m any, api *instance.API, zone scw.Zone, serverID string, err error) diag.Diagnostics {
+	diagnosticCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serverDiagnosticTimeout)
+	defer cancel()
+
+	diagnostic, diagnosticErr := fetchServerDiagnostic(diagnosticCtx, m, api, zone, serverID, serverDiagnosticErrorMaxEvents)
+	if diagnosticErr != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  err.Error(),
+		Detail:   diagnostic.String(),
+	}}
+}
+
+func DataSourceInstanceServerDiagnosticRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, err := newAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverID := zonal.ExpandID(d.Get("server_id"))
+	if serverID.Zone != "" {
+		zone = serverID.Zone
+	}
+
+	diagnostic, err := fetchServerDiagnostic(ctx, m, instanceAPI, zone, serverID.ID, d.Get("max_events").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+
+	events, err := flattenServerDiagnosticEvents(diagnostic.events)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diagnostic.eventsErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Audit Trail events of the server could not be listed",
+			Detail:   diagnostic.eventsErr.Error(),
+		})
+	}
+
+	server := diagnostic.server
+	maintenances := make([]string, 0, len(server.Maintenances))
+
+	for _, maintenance := range server.Maintenances {
+		maintenances = append(maintenances, maintenance.Reason)
+	}
+
+	d.SetId(zonal.NewIDString(zone, server.ID))
+	_ = d.Set("zone", zone)
+	_ = d.Set("name", server.Name)
+	_ = d.Set("state", server.State.String())
+	_ = d.Set("state_detail", server.StateDetail)
+	_ = d.Set("boot_type", server.BootType.String())
+	_ = d.Set("type", server.CommercialType)
+	_ = d.Set("creation_date", types.FlattenTime(server.CreationDate))
+	_ = d.Set("modification_date", types.FlattenTime(server.ModificationDate))
+	_ = d.Set("maintenances", maintenances)
+	_ = d.Set("user_data_keys", diagnostic.userDataKeys)
+	_ = d.Set("events", events)
+
+	return diags
+}
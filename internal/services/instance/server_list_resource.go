@@ -0,0 +1,45 @@
+package instance
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/listresource"
+)
+
+//go:embed descriptions/server_list_resource.md
+var serverListResourceDescription string
+
+func NewServerListResource() list.ListResource {
+	return listresource.NewZonal(listresource.Config[scw.Zone, *instanceSDK.Server]{
+		TypeName:    "instance_server",
+		Description: serverListResourceDescription,
+		Resource:    ResourceServer,
+		List: func(ctx context.Context, client *scw.Client, zone scw.Zone, filters listresource.Filters) ([]*instanceSDK.Server, error) {
+			res, err := instanceSDK.NewAPI(client).ListServers(&instanceSDK.ListServersRequest{
+				Zone:    zone,
+				Project: filters.ProjectID,
+				Name:    filters.Name,
+				Tags:    filters.Tags,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			return res.Servers, nil
+		},
+		Flatten: func(server *instanceSDK.Server) listresource.Item {
+			return listresource.Item{
+				DisplayName: server.Name,
+				SetIdentity: func(d *sdkschema.ResourceData) error {
+					return identity.SetZonalIdentity(d, server.Zone, server.ID)
+				},
+			}
+		},
+	})
+}
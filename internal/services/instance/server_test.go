@@ -1,13 +1,33 @@
 package instance_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	blockSDK "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	ipamSDK "github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	vpcSDK "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	iamchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/iam/testfuncs"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/instancehelpers"
 	instancechecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/testfuncs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -634,3 +654,566 @@ func TestAccServer_AdminPasswordEncryptionSSHKeyID(t *testing.T) {
 		},
 	})
 }
+
+func TestServerDiff(t *testing.T) {
+	t.Setenv("SCW_ACCESS_KEY", "SCWXXXXXXXXXXXXXXXXX")
+	t.Setenv("SCW_SECRET_KEY", "866F4A9A-D058-4D3C-A39F-86930849CCC0")
+	t.Setenv("SCW_DEFAULT_PROJECT_ID", "866F4A9A-D058-4D3C-A39F-86930849CCC0")
+
+	m, err := meta.NewMeta(t.Context(), &meta.Config{})
+	require.NoError(t, err)
+
+	r := instance.ResourceServer()
+	configType := r.CoreConfigSchema().ImpliedType()
+
+	const (
+		pnA = "fr-par-1/11111111-1111-1111-1111-111111111111"
+		pnB = "fr-par-1/22222222-2222-2222-2222-222222222222"
+		pnC = "fr-par-1/33333333-3333-3333-3333-333333333333"
+		ipA = "fr-par-1/44444444-4444-4444-4444-444444444444"
+		ipB = "fr-par-1/55555555-5555-5555-5555-555555555555"
+	)
+
+	// serverConfig builds a raw configuration with the given private networks and ip_id
+	serverConfig := func(ipID string, pnIDs ...string) cty.Value {
+		values := map[string]cty.Value{}
+		for name, attrType := range configType.AttributeTypes() {
+			values[name] = cty.NullVal(attrType)
+		}
+
+		values["type"] = cty.StringVal("DEV1-S")
+		values["image"] = cty.StringVal("ubuntu_jammy")
+
+		if ipID != "" {
+			values["ip_id"] = cty.StringVal(ipID)
+		}
+
+		pnType := configType.AttributeType("private_network").ElementType()
+		if len(pnIDs) == 0 {
+			values["private_network"] = cty.ListValEmpty(pnType)
+		} else {
+			pns := make([]cty.Value, 0, len(pnIDs))
+
+			for _, pnID := range pnIDs {
+				pn := map[string]cty.Value{}
+				for name, attrType := range pnType.AttributeTypes() {
+					pn[name] = cty.NullVal(attrType)
+				}
+
+				pn["pn_id"] = cty.StringVal(pnID)
+				pns = append(pns, cty.ObjectVal(pn))
+			}
+
+			values["private_network"] = cty.ListVal(pns)
+		}
+
+		return cty.ObjectVal(values)
+	}
+
+	// serverState builds the state of a started server with the given private networks and ip_id
+	serverState := func(ipID string, pnIDs ...string) map[string]string {
+		state := map[string]string{
+			"id":                     "fr-par-1/66666666-6666-6666-6666-666666666666",
+			"type":                   "DEV1-S",
+			"image":                  "ubuntu_jammy",
+			"zone":                   "fr-par-1",
+			"boot_type":              "local",
+			"protected":              "false",
+			"replace_on_type_change": "false",
+			"enable_dynamic_ip":      "false",
+			"ip_id":                  ipID,
+			"public_ips.#":           "1",
+			"public_ips.0.id":        ipA,
+			"private_network.#":      strconv.Itoa(len(pnIDs)),
+		}
+
+		for i, pnID := range pnIDs {
+			state[fmt.Sprintf("private_network.%d.pn_id", i)] = pnID
+			state[fmt.Sprintf("private_network.%d.zone", i)] = "fr-par-1"
+		}
+
+		return state
+	}
+
+	tests := []struct {
+		name             string
+		state            map[string]string
+		config           cty.Value
+		expectedDiff     []string
+		expectedComputed []string
+		expectedNoDiff   []string
+	}{
+		{
+			name:           "reordered private networks",
+			state:          serverState(ipA, pnA, pnB),
+			config:         serverConfig(ipA, pnB, pnA),
+			expectedNoDiff: []string{"private_network.0.pn_id", "private_network.1.pn_id", "public_ips.#"},
+		},
+		{
+			name:           "private networks without locality",
+			state:          serverState(ipA, pnA),
+			config:         serverConfig(ipA, "11111111-1111-1111-1111-111111111111"),
+			expectedNoDiff: []string{"private_network.0.pn_id"},
+		},
+		{
+			name:         "replaced private network",
+			state:        serverState(ipA, pnA, pnB),
+			config:       serverConfig(ipA, pnB, pnC),
+			expectedDiff: []string{"private_network.0.pn_id", "private_network.1.pn_id"},
+		},
+		{
+			name:             "changed ip_id",
+			state:            serverState(ipA),
+			config:           serverConfig(ipB),
+			expectedDiff:     []string{"ip_id"},
+			expectedComputed: []string{"public_ips.#"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID:         tc.state["id"],
+				Attributes: tc.state,
+				RawConfig:  tc.config,
+			}
+
+			state.RawState, err = state.AttrsAsObjectValue(configType)
+			require.NoError(t, err)
+
+			diff, err := r.SimpleDiff(t.Context(), state, terraform.NewResourceConfigShimmed(tc.config, r.CoreConfigSchema()), m)
+			require.NoError(t, err)
+			require.NotNil(t, diff)
+
+			for _, key := range tc.expectedDiff {
+				assert.Contains(t, diff.Attributes, key)
+			}
+
+			for _, key := range tc.expectedComputed {
+				if assert.Contains(t, diff.Attributes, key) {
+					assert.True(t, diff.Attributes[key].NewComputed, key)
+				}
+			}
+
+			for _, key := range tc.expectedNoDiff {
+				assert.NotContains(t, diff.Attributes, key)
+			}
+		})
+	}
+}
+
+func TestServerUserDataWOValidation(t *testing.T) {
+	validate := instance.ResourceServer().SchemaMap()["user_data_wo"].ValidateDiagFunc
+
+	assert.False(t, validate(`{"secret": "value", "cloud-init": "#cloud-config"}`, cty.GetAttrPath("user_data_wo")).HasError())
+	assert.True(t, validate(`{"secret": 42}`, cty.GetAttrPath("user_data_wo")).HasError())
+	assert.True(t, validate(`["secret"]`, cty.GetAttrPath("user_data_wo")).HasError())
+	assert.True(t, validate(`secret`, cty.GetAttrPath("user_data_wo")).HasError())
+}
+
+func TestServerReadSkipsWriteOnlyUserData(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+	api := instanceSDK.NewAPI(m.ScwClient())
+
+	server, err := api.CreateServer(&instanceSDK.CreateServerRequest{
+		Zone:           scw.ZoneFrPar1,
+		Name:           "test-user-data-wo",
+		CommercialType: "DEV1-S",
+		Image:          new("ubuntu_jammy"),
+	})
+	require.NoError(t, err)
+
+	err = api.SetAllServerUserData(&instanceSDK.SetAllServerUserDataRequest{
+		Zone:     scw.ZoneFrPar1,
+		ServerID: server.Server.ID,
+		UserData: map[string]io.Reader{
+			"secret":  strings.NewReader("value"),
+			"tracked": strings.NewReader("foo"),
+			"manual":  strings.NewReader("bar"),
+		},
+	})
+	require.NoError(t, err)
+
+	r := instance.ResourceServer()
+	d := r.Data(&terraform.InstanceState{
+		ID: "fr-par-1/" + server.Server.ID,
+		Attributes: map[string]string{
+			"user_data.%":          "1",
+			"user_data.tracked":    "foo",
+			"user_data_wo_version": "1",
+			"user_data_wo_keys.#":  "1",
+			"user_data_wo_keys.0":  "secret",
+		},
+	})
+
+	diags := r.ReadContext(t.Context(), d, m)
+	require.False(t, diags.HasError(), diags)
+
+	// Only the keys written through user_data_wo are left out, keys added outside of Terraform show up in the plan
+	assert.Equal(t, map[string]any{"tracked": "foo", "manual": "bar"}, d.Get("user_data"))
+	assert.Equal(t, []any{"secret"}, d.Get("user_data_wo_keys").(*schema.Set).List())
+}
+
+func TestServerReplacementStrategyValidation(t *testing.T) {
+	replacementStrategy := instance.ResourceServer().SchemaMap()["replacement_strategy"]
+	validate := replacementStrategy.Elem.(*schema.Resource).Schema["mode"].ValidateFunc
+
+	for _, mode := range []string{instance.InstanceServerReplacementHandover, instance.InstanceServerReplacementStopFirst} {
+		_, errs := validate(mode, "mode")
+		assert.Empty(t, errs, mode)
+	}
+
+	_, errs := validate("destroy_first", "mode")
+	assert.NotEmpty(t, errs)
+}
+
+// serverHandoverFixture holds the resources a server hands over to its replacement
+type serverHandoverFixture struct {
+	ipID     string
+	volumeID string
+	pnID     string
+	ipamIPID string
+}
+
+func newServerHandoverFixture(t *testing.T, m *meta.Meta) *serverHandoverFixture {
+	t.Helper()
+
+	client := m.ScwClient()
+
+	ip, err := instanceSDK.NewAPI(client).CreateIP(&instanceSDK.CreateIPRequest{
+		Zone: scw.ZoneFrPar1,
+		Type: instanceSDK.IPTypeRoutedIPv4,
+	})
+	require.NoError(t, err)
+
+	volume, err := instanceSDK.NewAPI(client).CreateVolume(&instanceSDK.CreateVolumeRequest{
+		Zone:       scw.ZoneFrPar1,
+		Name:       "test-handover",
+		VolumeType: instanceSDK.VolumeVolumeTypeBSSD,
+		Size:       scw.SizePtr(10 * scw.GB),
+	})
+	require.NoError(t, err)
+
+	pn, err := vpcSDK.NewAPI(client).CreatePrivateNetwork(&vpcSDK.CreatePrivateNetworkRequest{
+		Region: scw.RegionFrPar,
+		Name:   "test-handover",
+	})
+	require.NoError(t, err)
+
+	ipamIP, err := ipamSDK.NewAPI(client).BookIP(&ipamSDK.BookIPRequest{
+		Region: scw.RegionFrPar,
+		Source: &ipamSDK.Source{PrivateNetworkID: &pn.ID},
+	})
+	require.NoError(t, err)
+
+	return &serverHandoverFixture{
+		ipID:     ip.IP.ID,
+		volumeID: volume.Volume.ID,
+		pnID:     pn.ID,
+		ipamIPID: ipamIP.ID,
+	}
+}
+
+func (f *serverHandoverFixture) config(t *testing.T, serverType string, extraPrivateNetworkID string) cty.Value {
+	t.Helper()
+
+	privateNetworks := []any{
+		map[string]any{
+			"pn_id":       "fr-par/" + f.pnID,
+			"ipam_ip_ids": []any{"fr-par/" + f.ipamIPID},
+		},
+	}
+
+	if extraPrivateNetworkID != "" {
+		privateNetworks = append(privateNetworks, map[string]any{"pn_id": "fr-par/" + extraPrivateNetworkID})
+	}
+
+	attributes := map[string]any{
+		"image":                  ubuntuJammyImageLabel,
+		"type":                   serverType,
+		"replace_on_type_change": true,
+		"ip_ids":                 []any{"fr-par-1/" + f.ipID},
+		"additional_volume_ids":  []any{"fr-par-1/" + f.volumeID},
+		"private_network":        privateNetworks,
+		"replacement_strategy":   []any{map[string]any{"mode": instance.InstanceServerReplacementStopFirst}},
+	}
+
+	return serverConfig(t, attributes)
+}
+
+// serverConfig returns the configuration of a server from its attributes
+func serverConfig(t *testing.T, attributes map[string]any) cty.Value {
+	t.Helper()
+
+	// Terraform sends the blocks missing from the configuration as empty lists
+	r := instance.ResourceServer()
+	for name, attribute := range r.SchemaMap() {
+		if _, isBlock := attribute.Elem.(*schema.Resource); isBlock && attributes[name] == nil {
+			attributes[name] = []any{}
+		}
+	}
+
+	raw, err := json.Marshal(attributes)
+	require.NoError(t, err)
+
+	config, err := ctyjson.Unmarshal(raw, r.CoreConfigSchema().ImpliedType())
+	require.NoError(t, err)
+
+	return config
+}
+
+// planServer plans the server from prior to config, prior is nil when the server is created
+func planServer(t *testing.T, m *meta.Meta, prior *terraform.InstanceState, config cty.Value) (*terraform.InstanceState, *terraform.InstanceDiff) {
+	t.Helper()
+
+	r := instance.ResourceServer()
+
+	if prior == nil {
+		prior = &terraform.InstanceState{RawState: cty.NullVal(r.CoreConfigSchema().ImpliedType())}
+	} else {
+		var err error
+
+		prior = prior.DeepCopy()
+		prior.RawState, err = prior.AttrsAsObjectValue(r.CoreConfigSchema().ImpliedType())
+		require.NoError(t, err)
+	}
+
+	diff, err := r.Diff(t.Context(), prior, terraform.NewResourceConfigShimmed(config, r.CoreConfigSchema()), m)
+	require.NoError(t, err)
+
+	if diff != nil {
+		diff.RawConfig = config
+	}
+
+	return prior, diff
+}
+
+// applyServer creates the server planned from prior to config, prior is kept like with create_before_destroy
+func applyServer(t *testing.T, m *meta.Meta, prior *terraform.InstanceState, config cty.Value) (*terraform.InstanceState, *terraform.InstanceDiff, diag.Diagnostics) {
+	t.Helper()
+
+	_, diff := planServer(t, m, prior, config)
+
+	state, diags := instance.ResourceServer().Apply(t.Context(), &terraform.InstanceState{}, diff, m)
+
+	return state, diff, diags
+}
+
+// serverHandoverHolders returns the servers holding the IP and the volume of the fixture and the private NIC holding its IPAM IP
+func serverHandoverHolders(t *testing.T, m *meta.Meta, f *serverHandoverFixture) (ipServer string, volumeServer string, ipamResource string) {
+	t.Helper()
+
+	client := m.ScwClient()
+
+	ip, err := instanceSDK.NewAPI(client).GetIP(&instanceSDK.GetIPRequest{Zone: scw.ZoneFrPar1, IP: f.ipID})
+	require.NoError(t, err)
+
+	if ip.IP.Server != nil {
+		ipServer = ip.IP.Server.ID
+	}
+
+	volume, err := instanceSDK.NewAPI(client).GetVolume(&instanceSDK.GetVolumeRequest{Zone: scw.ZoneFrPar1, VolumeID: f.volumeID})
+	require.NoError(t, err)
+
+	if volume.Volume.Server != nil {
+		volumeServer = volume.Volume.Server.ID
+	}
+
+	ipamIP, err := ipamSDK.NewAPI(client).GetIP(&ipamSDK.GetIPRequest{Region: scw.RegionFrPar, IPID: f.ipamIPID})
+	require.NoError(t, err)
+
+	if ipamIP.Resource != nil {
+		ipamResource = ipamIP.Resource.ID
+	}
+
+	return ipServer, volumeServer, ipamResource
+}
+
+func serverPrivateNICID(t *testing.T, m *meta.Meta, serverID string, pnID string) string {
+	t.Helper()
+
+	nics, err := instanceSDK.NewAPI(m.ScwClient()).ListPrivateNICs(&instanceSDK.ListPrivateNICsRequest{
+		Zone:     scw.ZoneFrPar1,
+		ServerID: serverID,
+	})
+	require.NoError(t, err)
+
+	for _, nic := range nics.PrivateNics {
+		if nic.PrivateNetworkID == pnID {
+			return nic.ID
+		}
+	}
+
+	return ""
+}
+
+func TestServerReplacementHandover(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+	f := newServerHandoverFixture(t, m)
+	r := instance.ResourceServer()
+
+	replaced, _, diags := applyServer(t, m, nil, f.config(t, "DEV1-S", ""))
+	require.False(t, diags.HasError(), diags)
+
+	replacedID := zonal.ExpandID(replaced.ID).ID
+	assert.Equal(t, "fr-par/"+f.ipamIPID, replaced.Attributes["private_network.0.ipam_ip_ids.0"])
+
+	// Changing the type replaces the server, the plan records the server being replaced
+	server, diff, diags := applyServer(t, m, replaced, f.config(t, "DEV1-M", ""))
+	require.False(t, diags.HasError(), diags)
+	assert.True(t, diff.RequiresNew())
+	assert.Equal(t, replaced.ID, diff.Attributes["replaced_server_id"].New)
+
+	serverID := zonal.ExpandID(server.ID).ID
+	ipServer, volumeServer, ipamResource := serverHandoverHolders(t, m, f)
+	assert.Equal(t, serverID, ipServer)
+	assert.Equal(t, serverID, volumeServer)
+	assert.Equal(t, serverPrivateNICID(t, m, serverID, f.pnID), ipamResource)
+	assert.Empty(t, serverPrivateNICID(t, m, replacedID, f.pnID))
+	assert.Equal(t, "fr-par/"+f.ipamIPID, server.Attributes["private_network.0.ipam_ip_ids.0"])
+
+	replacedServer, err := instanceSDK.NewAPI(m.ScwClient()).GetServer(&instanceSDK.GetServerRequest{Zone: scw.ZoneFrPar1, ServerID: replacedID})
+	require.NoError(t, err)
+	assert.Equal(t, instanceSDK.ServerStateStopped, replacedServer.Server.State)
+
+	// Deleting the replaced server leaves the handed over resources to the new server
+	_, diags = r.Apply(t.Context(), replaced, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), diags)
+
+	ipServer, volumeServer, _ = serverHandoverHolders(t, m, f)
+	assert.Equal(t, serverID, ipServer)
+	assert.Equal(t, serverID, volumeServer)
+}
+
+func TestServerReplacementHandoverRollback(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+	f := newServerHandoverFixture(t, m)
+
+	replaced, _, diags := applyServer(t, m, nil, f.config(t, "DEV1-S", ""))
+	require.False(t, diags.HasError(), diags)
+
+	replacedID := zonal.ExpandID(replaced.ID).ID
+
+	// The new server cannot join a private network that does not exist once the resources are handed over
+	_, _, diags = applyServer(t, m, replaced, f.config(t, "DEV1-M", "00000000-0000-4000-8000-999999999999"))
+	require.True(t, diags.HasError())
+
+	ipServer, volumeServer, ipamResource := serverHandoverHolders(t, m, f)
+	assert.Equal(t, replacedID, ipServer)
+	assert.Equal(t, replacedID, volumeServer)
+	assert.Equal(t, serverPrivateNICID(t, m, replacedID, f.pnID), ipamResource)
+	assert.NotEmpty(t, ipamResource)
+
+	replacedServer, err := instanceSDK.NewAPI(m.ScwClient()).GetServer(&instanceSDK.GetServerRequest{Zone: scw.ZoneFrPar1, ServerID: replacedID})
+	require.NoError(t, err)
+	assert.Equal(t, instanceSDK.ServerStateRunning, replacedServer.Server.State)
+}
+
+func TestServerReplacementHandoverChecksHolder(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+	f := newServerHandoverFixture(t, m)
+
+	other, err := instanceSDK.NewAPI(m.ScwClient()).CreateServer(&instanceSDK.CreateServerRequest{
+		Zone:           scw.ZoneFrPar1,
+		Name:           "test-handover-other",
+		CommercialType: "DEV1-S",
+		Image:          new(ubuntuJammyImageLabel),
+		PublicIPs:      &[]string{f.ipID},
+	})
+	require.NoError(t, err)
+
+	// The IP is attached to a server which is not replaced by the new one
+	_, _, diags := applyServer(t, m, nil, f.config(t, "DEV1-S", ""))
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "which is not the server replaced by this resource")
+
+	ipServer, _, _ := serverHandoverHolders(t, m, f)
+	assert.Equal(t, other.Server.ID, ipServer)
+}
+
+// rootVolumeMigrationConfig returns the configuration of a server booting on a local volume or on a block volume
+func rootVolumeMigrationConfig(t *testing.T, volumeType string, iops int) cty.Value {
+	t.Helper()
+
+	rootVolume := map[string]any{"volume_type": volumeType, "size_in_gb": 20}
+	if iops != 0 {
+		rootVolume["sbs_iops"] = iops
+	}
+
+	return serverConfig(t, map[string]any{
+		"image":       ubuntuJammyImageLabel,
+		"type":        "DEV1-S",
+		"root_volume": []any{rootVolume},
+	})
+}
+
+func blockResources(t *testing.T, m *meta.Meta) ([]*blockSDK.Volume, []*blockSDK.Snapshot) {
+	t.Helper()
+
+	volumes, err := blockSDK.NewAPI(m.ScwClient()).ListVolumes(&blockSDK.ListVolumesRequest{Zone: scw.ZoneFrPar1})
+	require.NoError(t, err)
+
+	snapshots, err := blockSDK.NewAPI(m.ScwClient()).ListSnapshots(&blockSDK.ListSnapshotsRequest{Zone: scw.ZoneFrPar1})
+	require.NoError(t, err)
+
+	return volumes.Volumes, snapshots.Snapshots
+}
+
+func TestServerRootVolumeMigration(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+	r := instance.ResourceServer()
+
+	created, _, diags := applyServer(t, m, nil, rootVolumeMigrationConfig(t, instanceSDK.VolumeVolumeTypeLSSD.String(), 0))
+	require.False(t, diags.HasError(), diags)
+
+	// The plan names the server stopped by the migration
+	prior, diff := planServer(t, m, created, rootVolumeMigrationConfig(t, instanceSDK.VolumeVolumeTypeSbsVolume.String(), 5000))
+	assert.Equal(t, instancehelpers.VolumeUpdateImpactServerStop.String(), diff.Attributes["update_impact"].New)
+	assert.Equal(t, created.ID, diff.Attributes["servers_to_stop.0"].New)
+
+	// The apply warns about the stopped server, the planned impact is not kept in the state
+	state, diags := r.Apply(t.Context(), prior, diff, m)
+	require.False(t, diags.HasError(), diags)
+	require.NotEmpty(t, diags)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Detail, created.ID)
+	assert.Contains(t, diags[0].Detail, instancehelpers.VolumeUpdateImpactServerStop.String())
+	assert.Empty(t, state.Attributes["update_impact"])
+	assert.Empty(t, state.Attributes["servers_to_stop.0"])
+	assert.Equal(t, instanceSDK.VolumeVolumeTypeSbsVolume.String(), state.Attributes["root_volume.0.volume_type"])
+	assert.Equal(t, "started", state.Attributes["state"])
+
+	volumes, snapshots := blockResources(t, m)
+	require.Len(t, volumes, 1)
+	assert.Equal(t, zonal.ExpandID(state.Attributes["root_volume.0.volume_id"]).ID, volumes[0].ID)
+	assert.Len(t, snapshots, 1, "the migrated snapshot is kept as a backup")
+
+	// Clearing the impact of the applied update does not make a plan on its own
+	_, diff = planServer(t, m, state, rootVolumeMigrationConfig(t, instanceSDK.VolumeVolumeTypeSbsVolume.String(), 5000))
+	if diff != nil {
+		assert.Empty(t, diff.Attributes)
+	}
+}
+
+func TestServerRootVolumeMigrationCleanup(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+	r := instance.ResourceServer()
+
+	created, _, diags := applyServer(t, m, nil, rootVolumeMigrationConfig(t, instanceSDK.VolumeVolumeTypeLSSD.String(), 0))
+	require.False(t, diags.HasError(), diags)
+
+	// The block volume cannot be created with IOPS that match no performance class, the snapshot is already migrated
+	prior, diff := planServer(t, m, created, rootVolumeMigrationConfig(t, instanceSDK.VolumeVolumeTypeSbsVolume.String(), 1000))
+
+	_, diags = r.Apply(t.Context(), prior, diff, m)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "failed to create block volume from snapshot")
+
+	volumes, snapshots := blockResources(t, m)
+	assert.Empty(t, volumes)
+	assert.Empty(t, snapshots, "the snapshot of a failed migration is deleted")
+
+	server, err := instanceSDK.NewAPI(m.ScwClient()).GetServer(&instanceSDK.GetServerRequest{Zone: scw.ZoneFrPar1, ServerID: zonal.ExpandID(created.ID).ID})
+	require.NoError(t, err)
+	assert.Equal(t, instanceSDK.VolumeServerVolumeTypeLSSD, server.Server.Volumes["0"].VolumeType)
+}
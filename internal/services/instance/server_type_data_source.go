@@ -186,24 +186,13 @@ func DataSourceInstanceServerTypeRead(ctx context.Context, d *schema.ResourceDat
 	}
 
 	// Price (needs to be fetched from the Product Catalog)
-	pcuAPI := product_catalog.NewPublicCatalogAPI(meta.ExtractScwClient(i))
-
-	pcuInstances, err := pcuAPI.ListPublicCatalogProducts(&product_catalog.PublicCatalogAPIListPublicCatalogProductsRequest{
-		ProductTypes: []product_catalog.ListPublicCatalogProductsRequestProductType{
-			product_catalog.ListPublicCatalogProductsRequestProductTypeInstance,
-		},
-		Zone: &zone,
-	}, scw.WithAllPages(), scw.WithContext(ctx))
+	prices, err := listServerTypesHourlyPrices(ctx, i, zone)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	for _, pcuInstance := range pcuInstances.Products {
-		if pcuInstance.Properties.Instance.OfferID != name {
-			continue
-		}
-
-		_ = d.Set("hourly_price", pcuInstance.Price.RetailPrice.ToFloat())
+	if price, exists := prices[name]; exists {
+		_ = d.Set("hourly_price", price)
 	}
 
 	// Availability
@@ -221,6 +210,33 @@ func DataSourceInstanceServerTypeRead(ctx context.Context, d *schema.ResourceDat
 	return nil
 }
 
+// listServerTypesHourlyPrices returns the hourly price in euro of the server types of the zone, by name
+func listServerTypesHourlyPrices(ctx context.Context, m any, zone scw.Zone) (map[string]float64, error) {
+	pcuAPI := product_catalog.NewPublicCatalogAPI(meta.ExtractScwClient(m))
+
+	pcuInstances, err := pcuAPI.ListPublicCatalogProducts(&product_catalog.PublicCatalogAPIListPublicCatalogProductsRequest{
+		ProductTypes: []product_catalog.ListPublicCatalogProductsRequestProductType{
+			product_catalog.ListPublicCatalogProductsRequestProductTypeInstance,
+		},
+		Zone: &zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]float64, len(pcuInstances.Products))
+
+	for _, pcuInstance := range pcuInstances.Products {
+		if pcuInstance.Properties == nil || pcuInstance.Properties.Instance == nil || pcuInstance.Price == nil || pcuInstance.Price.RetailPrice == nil {
+			continue
+		}
+
+		prices[pcuInstance.Properties.Instance.OfferID] = pcuInstance.Price.RetailPrice.ToFloat()
+	}
+
+	return prices, nil
+}
+
 func flattenVolumeConstraints(serverType *instance.ServerType) []map[string]any {
 	flattened := map[string]any{}
 
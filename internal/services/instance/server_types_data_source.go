@@ -0,0 +1,340 @@
+package instance
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func DataSourceServerTypes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceInstanceServerTypesRead,
+		SchemaFunc:  serverTypesSchema,
+	}
+}
+
+func serverTypesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"min_cpu": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The minimum number of CPU cores of the server types",
+		},
+		"min_ram": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The minimum number of bytes of RAM of the server types",
+		},
+		"arch": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: verify.ValidateEnum[instance.Arch](),
+			Description:      "The architecture of the server types",
+		},
+		"min_gpu": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The minimum number of GPUs of the server types",
+		},
+		"max_gpu": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      -1,
+			ValidateFunc: validation.IntAtLeast(-1),
+			Description:  "The maximum number of GPUs of the server types, -1 means no limit",
+		},
+		"min_block_bandwidth": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The minimum block bandwidth in bytes per second of the server types",
+		},
+		"max_hourly_price": {
+			Type:         schema.TypeFloat,
+			Optional:     true,
+			ValidateFunc: validation.FloatAtLeast(0),
+			Description:  "The maximum hourly price in euro of the server types",
+		},
+		"availabilities": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "The accepted availabilities of the server types, only available server types are returned by default",
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: verify.ValidateEnum[instance.ServerTypesAvailability](),
+			},
+		},
+		"include_end_of_service": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to return the server types that will soon reach End Of Service",
+		},
+		"server_types": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The server types matching the requirements, sorted by hourly price",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the server type",
+					},
+					"arch": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The architecture of the server type",
+					},
+					"cpu": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The number of CPU cores of the server type",
+					},
+					"ram": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The number of bytes of RAM of the server type",
+					},
+					"gpu": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The number of GPUs of the server type",
+					},
+					"block_bandwidth": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The block bandwidth of the server type",
+					},
+					"hourly_price": {
+						Type:        schema.TypeFloat,
+						Computed:    true,
+						Description: "The hourly price of the server type in euro",
+					},
+					"availability": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Whether the server type is available in the zone",
+					},
+				},
+			},
+		},
+		"best": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the cheapest server type matching the requirements",
+		},
+		"zone": zonal.Schema(),
+	}
+}
+
+// serverTypeCandidate is a server type of the zone with its price and availability
+type serverTypeCandidate struct {
+	name         string
+	serverType   *instance.ServerType
+	hourlyPrice  *float64
+	availability instance.ServerTypesAvailability
+}
+
+// serverTypesFilter holds the requirements of the scaleway_instance_server_types data source
+type serverTypesFilter struct {
+	minCPU              uint32
+	minRAM              uint64
+	arch                instance.Arch
+	minGPU              uint64
+	maxGPU              *uint64
+	minBlockBandwidth   uint64
+	maxHourlyPrice      *float64
+	availabilities      []instance.ServerTypesAvailability
+	includeEndOfService bool
+}
+
+func expandServerTypesFilter(d *schema.ResourceData) *serverTypesFilter {
+	filter := &serverTypesFilter{
+		minCPU:              uint32(d.Get("min_cpu").(int)),
+		minRAM:              uint64(d.Get("min_ram").(int)),
+		arch:                instance.Arch(d.Get("arch").(string)),
+		minGPU:              uint64(d.Get("min_gpu").(int)),
+		minBlockBandwidth:   uint64(d.Get("min_block_bandwidth").(int)),
+		includeEndOfService: d.Get("include_end_of_service").(bool),
+		availabilities:      []instance.ServerTypesAvailability{instance.ServerTypesAvailabilityAvailable},
+	}
+
+	if maxGPU := d.Get("max_gpu").(int); maxGPU >= 0 {
+		filter.maxGPU = new(uint64(maxGPU))
+	}
+
+	if maxHourlyPrice, ok := d.GetOk("max_hourly_price"); ok {
+		filter.maxHourlyPrice = new(maxHourlyPrice.(float64))
+	}
+
+	if availabilities := types.ExpandStrings(d.Get("availabilities")); len(availabilities) > 0 {
+		filter.availabilities = make([]instance.ServerTypesAvailability, 0, len(availabilities))
+		for _, availability := range availabilities {
+			filter.availabilities = append(filter.availabilities, instance.ServerTypesAvailability(availability))
+		}
+	}
+
+	return filter
+}
+
+func (f *serverTypesFilter) match(candidate *serverTypeCandidate) bool {
+	serverType := candidate.serverType
+
+	gpu := uint64(0)
+	if serverType.Gpu != nil {
+		gpu = *serverType.Gpu
+	}
+
+	blockBandwidth := uint64(0)
+	if serverType.BlockBandwidth != nil {
+		blockBandwidth = *serverType.BlockBandwidth
+	}
+
+	switch {
+	case serverType.Ncpus < f.minCPU,
+		serverType.RAM < f.minRAM,
+		f.arch != "" && serverType.Arch != f.arch,
+		gpu < f.minGPU,
+		f.maxGPU != nil && gpu > *f.maxGPU,
+		blockBandwidth < f.minBlockBandwidth,
+		serverType.EndOfService && !f.includeEndOfService:
+		return false
+	}
+
+	// A server type without a known price cannot be compared with a maximum price
+	if f.maxHourlyPrice != nil && (candidate.hourlyPrice == nil || *candidate.hourlyPrice > *f.maxHourlyPrice) {
+		return false
+	}
+
+	for _, availability := range f.availabilities {
+		if candidate.availability == availability {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortServerTypeCandidates sorts the candidates by hourly price, the ones without a known price are last.
+// Candidates with the same price are sorted by name so that the result is stable between reads.
+func sortServerTypeCandidates(candidates []*serverTypeCandidate) {
+	sort.Slice(candidates, func(i, j int) bool {
+		priceI, priceJ := candidates[i].hourlyPrice, candidates[j].hourlyPrice
+
+		switch {
+		case priceI != nil && priceJ == nil:
+			return true
+		case priceI == nil && priceJ != nil:
+			return false
+		case priceI != nil && *priceI != *priceJ:
+			return *priceI < *priceJ
+		default:
+			return candidates[i].name < candidates[j].name
+		}
+	})
+}
+
+func flattenServerTypeCandidates(candidates []*serverTypeCandidate) []map[string]any {
+	flattened := make([]map[string]any, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		serverType := candidate.serverType
+		flat := map[string]any{
+			"name":         candidate.name,
+			"arch":         serverType.Arch.String(),
+			"cpu":          int(serverType.Ncpus),
+			"ram":          int(serverType.RAM),
+			"availability": candidate.availability.String(),
+		}
+
+		if serverType.Gpu != nil {
+			flat["gpu"] = int(*serverType.Gpu)
+		}
+
+		if serverType.BlockBandwidth != nil {
+			flat["block_bandwidth"] = int(*serverType.BlockBandwidth)
+		}
+
+		if candidate.hourlyPrice != nil {
+			flat["hourly_price"] = *candidate.hourlyPrice
+		}
+
+		flattened = append(flattened, flat)
+	}
+
+	return flattened
+}
+
+func DataSourceInstanceServerTypesRead(ctx context.Context, d *schema.ResourceData, i any) diag.Diagnostics {
+	instanceAPI, zone, err := newAPIWithZone(d, i)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverTypes, err := instanceAPI.ListServersTypes(&instance.ListServersTypesRequest{
+		Zone: zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	prices, err := listServerTypesHourlyPrices(ctx, i, zone)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	availabilitiesResponse, err := instanceAPI.GetServerTypesAvailability(&instance.GetServerTypesAvailabilityRequest{
+		Zone: zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter := expandServerTypesFilter(d)
+	candidates := []*serverTypeCandidate(nil)
+
+	for name, serverType := range serverTypes.Servers {
+		candidate := &serverTypeCandidate{
+			name:       name,
+			serverType: serverType,
+		}
+
+		if price, exists := prices[name]; exists {
+			candidate.hourlyPrice = new(price)
+		}
+
+		if availability, exists := availabilitiesResponse.Servers[name]; exists {
+			candidate.availability = availability.Availability
+		}
+
+		if filter.match(candidate) {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	sortServerTypeCandidates(candidates)
+
+	d.SetId(zone.String())
+	_ = d.Set("zone", zone)
+	_ = d.Set("server_types", flattenServerTypeCandidates(candidates))
+
+	if len(candidates) > 0 {
+		_ = d.Set("best", candidates[0].name)
+	} else {
+		_ = d.Set("best", "")
+	}
+
+	return nil
+}
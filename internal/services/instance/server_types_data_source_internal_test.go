@@ -0,0 +1,93 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerTypesFilter(t *testing.T) {
+	candidates := []*serverTypeCandidate{
+		{
+			name:         "DEV1-S",
+			serverType:   &instance.ServerType{Ncpus: 2, RAM: 2 * gb, Arch: instance.ArchX86_64},
+			hourlyPrice:  new(0.01),
+			availability: instance.ServerTypesAvailabilityAvailable,
+		},
+		{
+			name:         "PRO2-XS",
+			serverType:   &instance.ServerType{Ncpus: 4, RAM: 16 * gb, Arch: instance.ArchX86_64},
+			hourlyPrice:  new(0.1),
+			availability: instance.ServerTypesAvailabilityShortage,
+		},
+		{
+			name:         "PLAY2-MICRO",
+			serverType:   &instance.ServerType{Ncpus: 4, RAM: 8 * gb, Arch: instance.ArchX86_64},
+			hourlyPrice:  new(0.05),
+			availability: instance.ServerTypesAvailabilityScarce,
+		},
+		{
+			name:         "COPARM1-4C-16G",
+			serverType:   &instance.ServerType{Ncpus: 4, RAM: 16 * gb, Arch: instance.ArchArm64},
+			hourlyPrice:  new(0.04),
+			availability: instance.ServerTypesAvailabilityAvailable,
+		},
+		{
+			name:         "RENDER-S",
+			serverType:   &instance.ServerType{Ncpus: 10, RAM: 42 * gb, Arch: instance.ArchX86_64, Gpu: new(uint64(1))},
+			hourlyPrice:  new(1.2),
+			availability: instance.ServerTypesAvailabilityAvailable,
+		},
+		{
+			name:         "START1-M",
+			serverType:   &instance.ServerType{Ncpus: 4, RAM: 4 * gb, Arch: instance.ArchX86_64, EndOfService: true},
+			hourlyPrice:  new(0.02),
+			availability: instance.ServerTypesAvailabilityAvailable,
+		},
+	}
+
+	matching := func(filter *serverTypesFilter) []string {
+		names := []string(nil)
+
+		for _, candidate := range candidates {
+			if filter.match(candidate) {
+				names = append(names, candidate.name)
+			}
+		}
+
+		return names
+	}
+
+	available := []instance.ServerTypesAvailability{instance.ServerTypesAvailabilityAvailable}
+
+	assert.Equal(t, []string{"COPARM1-4C-16G", "RENDER-S"}, matching(&serverTypesFilter{minCPU: 4, availabilities: available}))
+	assert.Equal(t, []string{"RENDER-S"}, matching(&serverTypesFilter{minGPU: 1, availabilities: available}))
+	assert.Equal(t, []string{"DEV1-S", "COPARM1-4C-16G"}, matching(&serverTypesFilter{maxGPU: new(uint64(0)), availabilities: available}))
+	assert.Equal(t, []string{"DEV1-S"}, matching(&serverTypesFilter{arch: instance.ArchX86_64, maxHourlyPrice: new(0.5), availabilities: available}))
+	assert.Equal(t, []string{"COPARM1-4C-16G", "START1-M"}, matching(&serverTypesFilter{minCPU: 4, maxHourlyPrice: new(0.5), includeEndOfService: true, availabilities: available}))
+	assert.Equal(t, []string{"PLAY2-MICRO", "COPARM1-4C-16G"}, matching(&serverTypesFilter{
+		minRAM:         8 * gb,
+		maxHourlyPrice: new(0.5),
+		availabilities: []instance.ServerTypesAvailability{instance.ServerTypesAvailabilityAvailable, instance.ServerTypesAvailabilityScarce},
+	}))
+}
+
+func TestSortServerTypeCandidates(t *testing.T) {
+	candidates := []*serverTypeCandidate{
+		{name: "UNKNOWN"},
+		{name: "PRO2-S", hourlyPrice: new(0.2)},
+		{name: "DEV1-L", hourlyPrice: new(0.1)},
+		{name: "DEV1-M", hourlyPrice: new(0.1)},
+		{name: "DEV1-S", hourlyPrice: new(0.01)},
+	}
+
+	sortServerTypeCandidates(candidates)
+
+	names := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		names = append(names, candidate.name)
+	}
+
+	assert.Equal(t, []string{"DEV1-S", "DEV1-L", "DEV1-M", "PRO2-S", "UNKNOWN"}, names)
+}
@@ -7,7 +7,9 @@ import (
 	"io"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
@@ -37,7 +39,11 @@ func ResourceUserData() *schema.Resource {
 		SchemaVersion: 0,
 		SchemaFunc:    userDataSchema,
 		Identity:      identity.DefaultZonal(),
-		CustomizeDiff: cdf.LocalityCheck("server_id"),
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("server_id"),
+			customDiffCloudInitConfig,
+			customDiffInstanceUserDataValue,
+		),
 	}
 }
 
@@ -55,11 +61,15 @@ func userDataSchema() map[string]*schema.Schema {
 			Description: "The key of the user data to set.",
 		},
 		"value": {
-			Type:        schema.TypeString,
-			Required:    true,
-			Description: "The value of the user data to set.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "The value of the user data to set.",
+			ValidateFunc: validation.StringLenBetween(0, cloudInitMaxSize),
+			ExactlyOneOf: []string{"value", "cloud_init_config"},
 		},
-		"zone": zonal.Schema(),
+		"cloud_init_config": userDataCloudInitConfigSchema(),
+		"zone":              zonal.Schema(),
 	}
 }
 
@@ -77,7 +87,11 @@ func ResourceInstanceUserDataCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	key := d.Get("key").(string)
-	value := bytes.NewBufferString(d.Get("value").(string))
+
+	value, err := expandUserDataValue(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	userDataRequest := &instanceSDK.SetServerUserDataRequest{
 		Zone:     zone,
@@ -123,9 +137,48 @@ func setUserDataState(d *schema.ResourceData, serverUserDataRawValue io.Reader,
 	_ = d.Set("value", string(userDataValue))
 	_ = d.Set("zone", zone.String())
 
+	if cloudInitConfig, ok := d.GetOk("cloud_init_config"); ok {
+		flatCloudInitConfig, err := flattenCloudInitConfig(string(userDataValue), cloudInitConfig)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to parse cloud-init of user data: %w", err))
+		}
+
+		_ = d.Set("cloud_init_config", flatCloudInitConfig)
+	}
+
 	return nil
 }
 
+func userDataCloudInitConfigSchema() *schema.Schema {
+	s := cloudInitConfigSchema()
+	s.ExactlyOneOf = []string{"value", "cloud_init_config"}
+
+	return s
+}
+
+// customDiffInstanceUserDataValue marks the value as changed when it is rendered from a changed cloud_init_config block
+func customDiffInstanceUserDataValue(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if _, ok := diff.GetOk("cloud_init_config"); !ok || !diff.HasChange("cloud_init_config") {
+		return nil
+	}
+
+	return diff.SetNewComputed("value")
+}
+
+// expandUserDataValue returns the value to set, rendered from the cloud_init_config block if any
+func expandUserDataValue(d *schema.ResourceData) (io.Reader, error) {
+	if cloudInitConfig, ok := d.GetOk("cloud_init_config"); ok {
+		cloudInit, err := renderCloudInitConfig(cloudInitConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return bytes.NewBufferString(cloudInit), nil
+	}
+
+	return bytes.NewBufferString(d.Get("value").(string)), nil
+}
+
 func ResourceInstanceUserDataRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	instanceAPI, zone, id, key, err := NewAPIWithZoneAndNestedID(m, d.Id())
 	if err != nil {
@@ -188,9 +241,11 @@ func ResourceInstanceUserDataUpdate(ctx context.Context, d *schema.ResourceData,
 		userDataRequest.Zone = scw.Zone(v.(string))
 	}
 
-	if d.HasChanges("value") {
-		value := d.Get("value")
-		userDataRequest.Content = bytes.NewBufferString(value.(string))
+	if d.HasChanges("value", "cloud_init_config") {
+		userDataRequest.Content, err = expandUserDataValue(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	err = instanceAPI.SetServerUserData(userDataRequest, scw.WithContext(ctx))
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -37,9 +38,12 @@ func ResourceVolume() *schema.Resource {
 			Delete:  schema.DefaultTimeout(defaultInstanceVolumeDeleteTimeout),
 			Default: schema.DefaultTimeout(defaultInstanceVolumeDeleteTimeout),
 		},
-		SchemaFunc:    volumeSchema,
-		Identity:      identity.DefaultZonal(),
-		CustomizeDiff: cdf.LocalityCheck("from_snapshot_id"),
+		SchemaFunc: volumeSchema,
+		Identity:   identity.DefaultZonal(),
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("from_snapshot_id"),
+			customDiffInstanceVolumeUpdateImpact,
+		),
 	}
 }
 
@@ -91,12 +95,48 @@ func volumeSchema() map[string]*schema.Schema {
 			Default:     false,
 			Description: "If true, consider that this volume may have been migrated and no longer exists.",
 		},
+		"update_impact": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The impact of the planned update of the volume on the server it is attached to (online), cleared once the update is applied",
+		},
 		"organization_id": account.OrganizationIDSchema(),
 		"project_id":      account.ProjectIDSchema(),
 		"zone":            zonal.Schema(),
 	}
 }
 
+// customDiffInstanceVolumeUpdateImpact checks during plan that the volume can be resized and shows the impact of the resize.
+// Volumes that cannot be resized are not replaced to avoid losing their data, the plan fails instead.
+func customDiffInstanceVolumeUpdateImpact(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if diff.Id() == "" || diff.Get("migrate_to_sbs").(bool) {
+		return nil
+	}
+
+	oldSize, newSize := diff.GetChange("size_in_gb")
+	volumeType := instanceSDK.VolumeVolumeType(diff.Get("type").(string))
+
+	impact := instancehelpers.VolumeUpdateImpactNone
+
+	if diff.HasChange("size_in_gb") {
+		impact = instancehelpers.ClassifyVolumeUpdate(instancehelpers.VolumeUpdate{
+			VolumeType: volumeType,
+			Size:       oldSize.(int),
+			NewSize:    newSize.(int),
+		})
+	}
+
+	if impact == instancehelpers.VolumeUpdateImpactDestructive {
+		if volumeType != instanceSDK.VolumeVolumeTypeBSSD {
+			return fmt.Errorf("only block volume can be resized, %s volumes must be replaced", volumeType)
+		}
+
+		return errors.New("block volumes cannot be resized down")
+	}
+
+	return instancehelpers.SetPlannedUpdate(diff, "update_impact", impact.String(), impact == instancehelpers.VolumeUpdateImpactNone)
+}
+
 func ResourceInstanceVolumeCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	instanceAPI, zone, err := newAPIWithZone(d, m)
 	if err != nil {
@@ -154,6 +194,8 @@ func setVolumeState(d *schema.ResourceData, volume *instanceSDK.Volume) diag.Dia
 	_ = d.Set("zone", volume.Zone)
 	_ = d.Set("type", volume.VolumeType.String())
 	_ = d.Set("tags", volume.Tags)
+	// The update impact only describes the plan, it is not kept once the update is applied
+	_ = d.Set("update_impact", "")
 
 	_, fromSnapshot := d.GetOk("from_snapshot_id")
 	if !fromSnapshot {
@@ -16,6 +16,7 @@ import (
 func DataSourceVolume() *schema.Resource {
 	// Generate datasource schema from resource
 	dsSchema := datasource.SchemaFromResourceSchema(ResourceVolume().SchemaFunc())
+	delete(dsSchema, "update_impact")
 
 	// Set 'Optional' schema elements
 	datasource.AddOptionalFieldsToSchema(dsSchema, "name", "zone", "project_id")
@@ -0,0 +1,45 @@
+package instance
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/listresource"
+)
+
+//go:embed descriptions/volume_list_resource.md
+var volumeListResourceDescription string
+
+func NewVolumeListResource() list.ListResource {
+	return listresource.NewZonal(listresource.Config[scw.Zone, *instanceSDK.Volume]{
+		TypeName:    "instance_volume",
+		Description: volumeListResourceDescription,
+		Resource:    ResourceVolume,
+		List: func(ctx context.Context, client *scw.Client, zone scw.Zone, filters listresource.Filters) ([]*instanceSDK.Volume, error) {
+			res, err := instanceSDK.NewAPI(client).ListVolumes(&instanceSDK.ListVolumesRequest{
+				Zone:    zone,
+				Project: filters.ProjectID,
+				Name:    filters.Name,
+				Tags:    filters.Tags,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			return res.Volumes, nil
+		},
+		Flatten: func(volume *instanceSDK.Volume) listresource.Item {
+			return listresource.Item{
+				DisplayName: volume.Name,
+				SetIdentity: func(d *sdkschema.ResourceData) error {
+					return identity.SetZonalIdentity(d, volume.Zone, volume.ID)
+				},
+			}
+		},
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -21,6 +22,13 @@ import (
 //go:embed descriptions/acl.md
 var aclDescription string
 
+func aclIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":     identity.DefaultRegionAttribute(),
+		"cluster_id": {Type: schema.TypeString, Description: "The ID of the cluster (UUID format)", RequiredForImport: true},
+	})
+}
+
 func ResourceACL() *schema.Resource {
 	return &schema.Resource{
 		Description:   aclDescription,
@@ -29,7 +37,7 @@ func ResourceACL() *schema.Resource {
 		UpdateContext: ResourceACLUpdate,
 		DeleteContext: ResourceACLDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "cluster_id"),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create:  schema.DefaultTimeout(defaultK8SClusterTimeout),
@@ -40,6 +48,7 @@ func ResourceACL() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    aclSchema,
+		Identity:      aclIdentity(),
 		CustomizeDiff: cdf.LocalityCheck("cluster_id"),
 	}
 }
@@ -129,8 +138,13 @@ func ResourceACLCreate(ctx context.Context, d *schema.ResourceData, m any) diag.
 		return diag.FromErr(err)
 	}
 
-	regionalID := regional.NewID(region, clusterID).String()
-	d.SetId(regionalID)
+	err = identity.SetMultiPartIdentity(d, map[string]string{
+		"region":     region.String(),
+		"cluster_id": clusterID,
+	}, "region", "cluster_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ResourceACLRead(ctx, d, m)
 }
@@ -164,7 +178,10 @@ func ResourceACLRead(ctx context.Context, d *schema.ResourceData, m any) diag.Di
 	_ = d.Set("region", region)
 	_ = d.Set("acl_rules", flattenACL(acls.Rules))
 
-	return nil
+	return diag.FromErr(identity.SetMultiPartIdentity(d, map[string]string{
+		"region":     region.String(),
+		"cluster_id": clusterID,
+	}, "region", "cluster_id"))
 }
 
 func ResourceACLUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
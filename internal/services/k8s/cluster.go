@@ -15,11 +15,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/logging"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -54,6 +57,7 @@ func ResourceCluster() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    clusterSchema,
+		Identity:      identity.DefaultRegional(),
 		CustomizeDiff: customdiff.All(
 			func(_ context.Context, diff *schema.ResourceDiff, _ any) error {
 				autoUpgradeEnable, okAutoUpgradeEnable := diff.GetOkExists("auto_upgrade.0.enable")
@@ -141,6 +145,8 @@ func ResourceCluster() *schema.Resource {
 
 				return nil
 			},
+			customDiffClusterUpgradePath,
+			customDiffClusterNetworkOverlap,
 		),
 	}
 }
@@ -266,6 +272,12 @@ func clusterSchema() map[string]*schema.Schema {
 			Required:    true,
 			Description: "Delete additional resources like block volumes, load-balancers and the private network (if empty) on cluster deletion",
 		},
+		"skip_kubeconfig": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Do not read the admin kubeconfig and keep it out of the state. Use the scaleway_k8s_cluster_kubeconfig ephemeral resource instead",
+		},
 		"private_network_id": {
 			Type:             schema.TypeString,
 			Optional:         true,
@@ -351,6 +363,14 @@ func clusterSchema() map[string]*schema.Schema {
 				},
 			},
 		},
+		"upgrade_path": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The versions the cluster goes through during its last version upgrade, one minor at a time",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
 		"upgrade_available": {
 			Type:        schema.TypeBool,
 			Computed:    true,
@@ -571,7 +591,10 @@ func ResourceK8SClusterCreate(ctx context.Context, d *schema.ResourceData, m any
 		return append(diag.FromErr(err), diags...)
 	}
 
-	d.SetId(regional.NewIDString(region, res.ID))
+	err = identity.SetRegionalIdentity(d, region, res.ID)
+	if err != nil {
+		return append(diag.FromErr(err), diags...)
+	}
 
 	if strings.Contains(clusterType.(string), "multicloud") {
 		// In case of multi-cloud, we do not have the guarantee that a pool will be created in Scaleway.
@@ -608,6 +631,11 @@ func ResourceK8SClusterRead(ctx context.Context, d *schema.ResourceData, m any)
 		return diag.FromErr(err)
 	}
 
+	err = identity.SetRegionalIdentity(d, region, cluster.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_ = d.Set("region", string(region))
 	_ = d.Set("name", cluster.Name)
 	_ = d.Set("type", cluster.Type)
@@ -653,6 +681,12 @@ func ResourceK8SClusterRead(ctx context.Context, d *schema.ResourceData, m any)
 	////
 	// Read kubeconfig
 	////
+	if d.Get("skip_kubeconfig").(bool) {
+		_ = d.Set("kubeconfig", nil)
+
+		return nil
+	}
+
 	kubeconfig, err := flattenKubeconfig(ctx, k8sAPI, region, clusterID)
 	if err != nil {
 		if httperrors.Is403(err) {
@@ -712,6 +746,7 @@ func ResourceK8SClusterUpdate(ctx context.Context, d *schema.ResourceData, m any
 	}
 
 	canUpgrade := false
+	currentVersion := ""
 
 	////
 	// Construct UpdateClusterRequest
@@ -793,6 +828,8 @@ func ResourceK8SClusterUpdate(ctx context.Context, d *schema.ResourceData, m any
 			return append(diag.FromErr(err), diags...)
 		}
 
+		currentVersion = clusterResp.Version
+
 		if clusterResp.Version == version {
 			// no upgrades if same version
 			canUpgrade = false
@@ -912,31 +949,19 @@ func ResourceK8SClusterUpdate(ctx context.Context, d *schema.ResourceData, m any
 	// Upgrade if needed
 	////
 	if canUpgrade {
-		upgradeRequest := &k8s.UpgradeClusterRequest{
-			Region:       region,
-			ClusterID:    clusterID,
-			Version:      version,
-			UpgradePools: true,
-		}
-
-		_, err = k8sAPI.UpgradeCluster(upgradeRequest)
+		upgradePath, err := clusterUpgradePath(currentVersion, version)
 		if err != nil {
 			return append(diag.FromErr(err), diags...)
 		}
 
-		_, err = waitCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutUpdate))
+		// In case of multi-cloud, we do not have the guarantee that a pool will be created in Scaleway.
+		// But if we are not, we can wait for the pools to be upgraded.
+		waitPools := !strings.Contains(d.Get("type").(string), "multicloud")
+
+		err = upgradeClusterAlongPath(ctx, k8sAPI, region, clusterID, upgradePath, waitPools, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return append(diag.FromErr(err), diags...)
 		}
-
-		if !strings.Contains(d.Get("type").(string), "multicloud") {
-			// In case of multi-cloud, we do not have the guarantee that a pool will be created in Scaleway.
-			// But if we are not, we can wait for the pool to be upgraded.
-			_, err = waitClusterPool(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutUpdate))
-			if err != nil {
-				return append(diag.FromErr(err), diags...)
-			}
-		}
 	}
 
 	// Display warning if an update of networking fields is requested
@@ -1105,3 +1130,87 @@ func openIDConnectConfigSchema() *schema.Resource {
 		},
 	}
 }
+
+// customDiffClusterUpgradePath plans the upgrade path of a version change so that it shows up in the plan.
+// Intermediate minor versions are resolved to their latest patch version during apply.
+func customDiffClusterUpgradePath(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if diff.Id() == "" || !diff.HasChange("version") || !diff.NewValueKnown("version") {
+		return nil
+	}
+
+	oldVersion, newVersion := diff.GetChange("version")
+
+	upgradePath, err := clusterUpgradePath(oldVersion.(string), newVersion.(string))
+	if err != nil {
+		return err
+	}
+
+	return diff.SetNew("upgrade_path", upgradePath)
+}
+
+// customDiffClusterNetworkOverlap checks at plan time that the pod and service CIDRs do not overlap the subnets of the
+// private networks of the VPC, when the cluster is created or moved to another private network, and that a cluster
+// is only moved to a private network of its current VPC.
+// The check is skipped when the private networks cannot be listed, the API still validating the CIDRs on apply.
+func customDiffClusterNetworkOverlap(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+	if diff.Id() != "" && !diff.HasChanges("private_network_id", "pod_cidr", "service_cidr") {
+		return nil
+	}
+
+	if !diff.NewValueKnown("private_network_id") {
+		return nil
+	}
+
+	privateNetworkID := locality.ExpandID(diff.Get("private_network_id"))
+	if privateNetworkID == "" {
+		return nil
+	}
+
+	// Attaching a first private network migrates the cluster in place and keeps its CIDRs,
+	// any other change recreates the cluster with the configured CIDRs or the default ones
+	oldPrivateNetworkID, _ := diff.GetChange("private_network_id")
+	inPlace := diff.Id() != "" && oldPrivateNetworkID == ""
+
+	cidrs := make(map[string]string)
+
+	for _, key := range []string{"pod_cidr", "service_cidr"} {
+		if !diff.NewValueKnown(key) {
+			continue
+		}
+
+		raw, _ := meta.GetRawConfigForKey(diff, key, cty.String)
+		configured, _ := raw.(string)
+
+		switch {
+		case configured != "":
+			cidrs[key] = configured
+		case inPlace:
+			cidrs[key] = diff.Get(key).(string)
+		default:
+			cidrs[key] = NetworkingDefaultValues[key]
+		}
+	}
+
+	region, err := meta.ExtractRegion(diff, m)
+	if err != nil {
+		return err
+	}
+
+	vpcAPI := vpc.NewAPI(meta.ExtractScwClient(m))
+
+	privateNetworks, err := listVPCPrivateNetworks(ctx, vpcAPI, region, privateNetworkID)
+	if err != nil {
+		logging.L.Debugf("skipping the check of the cluster CIDRs against the VPC subnets: %s", err)
+
+		return nil
+	}
+
+	oldID := locality.ExpandID(oldPrivateNetworkID)
+	if diff.Id() != "" && oldID != "" && oldID != privateNetworkID {
+		if err := clusterSameVPC(ctx, vpcAPI, region, oldID, privateNetworkID, privateNetworks); err != nil {
+			return err
+		}
+	}
+
+	return clusterCIDROverlap(cidrs, privateNetworks)
+}
@@ -21,8 +21,9 @@ func DataSourceCluster() *schema.Resource {
 	dsSchema := datasource.SchemaFromResourceSchema(ResourceCluster().SchemaFunc())
 
 	// Set 'Optional' schema elements
-	datasource.AddOptionalFieldsToSchema(dsSchema, "name", "region", "project_id")
+	datasource.AddOptionalFieldsToSchema(dsSchema, "name", "region", "project_id", "skip_kubeconfig")
 	delete(dsSchema, "delete_additional_resources")
+	delete(dsSchema, "upgrade_path")
 
 	dsSchema["name"].ConflictsWith = []string{"cluster_id"}
 	dsSchema["cluster_id"] = &schema.Schema{
@@ -0,0 +1,359 @@
+package k8s
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+	"go.yaml.in/yaml/v4"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = (*ClusterKubeconfigEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*ClusterKubeconfigEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithClose     = (*ClusterKubeconfigEphemeralResource)(nil)
+)
+
+const (
+	defaultClusterKubeconfigTokenTTL = time.Hour
+	// clusterKubeconfigPrivateAccessKey is the private data key holding the access key of the minted IAM token
+	clusterKubeconfigPrivateAccessKey = "iam_access_key"
+)
+
+type ClusterKubeconfigEphemeralResource struct {
+	k8sAPI *k8s.API
+	iamAPI *iam.API
+	meta   *meta.Meta
+}
+
+func NewClusterKubeconfigEphemeralResource() ephemeral.EphemeralResource {
+	return &ClusterKubeconfigEphemeralResource{}
+}
+
+func (r *ClusterKubeconfigEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	m, ok := req.ProviderData.(*meta.Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *meta.Meta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	client := m.ScwClient()
+	r.k8sAPI = k8s.NewAPI(client)
+	r.iamAPI = iam.NewAPI(client)
+	r.meta = m
+}
+
+func (r *ClusterKubeconfigEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k8s_cluster_kubeconfig"
+}
+
+type ClusterKubeconfigEphemeralResourceModel struct {
+	ClusterID     types.String `tfsdk:"cluster_id"`
+	Region        types.String `tfsdk:"region"`
+	UseIAMToken   types.Bool   `tfsdk:"use_iam_token"`
+	ApplicationID types.String `tfsdk:"application_id"`
+	TokenTTL      types.String `tfsdk:"token_ttl"`
+	// Output
+	ConfigFile           types.String `tfsdk:"config_file"`
+	Host                 types.String `tfsdk:"host"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	Token                types.String `tfsdk:"token"`
+	ExpiresAt            types.String `tfsdk:"expires_at"`
+}
+
+//go:embed descriptions/cluster_kubeconfig_ephemeral_resource.md
+var clusterKubeconfigEphemeralResourceDescription string
+
+func (r *ClusterKubeconfigEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         clusterKubeconfigEphemeralResourceDescription,
+		MarkdownDescription: clusterKubeconfigEphemeralResourceDescription,
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the Kubernetes cluster",
+				Validators: []validator.String{
+					verify.IsStringUUIDOrUUIDWithLocality(),
+				},
+			},
+			"region": regional.SchemaAttribute("The region of the cluster. If not set, the region is derived from the cluster_id when possible or from the provider configuration."),
+			"use_iam_token": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Mint a short-lived IAM API key and use its secret key as token instead of the cluster admin token",
+			},
+			"application_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of the IAM application the token is minted for. Defaults to the principal of the provider credentials",
+				Validators: []validator.String{
+					verify.IsStringUUID(),
+					stringvalidator.AlsoRequires(path.MatchRoot("use_iam_token")),
+				},
+			},
+			"token_ttl": schema.StringAttribute{
+				Optional:    true,
+				Description: "Lifetime of the IAM token as a duration (e.g. `30m`). Defaults to `1h`",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("use_iam_token")),
+				},
+			},
+			"config_file": schema.StringAttribute{
+				Computed:    true,
+				Description: "The whole kubeconfig file",
+				Sensitive:   true,
+			},
+			"host": schema.StringAttribute{
+				Computed:    true,
+				Description: "The kubernetes master URL",
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				Computed:    true,
+				Description: "The kubernetes cluster CA certificate",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Description: "The token used to authenticate against the cluster, either the admin token or the IAM token",
+				Sensitive:   true,
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The date and time of the expiration of the IAM token (RFC 3339 format). Empty for the admin token",
+			},
+		},
+	}
+}
+
+func (r *ClusterKubeconfigEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ClusterKubeconfigEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.k8sAPI == nil || r.iamAPI == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured k8sAPI",
+			"The ephemeral resource was not properly configured. The Scaleway client is missing. "+
+				"This is usually a bug in the provider. Please report it to the maintainers.",
+		)
+
+		return
+	}
+
+	region, clusterID, err := regional.ParseID(data.ClusterID.ValueString())
+	if err != nil {
+		clusterID = data.ClusterID.ValueString()
+		region, _ = r.meta.ScwClient().GetDefaultRegion()
+	}
+
+	if !data.Region.IsNull() && !data.Region.IsUnknown() {
+		region = scw.Region(data.Region.ValueString())
+	}
+
+	if region == "" {
+		resp.Diagnostics.AddError(
+			"Missing region",
+			"The region attribute is required to read the kubeconfig. Please provide it explicitly, use a regional cluster_id or configure a default region in the provider.",
+		)
+
+		return
+	}
+
+	useIAMToken := data.UseIAMToken.ValueBool()
+
+	kubeconfig, err := r.k8sAPI.GetClusterKubeConfig(&k8s.GetClusterKubeConfigRequest{
+		Region:    region,
+		ClusterID: clusterID,
+		Redacted:  new(useIAMToken),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading cluster kubeconfig",
+			fmt.Sprintf("Failed to read kubeconfig of cluster %s: %s", clusterID, err),
+		)
+
+		return
+	}
+
+	host, err := kubeconfig.GetServer()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cluster kubeconfig", err.Error())
+
+		return
+	}
+
+	caCertificate, err := kubeconfig.GetCertificateAuthorityData()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cluster kubeconfig", err.Error())
+
+		return
+	}
+
+	data.Host = types.StringValue(host)
+	data.ClusterCACertificate = types.StringValue(caCertificate)
+	data.ExpiresAt = types.StringValue("")
+
+	if !useIAMToken {
+		token, err := kubeconfig.GetToken()
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading cluster kubeconfig", err.Error())
+
+			return
+		}
+
+		data.ConfigFile = types.StringValue(string(kubeconfig.GetRaw()))
+		data.Token = types.StringValue(token)
+
+		resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+		return
+	}
+
+	ttl := defaultClusterKubeconfigTokenTTL
+
+	if !data.TokenTTL.IsNull() && !data.TokenTTL.IsUnknown() {
+		ttl, err = time.ParseDuration(data.TokenTTL.ValueString())
+		if err != nil || ttl <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_ttl"),
+				"Invalid token_ttl value",
+				fmt.Sprintf("The token_ttl attribute must be a positive duration. Got %q", data.TokenTTL.ValueString()),
+			)
+
+			return
+		}
+	}
+
+	apiKey, err := r.createIAMToken(ctx, data.ApplicationID.ValueStringPointer(), clusterID, ttl)
+	if err != nil {
+		resp.Diagnostics.AddError("Error minting IAM token", err.Error())
+
+		return
+	}
+
+	configFile, err := kubeconfigWithToken(kubeconfig, *apiKey.SecretKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Error building kubeconfig", err.Error())
+
+		return
+	}
+
+	accessKey, _ := json.Marshal(apiKey.AccessKey)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, clusterKubeconfigPrivateAccessKey, accessKey)...)
+
+	data.ConfigFile = types.StringValue(configFile)
+	data.Token = types.StringValue(*apiKey.SecretKey)
+	data.ExpiresAt = types.StringValue(apiKey.ExpiresAt.Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Close deletes the IAM API key minted by Open, the admin token is left untouched
+func (r *ClusterKubeconfigEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	rawAccessKey, diags := req.Private.GetKey(ctx, clusterKubeconfigPrivateAccessKey)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || rawAccessKey == nil {
+		return
+	}
+
+	var accessKey string
+	if err := json.Unmarshal(rawAccessKey, &accessKey); err != nil {
+		resp.Diagnostics.AddError("Error reading IAM token access key", err.Error())
+
+		return
+	}
+
+	err := r.iamAPI.DeleteAPIKey(&iam.DeleteAPIKeyRequest{
+		AccessKey: accessKey,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Error deleting IAM token",
+			fmt.Sprintf("Failed to delete IAM API key %s, it will expire on its own: %s", accessKey, err),
+		)
+	}
+}
+
+// createIAMToken creates an expiring API key for the given application, or for the principal of the provider
+// credentials when no application is given. The cluster RBAC then applies the permissions of that principal.
+func (r *ClusterKubeconfigEphemeralResource) createIAMToken(ctx context.Context, applicationID *string, clusterID string, ttl time.Duration) (*iam.APIKey, error) {
+	req := &iam.CreateAPIKeyRequest{
+		ApplicationID: applicationID,
+		ExpiresAt:     new(time.Now().Add(ttl)),
+		Description:   "Kubeconfig token for cluster " + clusterID,
+	}
+
+	if applicationID == nil {
+		accessKey, exists := r.meta.ScwClient().GetAccessKey()
+		if !exists {
+			return nil, errors.New("no application_id given and the provider has no access key to derive the principal from")
+		}
+
+		currentKey, err := r.iamAPI.GetAPIKey(&iam.GetAPIKeyRequest{
+			AccessKey: accessKey,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the principal of the provider credentials: %w", err)
+		}
+
+		req.ApplicationID = currentKey.ApplicationID
+		req.UserID = currentKey.UserID
+	}
+
+	apiKey, err := r.iamAPI.CreateAPIKey(req, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey.SecretKey == nil {
+		return nil, fmt.Errorf("IAM API key %s was created without a secret key", apiKey.AccessKey)
+	}
+
+	return apiKey, nil
+}
+
+// kubeconfigWithToken returns the kubeconfig file with the token of its user replaced
+func kubeconfigWithToken(kubeconfig *k8s.Kubeconfig, token string) (string, error) {
+	if len(kubeconfig.Users) != 1 {
+		return "", errors.New("kubeconfig should have only one user")
+	}
+
+	users := []*k8s.KubeconfigUserWithName{{
+		Name: kubeconfig.Users[0].Name,
+		User: k8s.KubeconfigUser{Token: token},
+	}}
+
+	withToken := *kubeconfig
+	withToken.Users = users
+
+	out, err := yaml.Marshal(&withToken)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
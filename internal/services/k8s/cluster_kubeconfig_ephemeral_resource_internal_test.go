@@ -0,0 +1,47 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestKubeconfigWithToken(t *testing.T) {
+	kubeconfig := &k8s.Kubeconfig{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		CurrentContext: "admin@main",
+		Clusters: []*k8s.KubeconfigClusterWithName{{
+			Name:    "main",
+			Cluster: k8s.KubeconfigCluster{Server: "https://main.api.k8s.fr-par.scw.cloud:6443", CertificateAuthorityData: "Y2E="},
+		}},
+		Contexts: []*k8s.KubeconfigContextWithName{{
+			Name:    "admin@main",
+			Context: k8s.KubeconfigContext{Cluster: "main", User: "main-admin"},
+		}},
+		Users: []*k8s.KubeconfigUserWithName{{
+			Name: "main-admin",
+			User: k8s.KubeconfigUser{Token: "admin-token"},
+		}},
+	}
+
+	configFile, err := kubeconfigWithToken(kubeconfig, "iam-secret-key")
+	require.NoError(t, err)
+
+	var parsed k8s.Kubeconfig
+	require.NoError(t, yaml.Unmarshal([]byte(configFile), &parsed))
+
+	token, err := parsed.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "iam-secret-key", token)
+	assert.Equal(t, "main-admin", parsed.Users[0].Name)
+	assert.Equal(t, "admin@main", parsed.CurrentContext)
+	assert.Equal(t, kubeconfig.Clusters, parsed.Clusters)
+	assert.NotContains(t, configFile, "admin-token")
+
+	// The source kubeconfig is left untouched
+	assert.Equal(t, "admin-token", kubeconfig.Users[0].User.Token)
+}
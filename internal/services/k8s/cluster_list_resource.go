@@ -0,0 +1,53 @@
+package k8s
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/listresource"
+)
+
+//go:embed descriptions/cluster_list_resource.md
+var clusterListResourceDescription string
+
+func NewClusterListResource() list.ListResource {
+	return listresource.NewRegional(listresource.Config[scw.Region, *k8s.Cluster]{
+		TypeName:    "k8s_cluster",
+		Description: clusterListResourceDescription,
+		Resource:    ResourceCluster,
+		List: func(ctx context.Context, client *scw.Client, region scw.Region, filters listresource.Filters) ([]*k8s.Cluster, error) {
+			res, err := k8s.NewAPI(client).ListClusters(&k8s.ListClustersRequest{
+				Region:    region,
+				ProjectID: filters.ProjectID,
+				Name:      filters.Name,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			// The API does not filter clusters by tags
+			clusters := make([]*k8s.Cluster, 0, len(res.Clusters))
+
+			for _, cluster := range res.Clusters {
+				if listresource.HasTags(cluster.Tags, filters.Tags) {
+					clusters = append(clusters, cluster)
+				}
+			}
+
+			return clusters, nil
+		},
+		Flatten: func(cluster *k8s.Cluster) listresource.Item {
+			return listresource.Item{
+				DisplayName: cluster.Name,
+				SetIdentity: func(d *sdkschema.ResourceData) error {
+					return identity.SetRegionalIdentity(d, cluster.Region, cluster.ID)
+				},
+			}
+		},
+	})
+}
@@ -2,20 +2,31 @@ package k8s_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
 	"time"
 
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	k8sSDK "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	vpcSDK "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/k8s"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc"
 	vpcchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc/testfuncs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var DestroyWaitTimeout = 3 * time.Minute
@@ -1029,3 +1040,90 @@ func TestAccCluster_Networking(t *testing.T) {
 		},
 	})
 }
+
+// planCluster plans the given configuration of a cluster against its prior state
+func planCluster(t *testing.T, m *meta.Meta, prior *sdkterraform.InstanceState, attributes map[string]any) (*sdkterraform.InstanceDiff, error) {
+	t.Helper()
+
+	r := k8s.ResourceCluster()
+
+	// Terraform sends the blocks missing from the configuration as empty lists
+	for name, attribute := range r.SchemaMap() {
+		if _, isBlock := attribute.Elem.(*schema.Resource); isBlock && !attribute.Computed && attributes[name] == nil {
+			attributes[name] = []any{}
+		}
+	}
+
+	raw, err := json.Marshal(attributes)
+	require.NoError(t, err)
+
+	config, err := ctyjson.Unmarshal(raw, r.CoreConfigSchema().ImpliedType())
+	require.NoError(t, err)
+
+	prior = prior.DeepCopy()
+	prior.RawState, err = prior.AttrsAsObjectValue(r.CoreConfigSchema().ImpliedType())
+	require.NoError(t, err)
+
+	return r.Diff(t.Context(), prior, sdkterraform.NewResourceConfigShimmed(config, r.CoreConfigSchema()), m)
+}
+
+func TestClusterPrivateNetworkMoveVPC(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+	vpcAPI := vpcSDK.NewAPI(m.ScwClient())
+
+	privateNetworkIn := func(vpcID string) string {
+		pn, err := vpcAPI.CreatePrivateNetwork(&vpcSDK.CreatePrivateNetworkRequest{Name: "test", VpcID: &vpcID}, scw.WithContext(t.Context()))
+		require.NoError(t, err)
+
+		return regional.NewIDString(pn.Region, pn.ID)
+	}
+
+	newVPC := func() string {
+		v, err := vpcAPI.CreateVPC(&vpcSDK.CreateVPCRequest{Name: "test"}, scw.WithContext(t.Context()))
+		require.NoError(t, err)
+
+		return v.ID
+	}
+
+	clusterVPC := newVPC()
+	current := privateNetworkIn(clusterVPC)
+
+	prior := &sdkterraform.InstanceState{
+		ID: "fr-par/11111111-1111-1111-1111-111111111111",
+		Attributes: map[string]string{
+			"id":                          "fr-par/11111111-1111-1111-1111-111111111111",
+			"name":                        "test",
+			"type":                        "kapsule",
+			"version":                     "1.32.3",
+			"cni":                         "cilium",
+			"region":                      "fr-par",
+			"delete_additional_resources": "false",
+			"private_network_id":          current,
+			"pod_cidr":                    k8s.NetworkingDefaultValues["pod_cidr"],
+			"service_cidr":                k8s.NetworkingDefaultValues["service_cidr"],
+			"service_dns_ip":              k8s.NetworkingDefaultValues["service_dns_ip"],
+		},
+	}
+
+	config := func(privateNetworkID string) map[string]any {
+		return map[string]any{
+			"name":                        "test",
+			"type":                        "kapsule",
+			"version":                     "1.32.3",
+			"cni":                         "cilium",
+			"delete_additional_resources": false,
+			"private_network_id":          privateNetworkID,
+		}
+	}
+
+	diff, err := planCluster(t, m, prior, config(privateNetworkIn(clusterVPC)))
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew())
+
+	otherVPC := newVPC()
+	target := privateNetworkIn(otherVPC)
+
+	_, err = planCluster(t, m, prior, config(target))
+	require.ErrorContains(t, err, fmt.Sprintf("private network %s is in VPC %s, the cluster can only be moved to a private network of its current VPC %s",
+		locality.ExpandID(target), otherVPC, clusterVPC))
+}
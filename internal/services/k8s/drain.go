@@ -0,0 +1,316 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
+)
+
+const kubernetesMirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// kubernetesClient is a minimal client of the Kubernetes API of a cluster, authenticated with the admin token
+// of its kubeconfig. It only covers what is needed to drain nodes.
+type kubernetesClient struct {
+	host       string
+	token      string
+	httpClient *http.Client
+}
+
+type kubernetesAPIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *kubernetesAPIError) Error() string {
+	return fmt.Sprintf("kubernetes API returned %d: %s", e.StatusCode, e.Message)
+}
+
+type kubernetesObjectMeta struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+	OwnerReferences []struct {
+		Kind string `json:"kind"`
+	} `json:"ownerReferences,omitempty"`
+}
+
+type kubernetesPod struct {
+	Metadata kubernetesObjectMeta `json:"metadata"`
+	Status   struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+type kubernetesPodList struct {
+	Items []kubernetesPod `json:"items"`
+}
+
+// newKubernetesClient builds a client from the cluster kubeconfig, retrieved the same way as the kubeconfig attribute
+func newKubernetesClient(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string) (*kubernetesClient, error) {
+	kubeconfig, err := k8sAPI.GetClusterKubeConfig(&k8s.GetClusterKubeConfigRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	host, err := kubeconfig.GetServer()
+	if err != nil {
+		return nil, err
+	}
+
+	caData, err := kubeconfig.GetCertificateAuthorityData()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := kubeconfig.GetToken()
+	if err != nil {
+		return nil, err
+	}
+
+	caPEM, err := base64.StdEncoding.DecodeString(caData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster CA certificate: %w", err)
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("invalid cluster CA certificate: no certificate found")
+	}
+
+	return &kubernetesClient{
+		host:  host,
+		token: token,
+		httpClient: &http.Client{
+			Timeout: time.Minute,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs:    rootCAs,
+					MinVersion: tls.VersionTLS12,
+				},
+			},
+		},
+	}, nil
+}
+
+func (c *kubernetesClient) do(ctx context.Context, method string, path string, contentType string, body any, out any) error {
+	var reqBody io.Reader
+
+	if body != nil {
+		rawBody, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reqBody = bytes.NewReader(rawBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.host+path, reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		status := struct {
+			Message string `json:"message"`
+		}{}
+		if json.Unmarshal(respBody, &status) != nil || status.Message == "" {
+			status.Message = string(respBody)
+		}
+
+		return &kubernetesAPIError{StatusCode: resp.StatusCode, Message: status.Message}
+	}
+
+	if out != nil {
+		return json.Unmarshal(respBody, out)
+	}
+
+	return nil
+}
+
+// cordonNode marks the node as unschedulable
+func (c *kubernetesClient) cordonNode(ctx context.Context, nodeName string) error {
+	patch := map[string]any{"spec": map[string]any{"unschedulable": true}}
+
+	return c.do(ctx, http.MethodPatch, "/api/v1/nodes/"+url.PathEscape(nodeName), "application/strategic-merge-patch+json", patch, nil)
+}
+
+// listNodePods lists the pods of the node that must be evicted to drain it
+func (c *kubernetesClient) listNodePods(ctx context.Context, nodeName string) ([]kubernetesPod, error) {
+	query := url.Values{"fieldSelector": []string{"spec.nodeName=" + nodeName}}
+	pods := &kubernetesPodList{}
+
+	err := c.do(ctx, http.MethodGet, "/api/v1/pods?"+query.Encode(), "", nil, pods)
+	if err != nil {
+		return nil, err
+	}
+
+	return podsToEvict(pods.Items), nil
+}
+
+// evictPod asks for the eviction of the pod, which is refused with a 429 while a PodDisruptionBudget forbids it
+func (c *kubernetesClient) evictPod(ctx context.Context, pod kubernetesPod) error {
+	eviction := map[string]any{
+		"apiVersion": "policy/v1",
+		"kind":       "Eviction",
+		"metadata": kubernetesObjectMeta{
+			Name:      pod.Metadata.Name,
+			Namespace: pod.Metadata.Namespace,
+		},
+	}
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/eviction", url.PathEscape(pod.Metadata.Namespace), url.PathEscape(pod.Metadata.Name))
+
+	err := c.do(ctx, http.MethodPost, path, "application/json", eviction, nil)
+
+	apiErr := &kubernetesAPIError{}
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return err
+}
+
+// podsToEvict filters out the pods that are not evicted when draining a node: pods managed by a DaemonSet,
+// static pods and pods that already completed
+func podsToEvict(pods []kubernetesPod) []kubernetesPod {
+	toEvict := make([]kubernetesPod, 0, len(pods))
+
+	for _, pod := range pods {
+		if pod.Status.Phase == "Succeeded" || pod.Status.Phase == "Failed" {
+			continue
+		}
+
+		if _, isMirror := pod.Metadata.Annotations[kubernetesMirrorPodAnnotation]; isMirror {
+			continue
+		}
+
+		isDaemonSetPod := false
+
+		for _, owner := range pod.Metadata.OwnerReferences {
+			if owner.Kind == "DaemonSet" {
+				isDaemonSetPod = true
+			}
+		}
+
+		if !isDaemonSetPod {
+			toEvict = append(toEvict, pod)
+		}
+	}
+
+	return toEvict
+}
+
+// drainNodes cordons all the nodes first so that evicted pods are not rescheduled on them, then evicts their pods
+// node after node. Evictions blocked by a PodDisruptionBudget are retried until the timeout.
+func drainNodes(ctx context.Context, client *kubernetesClient, nodeNames []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, nodeName := range nodeNames {
+		err := client.cordonNode(ctx, nodeName)
+		if err != nil {
+			return fmt.Errorf("cordon node %s: %w", nodeName, err)
+		}
+	}
+
+	retryInterval := defaultK8SRetryInterval
+	if transport.DefaultWaitRetryInterval != nil {
+		retryInterval = *transport.DefaultWaitRetryInterval
+	}
+
+	for _, nodeName := range nodeNames {
+		err := drainNode(ctx, client, nodeName, retryInterval)
+		if err != nil {
+			return fmt.Errorf("drain node %s: %w", nodeName, err)
+		}
+	}
+
+	return nil
+}
+
+func drainNode(ctx context.Context, client *kubernetesClient, nodeName string, retryInterval time.Duration) error {
+	var (
+		blocked   *kubernetesPod
+		remaining int
+	)
+
+	for {
+		pods, err := client.listNodePods(ctx, nodeName)
+		if err != nil {
+			return drainError(ctx, err, blocked, remaining)
+		}
+
+		if len(pods) == 0 {
+			return nil
+		}
+
+		blocked, remaining = nil, len(pods)
+
+		for _, pod := range pods {
+			err = client.evictPod(ctx, pod)
+
+			apiErr := &kubernetesAPIError{}
+
+			switch {
+			case err == nil:
+			case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests:
+				blocked = &pod
+			default:
+				return drainError(ctx, fmt.Errorf("evict pod %s/%s: %w", pod.Metadata.Namespace, pod.Metadata.Name, err), blocked, remaining)
+			}
+		}
+
+		// Wait for the evicted pods to terminate and for the disruption budgets to allow more evictions
+		select {
+		case <-ctx.Done():
+			return drainError(ctx, ctx.Err(), blocked, remaining)
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// drainError explains a drain timeout by the pods left on the node
+func drainError(ctx context.Context, err error, blocked *kubernetesPod, remaining int) error {
+	if ctx.Err() == nil {
+		return err
+	}
+
+	if blocked != nil {
+		return fmt.Errorf("timed out, eviction of pod %s/%s is still refused by a PodDisruptionBudget", blocked.Metadata.Namespace, blocked.Metadata.Name)
+	}
+
+	return fmt.Errorf("timed out waiting for %d pods to terminate", remaining)
+}
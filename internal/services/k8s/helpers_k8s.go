@@ -4,13 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/logging"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
 )
@@ -19,6 +25,8 @@ const (
 	defaultK8SClusterTimeout = 15 * time.Minute
 	defaultK8SPoolTimeout    = 30 * time.Minute
 	defaultK8SRetryInterval  = 5 * time.Second
+	defaultK8SDrainTimeout   = 15 * time.Minute
+	defaultK8SNodeTimeout    = 15 * time.Minute
 )
 
 func newAPIWithRegion(d *schema.ResourceData, m any) (*k8s.API, scw.Region, error) {
@@ -134,3 +142,217 @@ func getClusterProjectID(ctx context.Context, k8sAPI *k8s.API, pool *k8s.Pool) (
 
 	return cluster.ProjectID, nil
 }
+
+// parseMinorVersion returns the major and minor numbers of a x.y or x.y.z version
+func parseMinorVersion(version string) (int, int, error) {
+	versionSplit := strings.Split(version, ".")
+	if len(versionSplit) < 2 || len(versionSplit) > 3 {
+		return 0, 0, fmt.Errorf("version should be like x.y or x.y.z not %s", version)
+	}
+
+	major, err := strconv.Atoi(versionSplit[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid major version in %s: %w", version, err)
+	}
+
+	minor, err := strconv.Atoi(versionSplit[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid minor version in %s: %w", version, err)
+	}
+
+	return major, minor, nil
+}
+
+// clusterUpgradePath returns the versions a cluster goes through to upgrade from current to target, one minor at a time.
+// Intermediate steps are minor versions (x.y), the last step is the target as given.
+func clusterUpgradePath(current, target string) ([]string, error) {
+	if current == target {
+		return nil, nil
+	}
+
+	currentMajor, currentMinor, err := parseMinorVersion(current)
+	if err != nil {
+		return nil, err
+	}
+
+	targetMajor, targetMinor, err := parseMinorVersion(target)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case currentMajor != targetMajor:
+		return nil, fmt.Errorf("cannot upgrade cluster from %s to %s: upgrades across major versions are not supported", current, target)
+	case targetMinor < currentMinor:
+		return nil, fmt.Errorf("cannot upgrade cluster from %s to %s: downgrades are not supported", current, target)
+	case targetMinor == currentMinor && len(strings.Split(target, ".")) == 2:
+		// x.y.z to x.y is only a switch to auto upgrades, there is nothing to upgrade
+		return nil, nil
+	}
+
+	path := make([]string, 0, targetMinor-currentMinor+1)
+	for minor := currentMinor + 1; minor < targetMinor; minor++ {
+		path = append(path, fmt.Sprintf("%d.%d", currentMajor, minor))
+	}
+
+	return append(path, target), nil
+}
+
+// upgradeClusterAlongPath upgrades the control plane to each version of the path in order.
+// Before moving to the next minor, every pool must have caught up with the control plane: pools are upgraded
+// by the API according to their upgrade_policy, and any pool left behind is upgraded explicitly.
+func upgradeClusterAlongPath(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string, path []string, waitPools bool, timeout time.Duration) error {
+	for i, step := range path {
+		version := step
+		if i < len(path)-1 {
+			latest, err := k8sGetLatestVersionFromMinor(ctx, k8sAPI, region, step)
+			if err != nil {
+				return err
+			}
+
+			version = latest
+		}
+
+		_, err := k8sAPI.UpgradeCluster(&k8s.UpgradeClusterRequest{
+			Region:       region,
+			ClusterID:    clusterID,
+			Version:      version,
+			UpgradePools: true,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("upgrade to %s (step %d/%d of %s): %w", version, i+1, len(path), strings.Join(path, " -> "), err)
+		}
+
+		_, err = waitCluster(ctx, k8sAPI, region, clusterID, timeout)
+		if err != nil {
+			return err
+		}
+
+		if !waitPools {
+			continue
+		}
+
+		_, err = waitClusterPool(ctx, k8sAPI, region, clusterID, timeout)
+		if err != nil {
+			return err
+		}
+
+		if i == len(path)-1 {
+			continue
+		}
+
+		err = upgradeClusterPools(ctx, k8sAPI, region, clusterID, version, timeout)
+		if err != nil {
+			return fmt.Errorf("upgrade pools to %s (step %d/%d of %s): %w", version, i+1, len(path), strings.Join(path, " -> "), err)
+		}
+	}
+
+	return nil
+}
+
+// upgradeClusterPools makes sure every pool of the cluster runs the given version and is ready
+func upgradeClusterPools(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string, version string, timeout time.Duration) error {
+	pools, err := k8sAPI.ListPools(&k8s.ListPoolsRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools.Pools {
+		if pool.Version != version {
+			_, err = k8sAPI.UpgradePool(&k8s.UpgradePoolRequest{
+				Region:  region,
+				PoolID:  pool.ID,
+				Version: version,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = waitPoolReady(ctx, k8sAPI, region, pool.ID, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listVPCPrivateNetworks lists the private networks of the VPC the given private network belongs to
+func listVPCPrivateNetworks(ctx context.Context, vpcAPI *vpc.API, region scw.Region, privateNetworkID string) ([]*vpc.PrivateNetwork, error) {
+	res, err := vpcAPI.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+		Region:            region,
+		PrivateNetworkIDs: []string{privateNetworkID},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.PrivateNetworks) == 0 {
+		return nil, fmt.Errorf("private network %s not found", privateNetworkID)
+	}
+
+	res, err = vpcAPI.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+		Region: region,
+		VpcID:  &res.PrivateNetworks[0].VpcID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return res.PrivateNetworks, nil
+}
+
+// clusterSameVPC returns an error if the private network a cluster is moved to, listed with the other private networks
+// of its VPC, is not in the VPC of the current private network. The check is skipped if the current private network
+// cannot be fetched anymore.
+func clusterSameVPC(ctx context.Context, vpcAPI *vpc.API, region scw.Region, currentID string, targetID string, privateNetworks []*vpc.PrivateNetwork) error {
+	res, err := vpcAPI.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+		Region:            region,
+		PrivateNetworkIDs: []string{currentID},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		logging.L.Debugf("skipping the check of the VPC of the private network of the cluster: %s", err)
+
+		return nil
+	}
+
+	if len(res.PrivateNetworks) == 0 || len(privateNetworks) == 0 {
+		return nil
+	}
+
+	currentVPCID := res.PrivateNetworks[0].VpcID
+
+	targetVPCID := privateNetworks[0].VpcID
+	if targetVPCID != currentVPCID {
+		return fmt.Errorf("private network %s is in VPC %s, the cluster can only be moved to a private network of its current VPC %s",
+			targetID, targetVPCID, currentVPCID)
+	}
+
+	return nil
+}
+
+// clusterCIDROverlap returns an error if one of the cluster CIDRs, indexed by attribute name, overlaps
+// one of the IPv4 or IPv6 subnets of the private networks
+func clusterCIDROverlap(cidrs map[string]string, privateNetworks []*vpc.PrivateNetwork) error {
+	for _, key := range slices.Sorted(maps.Keys(cidrs)) {
+		_, cidr, err := net.ParseCIDR(cidrs[key])
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+
+		for _, privateNetwork := range privateNetworks {
+			for _, subnet := range privateNetwork.Subnets {
+				if cidr.Contains(subnet.Subnet.IP) || subnet.Subnet.Contains(cidr.IP) {
+					return fmt.Errorf("%s %s overlaps the subnet %s of private network %s (%s) in the VPC of the cluster",
+						key, cidrs[key], subnet.Subnet.String(), privateNetwork.Name, privateNetwork.ID)
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package k8s
+
+import (
+	"net"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterUpgradePath(t *testing.T) {
+	path, err := clusterUpgradePath("1.29.4", "1.31.2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.30", "1.31.2"}, path)
+
+	// Auto upgrade clusters only know their minor version
+	path, err = clusterUpgradePath("1.29", "1.32")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.30", "1.31", "1.32"}, path)
+
+	path, err = clusterUpgradePath("1.31.1", "1.31.2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.31.2"}, path)
+
+	// Switching to auto upgrade on the same minor is not an upgrade
+	path, err = clusterUpgradePath("1.31.1", "1.31")
+	require.NoError(t, err)
+	assert.Empty(t, path)
+
+	_, err = clusterUpgradePath("1.31.1", "1.30.4")
+	require.ErrorContains(t, err, "downgrades are not supported")
+
+	_, err = clusterUpgradePath("1.31.1", "2.0.0")
+	require.ErrorContains(t, err, "across major versions")
+
+	_, err = clusterUpgradePath("1.31.1", "latest")
+	require.Error(t, err)
+}
+
+func testVPCSubnet(t *testing.T, cidr string) *vpc.Subnet {
+	t.Helper()
+
+	_, subnet, err := net.ParseCIDR(cidr)
+	require.NoError(t, err)
+
+	return &vpc.Subnet{Subnet: scw.IPNet{IPNet: *subnet}}
+}
+
+func TestClusterCIDROverlap(t *testing.T) {
+	privateNetworks := []*vpc.PrivateNetwork{
+		{
+			ID:      "11111111-1111-1111-1111-111111111111",
+			Name:    "current",
+			Subnets: []*vpc.Subnet{testVPCSubnet(t, "172.16.0.0/22"), testVPCSubnet(t, "fd5f:519c:6d46:2728::/64")},
+		},
+		{
+			ID:      "22222222-2222-2222-2222-222222222222",
+			Name:    "target",
+			Subnets: []*vpc.Subnet{testVPCSubnet(t, "10.32.4.0/24")},
+		},
+	}
+
+	defaultCIDRs := map[string]string{
+		"pod_cidr":     NetworkingDefaultValues["pod_cidr"],
+		"service_cidr": NetworkingDefaultValues["service_cidr"],
+	}
+	require.NoError(t, clusterCIDROverlap(defaultCIDRs, privateNetworks[:1]))
+
+	// The default service CIDR 10.32.0.0/20 contains the subnet of the target private network
+	err := clusterCIDROverlap(defaultCIDRs, privateNetworks)
+	require.ErrorContains(t, err, "service_cidr 10.32.0.0/20 overlaps the subnet 10.32.4.0/24 of private network target")
+
+	err = clusterCIDROverlap(map[string]string{"pod_cidr": "172.16.2.0/24"}, privateNetworks)
+	require.ErrorContains(t, err, "pod_cidr 172.16.2.0/24 overlaps the subnet 172.16.0.0/22 of private network current")
+
+	require.NoError(t, clusterCIDROverlap(map[string]string{"pod_cidr": "10.33.0.0/16"}, privateNetworks))
+}
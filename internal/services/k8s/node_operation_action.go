@@ -0,0 +1,199 @@
+package k8s
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+const (
+	nodeActionReboot  = "reboot"
+	nodeActionReplace = "replace"
+)
+
+var (
+	_ action.Action              = (*NodeOperationAction)(nil)
+	_ action.ActionWithConfigure = (*NodeOperationAction)(nil)
+)
+
+//go:embed descriptions/node_operation_action.md
+var nodeOperationActionDescription string
+
+type NodeOperationAction struct {
+	k8sAPI *k8s.API
+	meta   *meta.Meta
+}
+
+func (a *NodeOperationAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	m, ok := req.ProviderData.(*meta.Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *meta.Meta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.k8sAPI = k8s.NewAPI(m.ScwClient())
+	a.meta = m
+}
+
+func (a *NodeOperationAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k8s_node_operation"
+}
+
+type NodeOperationActionModel struct {
+	Action types.String `tfsdk:"action"`
+	NodeID types.String `tfsdk:"node_id"`
+	Region types.String `tfsdk:"region"`
+	Wait   types.Bool   `tfsdk:"wait"`
+}
+
+func NewNodeOperationAction() action.Action {
+	return &NodeOperationAction{}
+}
+
+func (a *NodeOperationAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         nodeOperationActionDescription,
+		MarkdownDescription: nodeOperationActionDescription,
+		Attributes: map[string]schema.Attribute{
+			"action": schema.StringAttribute{
+				Required:    true,
+				Description: "Type of action to perform on the node: reboot restarts the underlying instance, replace deletes it and creates a new one",
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(nodeActionReboot, nodeActionReplace),
+				},
+			},
+			"node_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the node to send the action to. Can be a plain UUID or a regional ID.",
+				Validators: []validator.String{
+					verify.IsStringUUIDOrUUIDWithLocality(),
+				},
+			},
+			"region": regional.SchemaAttribute("Region of the node. If not set, the region is derived from the node_id when possible or from the provider configuration."),
+			"wait": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Wait for the node to be ready again",
+			},
+		},
+	}
+}
+
+func (a *NodeOperationAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data NodeOperationActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if a.k8sAPI == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured k8sAPI",
+			"The action was not properly configured. The Scaleway client is missing. "+
+				"This is usually a bug in the provider. Please report it to the maintainers.",
+		)
+
+		return
+	}
+
+	nodeID := locality.ExpandID(data.NodeID.ValueString())
+
+	var region scw.Region
+
+	if !data.Region.IsNull() && data.Region.ValueString() != "" {
+		region = scw.Region(data.Region.ValueString())
+	} else if derivedRegion, id, err := regional.ParseID(data.NodeID.ValueString()); err == nil {
+		region = derivedRegion
+		nodeID = id
+	} else {
+		defaultRegion, exists := a.meta.ScwClient().GetDefaultRegion()
+		if !exists {
+			resp.Diagnostics.AddError(
+				"Missing region",
+				"The region attribute is required to send an action to a node. Please provide it explicitly or configure a default region in the provider.",
+			)
+
+			return
+		}
+
+		region = defaultRegion
+	}
+
+	var err error
+
+	switch strings.ToLower(data.Action.ValueString()) {
+	case nodeActionReboot:
+		_, err = a.k8sAPI.RebootNode(&k8s.RebootNodeRequest{
+			Region: region,
+			NodeID: nodeID,
+		}, scw.WithContext(ctx))
+	case nodeActionReplace:
+		_, err = a.k8sAPI.ReplaceNode(&k8s.ReplaceNodeRequest{
+			Region: region,
+			NodeID: nodeID,
+		}, scw.WithContext(ctx))
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("failed to %s node %s", strings.ToLower(data.Action.ValueString()), nodeID),
+			err.Error(),
+		)
+
+		return
+	}
+
+	if data.Wait.ValueBool() {
+		retryInterval := defaultK8SRetryInterval
+		if transport.DefaultWaitRetryInterval != nil {
+			retryInterval = *transport.DefaultWaitRetryInterval
+		}
+
+		node, err := a.k8sAPI.WaitForNode(&k8s.WaitForNodeRequest{
+			Region:        region,
+			NodeID:        nodeID,
+			Timeout:       new(defaultK8SNodeTimeout),
+			RetryInterval: &retryInterval,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"error waiting for node "+nodeID,
+				err.Error(),
+			)
+
+			return
+		}
+
+		if node.Status != k8s.NodeStatusReady {
+			detail := fmt.Sprintf("The node did not become ready, its status is %s", node.Status)
+			if node.ErrorMessage != nil {
+				detail += ": " + *node.ErrorMessage
+			}
+
+			resp.Diagnostics.AddError("node "+nodeID+" is not ready", detail)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package k8s_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	k8sSDK "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createMockedPoolNode creates a cluster with a single node pool in the given region and returns its node
+func createMockedPoolNode(t *testing.T, m *meta.Meta, region scw.Region) (*k8sSDK.Cluster, *k8sSDK.Node) {
+	t.Helper()
+
+	ctx := t.Context()
+	k8sAPI := k8sSDK.NewAPI(m.ScwClient())
+
+	cluster, err := k8sAPI.CreateCluster(&k8sSDK.CreateClusterRequest{Region: region, Name: "test", Version: "1.32.3", Cni: k8sSDK.CNICilium}, scw.WithContext(ctx))
+	require.NoError(t, err)
+
+	pool, err := k8sAPI.CreatePool(&k8sSDK.CreatePoolRequest{Region: region, Zone: scw.Zone(region + "-1"), ClusterID: cluster.ID, Name: "default", NodeType: "PRO2-XXS", Size: 1}, scw.WithContext(ctx))
+	require.NoError(t, err)
+
+	_, err = k8sAPI.WaitForPool(&k8sSDK.WaitForPoolRequest{Region: region, PoolID: pool.ID, RetryInterval: new(time.Duration(0))}, scw.WithContext(ctx))
+	require.NoError(t, err)
+
+	nodes, err := k8sAPI.ListNodes(&k8sSDK.ListNodesRequest{Region: region, ClusterID: cluster.ID}, scw.WithContext(ctx), scw.WithAllPages())
+	require.NoError(t, err)
+	require.Len(t, nodes.Nodes, 1)
+
+	return cluster, nodes.Nodes[0]
+}
+
+func TestNodeOperationAction(t *testing.T) {
+	ctx := t.Context()
+	m := acctest.NewMockedMeta(t)
+	k8sAPI := k8sSDK.NewAPI(m.ScwClient())
+
+	_, node := createMockedPoolNode(t, m, scw.RegionNlAms)
+
+	nodeOperation := k8s.NewNodeOperationAction()
+
+	configureResp := &action.ConfigureResponse{}
+	nodeOperation.(action.ActionWithConfigure).Configure(ctx, action.ConfigureRequest{ProviderData: m}, configureResp)
+	require.False(t, configureResp.Diagnostics.HasError())
+
+	schemaResp := &action.SchemaResponse{}
+	nodeOperation.Schema(ctx, action.SchemaRequest{}, schemaResp)
+
+	invoke := func(operation string, wait bool) *action.InvokeResponse {
+		resp := &action.InvokeResponse{}
+		nodeOperation.Invoke(ctx, action.InvokeRequest{
+			Config: tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+					"action":  tftypes.NewValue(tftypes.String, operation),
+					"node_id": tftypes.NewValue(tftypes.String, regional.NewIDString(node.Region, node.ID)),
+					"region":  tftypes.NewValue(tftypes.String, nil),
+					"wait":    tftypes.NewValue(tftypes.Bool, wait),
+				}),
+			},
+		}, resp)
+
+		return resp
+	}
+
+	// The region of the node is taken from its regional ID rather than from the provider
+	resp := invoke("reboot", false)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	// A rebooting node refuses the action
+	resp = invoke("replace", false)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "failed to replace node "+node.ID)
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), string(k8sSDK.NodeStatusRebooting))
+
+	_, err := k8sAPI.WaitForNode(&k8sSDK.WaitForNodeRequest{Region: node.Region, NodeID: node.ID, RetryInterval: new(time.Duration(0))}, scw.WithContext(ctx))
+	require.NoError(t, err)
+
+	for _, operation := range []string{"replace", "REBOOT"} {
+		resp = invoke(operation, true)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		current, err := k8sAPI.GetNode(&k8sSDK.GetNodeRequest{Region: node.Region, NodeID: node.ID}, scw.WithContext(ctx))
+		require.NoError(t, err)
+		assert.Equal(t, k8sSDK.NodeStatusReady, current.Status, operation)
+	}
+}
@@ -0,0 +1,192 @@
+package k8s
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+//go:embed descriptions/nodes_datasource.md
+var nodesDataSourceDescription string
+
+func DataSourceNodes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceK8SNodesRead,
+		Description: nodesDataSourceDescription,
+		SchemaFunc:  nodesSchema,
+	}
+}
+
+func nodesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cluster_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			Description:      "The ID of the cluster the nodes belong to",
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+		},
+		"pool_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "Only nodes of this pool are listed",
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+		},
+		"status": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "Only nodes with this status are listed",
+			ValidateDiagFunc: verify.ValidateEnum[k8s.NodeStatus](),
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Nodes with a name like it are listed",
+		},
+		"nodes": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The list of nodes",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the node",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the node",
+					},
+					"pool_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the pool the node belongs to",
+					},
+					"status": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The status of the node",
+					},
+					"error_message": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The details of the error, if the node is in error",
+					},
+					"provider_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The underlying instance ID, prefixed by the instance type and location",
+					},
+					"instance_server_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the instance server backing the node",
+					},
+					"zone": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The zone of the instance server backing the node",
+					},
+					"created_at": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The date and time of the creation of the node",
+					},
+					"updated_at": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The date and time of the last update of the node",
+					},
+				},
+			},
+		},
+		"region": regional.Schema(),
+	}
+}
+
+func DataSourceK8SNodesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	cluster := regional.ExpandID(d.Get("cluster_id"))
+	clusterID := cluster.ID
+
+	// The region of a regional cluster ID takes precedence over the default region of the provider
+	region, err := meta.ExtractRegionWithDefault(d, m, cluster.Region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	k8sAPI := k8s.NewAPI(meta.ExtractScwClient(m))
+
+	req := &k8s.ListNodesRequest{
+		Region:    region,
+		ClusterID: clusterID,
+		Name:      types.ExpandStringPtr(d.Get("name")),
+		Status:    k8s.NodeStatus(d.Get("status").(string)),
+	}
+
+	if poolID, ok := d.GetOk("pool_id"); ok {
+		req.PoolID = new(regional.ExpandID(poolID).ID)
+	}
+
+	res, err := k8sAPI.ListNodes(req, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodes := make([]map[string]any, 0, len(res.Nodes))
+
+	for _, node := range res.Nodes {
+		rawNode := map[string]any{
+			"id":            regional.NewIDString(node.Region, node.ID),
+			"name":          node.Name,
+			"pool_id":       regional.NewIDString(node.Region, node.PoolID),
+			"status":        node.Status.String(),
+			"error_message": types.FlattenStringPtr(node.ErrorMessage),
+			"provider_id":   node.ProviderID,
+			"created_at":    types.FlattenTime(node.CreatedAt),
+			"updated_at":    types.FlattenTime(node.UpdatedAt),
+		}
+
+		// Nodes still being created are not yet backed by a server
+		if zone, serverID, err := parseNodeProviderID(node.ProviderID); err == nil {
+			rawNode["instance_server_id"] = zonal.NewIDString(zone, serverID)
+			rawNode["zone"] = zone.String()
+		}
+
+		nodes = append(nodes, rawNode)
+	}
+
+	d.SetId(regional.NewIDString(region, clusterID))
+	_ = d.Set("nodes", nodes)
+
+	return nil
+}
+
+// parseNodeProviderID extracts the zone and the ID of the server backing a node
+// from its provider ID, which is of the form scaleway://instance/<zone>/<id>
+func parseNodeProviderID(providerID string) (scw.Zone, string, error) {
+	trimmed, hasPrefix := strings.CutPrefix(providerID, "scaleway://")
+
+	parts := strings.Split(trimmed, "/")
+	if !hasPrefix || len(parts) != 3 || parts[0] != "instance" || parts[1] == "" || parts[2] == "" {
+		return "", "", fmt.Errorf("unexpected node provider ID format: %q", providerID)
+	}
+
+	zone, err := scw.ParseZone(parts[1])
+	if err != nil {
+		return "", "", err
+	}
+
+	return zone, parts[2], nil
+}
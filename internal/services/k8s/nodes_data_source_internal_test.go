@@ -0,0 +1,32 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNodeProviderID(t *testing.T) {
+	zone, serverID, err := parseNodeProviderID("scaleway://instance/fr-par-2/11111111-1111-1111-1111-111111111111")
+	require.NoError(t, err)
+	assert.Equal(t, scw.ZoneFrPar2, zone)
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", serverID)
+
+	for _, providerID := range []string{
+		"",
+		"scaleway://instance/fr-par-2/",
+		"scaleway://instance/11111111-1111-1111-1111-111111111111",
+		"instance/fr-par-2/11111111-1111-1111-1111-111111111111",
+		"scaleway://instance/fr-par/11111111-1111-1111-1111-111111111111",
+		"scaleway://baremetal/fr-par-2/11111111-1111-1111-1111-111111111111",
+	} {
+		_, _, err = parseNodeProviderID(providerID)
+		assert.Error(t, err, providerID)
+	}
+}
+
+func TestNodesSchema(t *testing.T) {
+	require.NoError(t, DataSourceNodes().InternalValidate(nil, false))
+}
@@ -0,0 +1,37 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceNodesClusterRegion(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+
+	cluster, node := createMockedPoolNode(t, m, scw.RegionNlAms)
+
+	dataSource := k8s.DataSourceNodes()
+	d := schema.TestResourceDataRaw(t, dataSource.SchemaMap(), map[string]any{
+		"cluster_id": regional.NewIDString(cluster.Region, cluster.ID),
+	})
+
+	diags := k8s.DataSourceK8SNodesRead(t.Context(), d, m)
+	require.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, regional.NewIDString(scw.RegionNlAms, cluster.ID), d.Id())
+	assert.Equal(t, 1, d.Get("nodes.#"))
+	assert.Equal(t, regional.NewIDString(scw.RegionNlAms, node.ID), d.Get("nodes.0.id"))
+	assert.Equal(t, "nl-ams-1", d.Get("nodes.0.zone"))
+
+	_, serverID, err := zonal.ParseID(d.Get("nodes.0.instance_server_id").(string))
+	require.NoError(t, err)
+	assert.Equal(t, node.ProviderID, "scaleway://instance/nl-ams-1/"+serverID)
+}
@@ -8,15 +8,18 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/ipam"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
 )
@@ -24,6 +27,16 @@ import (
 //go:embed descriptions/pool.md
 var poolDescription string
 
+// poolIdentity identifies a pool by its cluster and its own ID.
+// The resource ID stays region/pool_id, the cluster is only informative during import.
+func poolIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":     identity.DefaultRegionAttribute(),
+		"cluster_id": {Type: schema.TypeString, Description: "The ID of the cluster of the pool (UUID format)", OptionalForImport: true},
+		"pool_id":    {Type: schema.TypeString, Description: "The ID of the pool (UUID format)", RequiredForImport: true},
+	})
+}
+
 func ResourcePool() *schema.Resource {
 	return &schema.Resource{
 		Description:   poolDescription,
@@ -33,7 +46,7 @@ func ResourcePool() *schema.Resource {
 		DeleteContext: ResourceK8SPoolDelete,
 		CustomizeDiff: ResourceK8SPoolCustomDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "pool_id"),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create:  schema.DefaultTimeout(defaultK8SPoolTimeout),
@@ -42,6 +55,7 @@ func ResourcePool() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    poolSchema,
+		Identity:      poolIdentity(),
 	}
 }
 
@@ -62,7 +76,6 @@ func poolSchema() map[string]*schema.Schema {
 		"node_type": {
 			Type:             schema.TypeString,
 			Required:         true,
-			ForceNew:         true,
 			Description:      "Server type of the pool servers",
 			DiffSuppressFunc: dsf.IgnoreCaseAndHyphen,
 		},
@@ -107,7 +120,6 @@ func poolSchema() map[string]*schema.Schema {
 			Type:             schema.TypeString,
 			Optional:         true,
 			Default:          k8s.RuntimeContainerd.String(),
-			ForceNew:         true,
 			Description:      "Container runtime for the pool",
 			ValidateDiagFunc: verify.ValidateEnum[k8s.Runtime](),
 		},
@@ -120,7 +132,6 @@ func poolSchema() map[string]*schema.Schema {
 		"placement_group_id": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			ForceNew:    true,
 			Default:     nil,
 			Description: "ID of the placement group",
 		},
@@ -158,7 +169,6 @@ func poolSchema() map[string]*schema.Schema {
 		"root_volume_type": {
 			Type:             schema.TypeString,
 			Optional:         true,
-			ForceNew:         true,
 			Computed:         true,
 			Description:      "System volume type of the nodes composing the pool",
 			ValidateDiagFunc: verify.ValidateEnum[k8s.PoolVolumeType](),
@@ -166,7 +176,6 @@ func poolSchema() map[string]*schema.Schema {
 		"root_volume_size_in_gb": {
 			Type:        schema.TypeInt,
 			Optional:    true,
-			ForceNew:    true,
 			Computed:    true,
 			Description: "The size of the system volume of the nodes in gigabyte",
 		},
@@ -174,9 +183,25 @@ func poolSchema() map[string]*schema.Schema {
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Default:     false,
-			ForceNew:    true,
 			Description: "Defines if the public IP should be removed from the nodes.",
 		},
+		"replacement_strategy": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "How the pool is replaced when a setting that cannot be updated in place changes: `recreate` (default) or `blue_green`",
+			ValidateFunc: validation.StringInSlice([]string{poolReplacementStrategyRecreate, poolReplacementStrategyBlueGreen}, false),
+		},
+		"replaced_pool_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the pool replaced by this one, set during plan by a blue/green replacement",
+		},
+		"drain_timeout": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "How long to wait for the nodes of the old pool to be drained during a blue/green replacement, or before a blue/green pool is destroyed. Defaults to `15m`",
+			ValidateDiagFunc: verify.IsDuration(),
+		},
 		"zone":   zonal.Schema(),
 		"region": regional.Schema(),
 		// Computed elements
@@ -281,10 +306,61 @@ func ResourceK8SPoolCreate(ctx context.Context, d *schema.ResourceData, m any) d
 	////
 	// Create pool
 	////
+	req := expandPoolCreateRequest(d, m, region, types.ExpandOrGenerateString(d.Get("name"), "pool"))
+
+	// check if the cluster is waiting for a pool
+	cluster, err := k8sAPI.GetCluster(&k8s.GetClusterRequest{
+		ClusterID: locality.ExpandID(d.Get("cluster_id")),
+		Region:    region,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if cluster.Status == k8s.ClusterStatusCreating {
+		_, err = waitClusterStatus(ctx, k8sAPI, cluster, k8s.ClusterStatusReady, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	replacedPool, err := getReplacedPool(ctx, d, k8sAPI)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if replacedPool != nil {
+		return resourceK8SPoolCreateBlueGreen(ctx, d, m, k8sAPI, replacedPool)
+	}
+
+	res, err := k8sAPI.CreatePool(req, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(regional.NewIDString(region, res.ID))
+
+	if d.Get("wait_for_pool_ready").(bool) { // wait for the pool to be ready if specified (including all its nodes)
+		_, err = waitPoolReady(ctx, k8sAPI, region, res.ID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	_, err = waitCluster(ctx, k8sAPI, region, cluster.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceK8SPoolRead(ctx, d, m)
+}
+
+// expandPoolCreateRequest builds the creation request of a pool with the given name from the resource configuration
+func expandPoolCreateRequest(d *schema.ResourceData, m any, region scw.Region, name string) *k8s.CreatePoolRequest {
 	req := &k8s.CreatePoolRequest{
 		Region:           region,
 		ClusterID:        locality.ExpandID(d.Get("cluster_id")),
-		Name:             types.ExpandOrGenerateString(d.Get("name"), "pool"),
+		Name:             name,
 		NodeType:         d.Get("node_type").(string),
 		Autoscaling:      d.Get("autoscaling").(bool),
 		Autohealing:      d.Get("autohealing").(bool),
@@ -346,42 +422,7 @@ func ResourceK8SPoolCreate(ctx context.Context, d *schema.ResourceData, m any) d
 		req.SecurityGroupID = types.ExpandStringPtr(locality.ExpandID(securityGroupID.(string)))
 	}
 
-	// check if the cluster is waiting for a pool
-	cluster, err := k8sAPI.GetCluster(&k8s.GetClusterRequest{
-		ClusterID: locality.ExpandID(d.Get("cluster_id")),
-		Region:    region,
-	}, scw.WithContext(ctx))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if cluster.Status == k8s.ClusterStatusCreating {
-		_, err = waitClusterStatus(ctx, k8sAPI, cluster, k8s.ClusterStatusReady, d.Timeout(schema.TimeoutCreate))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-	}
-
-	res, err := k8sAPI.CreatePool(req, scw.WithContext(ctx))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	d.SetId(regional.NewIDString(region, res.ID))
-
-	if d.Get("wait_for_pool_ready").(bool) { // wait for the pool to be ready if specified (including all its nodes)
-		_, err = waitPoolReady(ctx, k8sAPI, region, res.ID, d.Timeout(schema.TimeoutCreate))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-	}
-
-	_, err = waitCluster(ctx, k8sAPI, region, cluster.ID, d.Timeout(schema.TimeoutCreate))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	return ResourceK8SPoolRead(ctx, d, m)
+	return req
 }
 
 func ResourceK8SPoolRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -409,13 +450,22 @@ func ResourceK8SPoolRead(ctx context.Context, d *schema.ResourceData, m any) dia
 		return diag.FromErr(err)
 	}
 
+	err = identity.SetIdentityAttributes(d, map[string]string{
+		"region":     region.String(),
+		"cluster_id": pool.ClusterID,
+		"pool_id":    pool.ID,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	nodes, err := getNodes(ctx, k8sAPI, pool)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	_ = d.Set("cluster_id", regional.NewIDString(region, pool.ClusterID))
-	_ = d.Set("name", pool.Name)
+	_ = d.Set("name", poolConfiguredName(d.Get("name").(string), pool.Name))
 	_ = d.Set("node_type", pool.NodeType)
 	_ = d.Set("autoscaling", pool.Autoscaling)
 	_ = d.Set("autohealing", pool.Autohealing)
@@ -590,28 +640,53 @@ func ResourceK8SPoolDelete(ctx context.Context, d *schema.ResourceData, m any) d
 		return diag.FromErr(err)
 	}
 
+	// A blue/green pool destroyed before its replacement is created is drained first, so that evictions still honor
+	// the PodDisruptionBudgets of the cluster
+	if d.Get("replacement_strategy").(string) == poolReplacementStrategyBlueGreen {
+		diags := resourceK8SPoolDrainBeforeDelete(ctx, d, k8sAPI, region, poolID)
+		if diags != nil {
+			return diags
+		}
+	}
+
 	////
 	// Delete Pool
 	////
+	err = deletePool(ctx, k8sAPI, region, poolID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// deletePool deletes a pool and waits for it to be gone
+func deletePool(ctx context.Context, k8sAPI *k8s.API, region scw.Region, poolID string) error {
 	req := &k8s.DeletePoolRequest{
 		Region: region,
 		PoolID: poolID,
 	}
 
-	_, err = k8sAPI.DeletePool(req, scw.WithContext(ctx))
+	_, err := k8sAPI.DeletePool(req, scw.WithContext(ctx))
 	if err != nil {
 		if !httperrors.Is404(err) {
-			return diag.FromErr(err)
+			return err
 		}
 	}
 
+	retryInterval := defaultK8SRetryInterval
+	if transport.DefaultWaitRetryInterval != nil {
+		retryInterval = *transport.DefaultWaitRetryInterval
+	}
+
 	_, err = k8sAPI.WaitForPool(&k8s.WaitForPoolRequest{
-		PoolID: poolID,
-		Region: region,
+		PoolID:        poolID,
+		Region:        region,
+		RetryInterval: &retryInterval,
 	}, scw.WithContext(ctx))
 	if err != nil {
 		if !httperrors.Is404(err) {
-			return diag.FromErr(err)
+			return err
 		}
 	}
 
@@ -626,5 +701,40 @@ func ResourceK8SPoolCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ a
 		}
 	}
 
+	if diff.Id() == "" {
+		return customDiffK8SPoolReplacedPool(diff)
+	}
+
+	// Both strategies replace the pool, blue/green creates the new pool before the old one is destroyed
+	for _, key := range poolReplacementKeys {
+		if diff.HasChange(key) {
+			err := diff.ForceNew(key)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
+
+// customDiffK8SPoolReplacedPool records in the plan the pool replaced by a blue/green replacement,
+// so that the new pool is created next to it and its nodes are drained.
+func customDiffK8SPoolReplacedPool(diff *schema.ResourceDiff) error {
+	if diff.Get("replacement_strategy").(string) != poolReplacementStrategyBlueGreen {
+		return nil
+	}
+
+	// The diff of a replacement is computed again without the prior state, its ID is only left in the raw state
+	rawState := diff.GetRawState()
+	if rawState.IsNull() || !rawState.IsKnown() {
+		return nil
+	}
+
+	replacedID := rawState.GetAttr("id")
+	if replacedID.IsNull() || !replacedID.IsKnown() {
+		return nil
+	}
+
+	return diff.SetNew("replaced_pool_id", replacedID.AsString())
+}
@@ -0,0 +1,209 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+)
+
+const (
+	poolReplacementStrategyRecreate  = "recreate"
+	poolReplacementStrategyBlueGreen = "blue_green"
+
+	// poolBlueGreenSuffix is appended to the pool name every other blue/green replacement,
+	// as the old and the new pool live side by side in the cluster
+	poolBlueGreenSuffix = "-green"
+)
+
+// poolReplacementKeys are the pool settings that cannot be updated in place
+var poolReplacementKeys = []string{
+	"node_type",
+	"container_runtime",
+	"placement_group_id",
+	"root_volume_type",
+	"root_volume_size_in_gb",
+	"public_ip_disabled",
+}
+
+// blueGreenPoolName returns the name of the pool replacing the current one, the suffix is only needed when both pools
+// would have the same name
+func blueGreenPoolName(name string, currentName string) string {
+	if currentName != name {
+		return name
+	}
+
+	return name + poolBlueGreenSuffix
+}
+
+// poolConfiguredName returns the configured name of a pool, which may carry the blue/green suffix in the API
+func poolConfiguredName(name string, poolName string) string {
+	if name != "" && poolName == name+poolBlueGreenSuffix {
+		return name
+	}
+
+	return poolName
+}
+
+// getReplacedPool returns the pool being replaced by a blue/green replacement, or nil when the pool is created
+// from scratch. The replaced pool is already drained and gone when the old pool is destroyed before the new one is
+// created, without create_before_destroy.
+func getReplacedPool(ctx context.Context, d *schema.ResourceData, k8sAPI *k8s.API) (*k8s.Pool, error) {
+	replacedPoolID := d.Get("replaced_pool_id").(string)
+	if d.Get("replacement_strategy").(string) != poolReplacementStrategyBlueGreen || replacedPoolID == "" {
+		return nil, nil
+	}
+
+	region, poolID, err := regional.ParseID(replacedPoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := k8sAPI.GetPool(&k8s.GetPoolRequest{
+		Region: region,
+		PoolID: poolID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// resourceK8SPoolCreateBlueGreen creates the pool replacing oldPool with the planned settings: the new pool must be ready
+// before the nodes of the old pool are drained, Terraform then destroys the old pool. A failed drain leaves both pools
+// in place without adopting the new one, the next apply resumes with the same new pool.
+func resourceK8SPoolCreateBlueGreen(ctx context.Context, d *schema.ResourceData, m any, k8sAPI *k8s.API, oldPool *k8s.Pool) diag.Diagnostics {
+	newPool, err := findOrCreateBlueGreenPool(ctx, d, m, k8sAPI, oldPool)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitPoolReady(ctx, k8sAPI, oldPool.Region, newPool.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = drainPool(ctx, d, k8sAPI, oldPool)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to drain the nodes of pool %s", oldPool.Name),
+			Detail: fmt.Sprintf("%s\n\nThe new pool %s (%s) is ready and the nodes of the old pool are cordoned. "+
+				"Apply again to resume the replacement once the workloads can be evicted.", err, newPool.Name, newPool.ID),
+		}}
+	}
+
+	d.SetId(regional.NewIDString(oldPool.Region, newPool.ID))
+
+	return ResourceK8SPoolRead(ctx, d, m)
+}
+
+// resourceK8SPoolDrainBeforeDelete drains the nodes of a blue/green pool before it is deleted. A failed drain leaves
+// the pool in place with its nodes cordoned.
+func resourceK8SPoolDrainBeforeDelete(ctx context.Context, d *schema.ResourceData, k8sAPI *k8s.API, region scw.Region, poolID string) diag.Diagnostics {
+	pool, err := k8sAPI.GetPool(&k8s.GetPoolRequest{
+		Region: region,
+		PoolID: poolID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	err = drainPool(ctx, d, k8sAPI, pool)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to drain the nodes of pool %s", pool.Name),
+			Detail: fmt.Sprintf("%s\n\nThe pool is not deleted and its nodes are cordoned. "+
+				"Apply again once the workloads can be evicted to other pools of the cluster.", err),
+		}}
+	}
+
+	return nil
+}
+
+// drainPool drains all the nodes of a pool within the drain_timeout of the resource
+func drainPool(ctx context.Context, d *schema.ResourceData, k8sAPI *k8s.API, pool *k8s.Pool) error {
+	nodes, err := k8sAPI.ListNodes(&k8s.ListNodesRequest{
+		Region:    pool.Region,
+		ClusterID: pool.ClusterID,
+		PoolID:    &pool.ID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	if len(nodes.Nodes) == 0 {
+		return nil
+	}
+
+	nodeNames := make([]string, 0, len(nodes.Nodes))
+	for _, node := range nodes.Nodes {
+		nodeNames = append(nodeNames, node.Name)
+	}
+
+	drainTimeout := defaultK8SDrainTimeout
+	if rawTimeout, ok := d.GetOk("drain_timeout"); ok {
+		drainTimeout, err = time.ParseDuration(rawTimeout.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	return drainPoolNodes(ctx, k8sAPI, pool, nodeNames, drainTimeout)
+}
+
+// drainPoolNodes drains the nodes of a pool through the Kubernetes API of its cluster
+func drainPoolNodes(ctx context.Context, k8sAPI *k8s.API, pool *k8s.Pool, nodeNames []string, drainTimeout time.Duration) error {
+	client, err := newKubernetesClient(ctx, k8sAPI, pool.Region, pool.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	return drainNodes(ctx, client, nodeNames, drainTimeout)
+}
+
+// findOrCreateBlueGreenPool returns the pool replacing oldPool, reusing the one left by an interrupted replacement
+func findOrCreateBlueGreenPool(ctx context.Context, d *schema.ResourceData, m any, k8sAPI *k8s.API, oldPool *k8s.Pool) (*k8s.Pool, error) {
+	name := blueGreenPoolName(d.Get("name").(string), oldPool.Name)
+
+	pools, err := k8sAPI.ListPools(&k8s.ListPoolsRequest{
+		Region:    oldPool.Region,
+		ClusterID: oldPool.ClusterID,
+		Name:      &name,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pool := range pools.Pools {
+		if pool.Name != name {
+			continue
+		}
+
+		if !dsf.IgnoreCaseAndHyphen("", pool.NodeType, d.Get("node_type").(string), nil) {
+			return nil, fmt.Errorf("pool %s (%s) left by a previous replacement has node type %s instead of %s, delete it or restore the previous node type",
+				pool.Name, pool.ID, pool.NodeType, d.Get("node_type"))
+		}
+
+		return pool, nil
+	}
+
+	return k8sAPI.CreatePool(expandPoolCreateRequest(d, m, oldPool.Region, name), scw.WithContext(ctx))
+}
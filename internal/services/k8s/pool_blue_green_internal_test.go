@@ -0,0 +1,132 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlueGreenPoolName(t *testing.T) {
+	assert.Equal(t, "default-green", blueGreenPoolName("default", "default"))
+	assert.Equal(t, "default", blueGreenPoolName("default", "default-green"))
+	assert.Equal(t, "workers", blueGreenPoolName("workers", "default"))
+
+	assert.Equal(t, "default", poolConfiguredName("default", "default-green"))
+	assert.Equal(t, "default", poolConfiguredName("default", "default"))
+	// Imported pools keep their API name
+	assert.Equal(t, "default-green", poolConfiguredName("", "default-green"))
+}
+
+func testKubernetesPod(name string, phase string, ownerKind string, annotations map[string]string) kubernetesPod {
+	pod := kubernetesPod{Metadata: kubernetesObjectMeta{Name: name, Namespace: "default", Annotations: annotations}}
+	pod.Status.Phase = phase
+
+	if ownerKind != "" {
+		pod.Metadata.OwnerReferences = append(pod.Metadata.OwnerReferences, struct {
+			Kind string `json:"kind"`
+		}{Kind: ownerKind})
+	}
+
+	return pod
+}
+
+func TestPodsToEvict(t *testing.T) {
+	pods := podsToEvict([]kubernetesPod{
+		testKubernetesPod("web", "Running", "ReplicaSet", nil),
+		testKubernetesPod("cilium", "Running", "DaemonSet", nil),
+		testKubernetesPod("static", "Running", "Node", map[string]string{kubernetesMirrorPodAnnotation: "hash"}),
+		testKubernetesPod("job", "Succeeded", "Job", nil),
+		testKubernetesPod("pending", "Pending", "", nil),
+	})
+
+	names := []string{}
+	for _, pod := range pods {
+		names = append(names, pod.Metadata.Name)
+	}
+
+	assert.Equal(t, []string{"web", "pending"}, names)
+}
+
+// fakeKubernetesAPI serves a single node whose pod is protected by a PodDisruptionBudget for the first evictions
+type fakeKubernetesAPI struct {
+	mu              sync.Mutex
+	cordoned        bool
+	evictionRetries int
+	podEvicted      bool
+}
+
+func (f *fakeKubernetesAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/nodes/node-1":
+		f.cordoned = true
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/pods":
+		pods := kubernetesPodList{}
+		if !f.podEvicted {
+			pods.Items = append(pods.Items, testKubernetesPod("web", "Running", "ReplicaSet", nil))
+		}
+
+		_ = json.NewEncoder(w).Encode(pods)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/namespaces/default/pods/web/eviction":
+		if f.evictionRetries > 0 {
+			f.evictionRetries--
+
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"Cannot evict pod as it would violate the pod's disruption budget."}`))
+
+			return
+		}
+
+		f.podEvicted = true
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testKubernetesClient(t *testing.T, handler http.Handler) *kubernetesClient {
+	t.Helper()
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	retryInterval := 10 * time.Millisecond
+	previousInterval := transport.DefaultWaitRetryInterval
+	transport.DefaultWaitRetryInterval = &retryInterval
+
+	t.Cleanup(func() { transport.DefaultWaitRetryInterval = previousInterval })
+
+	return &kubernetesClient{host: server.URL, token: "token", httpClient: server.Client()}
+}
+
+func TestDrainNodesHonorsPodDisruptionBudgets(t *testing.T) {
+	api := &fakeKubernetesAPI{evictionRetries: 2}
+	client := testKubernetesClient(t, api)
+
+	require.NoError(t, drainNodes(context.Background(), client, []string{"node-1"}, time.Minute))
+	assert.True(t, api.cordoned)
+	assert.True(t, api.podEvicted)
+	assert.Equal(t, 0, api.evictionRetries)
+}
+
+func TestDrainNodesTimeout(t *testing.T) {
+	api := &fakeKubernetesAPI{evictionRetries: 1000}
+	client := testKubernetesClient(t, api)
+
+	err := drainNodes(context.Background(), client, []string{"node-1"}, 100*time.Millisecond)
+	require.ErrorContains(t, err, "drain node node-1")
+	require.ErrorContains(t, err, "default/web is still refused by a PodDisruptionBudget")
+}
+
+func TestPoolSchema(t *testing.T) {
+	require.NoError(t, ResourcePool().InternalValidate(nil, true))
+}
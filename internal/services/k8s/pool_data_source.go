@@ -23,6 +23,8 @@ func DataSourcePool() *schema.Resource {
 
 	// Set 'Optional' schema elements
 	datasource.AddOptionalFieldsToSchema(dsSchema, "name", "region", "cluster_id", "size")
+	delete(dsSchema, "replacement_strategy")
+	delete(dsSchema, "drain_timeout")
 
 	dsSchema["name"].ConflictsWith = []string{"pool_id"}
 	dsSchema["cluster_id"].ConflictsWith = []string{"pool_id"}
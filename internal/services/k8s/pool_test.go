@@ -2,12 +2,19 @@ package k8s_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
@@ -15,10 +22,14 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/k8s"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc"
 	vpcchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc/testfuncs"
 	vpcgwchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpcgw/testfuncs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccPool_Basic(t *testing.T) {
@@ -1056,3 +1067,223 @@ func testAccCheckK8SPoolNodesOneOfIsDeleting(name string) resource.TestCheckFunc
 		return fmt.Errorf("nodes status were not as expected: got %q for nodes.0 and %q for nodes.1", nodesZeroStatus, nodesOneStatus)
 	}
 }
+
+func TestPoolReadSetsIdentityOfPreIdentityState(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+	api := k8sSDK.NewAPI(m.ScwClient())
+
+	cluster, err := api.CreateCluster(&k8sSDK.CreateClusterRequest{
+		Region:  scw.RegionFrPar,
+		Name:    "test-pool-identity",
+		Version: "1.32.3",
+		Cni:     k8sSDK.CNICilium,
+	})
+	require.NoError(t, err)
+
+	pool, err := api.CreatePool(&k8sSDK.CreatePoolRequest{
+		Region:    scw.RegionFrPar,
+		ClusterID: cluster.ID,
+		Name:      "default",
+		NodeType:  "PRO2-XXS",
+	})
+	require.NoError(t, err)
+
+	// State written by a provider version without resource identity
+	d := k8s.ResourcePool().Data(&sdkterraform.InstanceState{ID: "fr-par/" + pool.ID})
+
+	diags := k8s.ResourcePool().ReadContext(t.Context(), d, m)
+	require.False(t, diags.HasError(), diags)
+
+	identity, err := d.Identity()
+	require.NoError(t, err)
+	assert.Equal(t, "fr-par", identity.Get("region"))
+	assert.Equal(t, cluster.ID, identity.Get("cluster_id"))
+	assert.Equal(t, pool.ID, identity.Get("pool_id"))
+	assert.Equal(t, "fr-par/"+pool.ID, d.Id())
+}
+
+// applyPool plans the pool from prior to the configuration made of attributes and applies it, prior is kept like
+// with create_before_destroy when the pool is replaced
+func applyPool(t *testing.T, m *meta.Meta, prior *sdkterraform.InstanceState, attributes map[string]any) (*sdkterraform.InstanceState, *sdkterraform.InstanceDiff, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := t.Context()
+	r := k8s.ResourcePool()
+
+	// Terraform sends the blocks missing from the configuration as empty lists
+	for name, attribute := range r.SchemaMap() {
+		if _, isBlock := attribute.Elem.(*schema.Resource); isBlock && !attribute.Computed && attributes[name] == nil {
+			attributes[name] = []any{}
+		}
+	}
+
+	raw, err := json.Marshal(attributes)
+	require.NoError(t, err)
+
+	config, err := ctyjson.Unmarshal(raw, r.CoreConfigSchema().ImpliedType())
+	require.NoError(t, err)
+
+	if prior == nil {
+		prior = &sdkterraform.InstanceState{RawState: cty.NullVal(r.CoreConfigSchema().ImpliedType())}
+	} else {
+		prior = prior.DeepCopy()
+		prior.RawState, err = prior.AttrsAsObjectValue(r.CoreConfigSchema().ImpliedType())
+		require.NoError(t, err)
+	}
+
+	diff, err := r.Diff(ctx, prior, sdkterraform.NewResourceConfigShimmed(config, r.CoreConfigSchema()), m)
+	require.NoError(t, err)
+
+	diff.RawConfig = config
+
+	if diff.RequiresNew() {
+		prior = &sdkterraform.InstanceState{}
+	}
+
+	state, diags := r.Apply(ctx, prior, diff, m)
+
+	return state, diff, diags
+}
+
+func TestPoolBlueGreenReplacement(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+	api := k8sSDK.NewAPI(m.ScwClient())
+
+	cluster, err := api.CreateCluster(&k8sSDK.CreateClusterRequest{
+		Region:  scw.RegionFrPar,
+		Name:    "test-pool-blue-green",
+		Version: "1.32.3",
+		Cni:     k8sSDK.CNICilium,
+	})
+	require.NoError(t, err)
+
+	config := func(nodeType string, size int) map[string]any {
+		return map[string]any{
+			"cluster_id":           "fr-par/" + cluster.ID,
+			"name":                 "workers",
+			"node_type":            nodeType,
+			"size":                 size,
+			"replacement_strategy": "blue_green",
+		}
+	}
+
+	pools := func() []string {
+		res, err := api.ListPools(&k8sSDK.ListPoolsRequest{Region: scw.RegionFrPar, ClusterID: cluster.ID}, scw.WithAllPages())
+		require.NoError(t, err)
+
+		names := []string(nil)
+		for _, pool := range res.Pools {
+			names = append(names, pool.Name)
+		}
+
+		return names
+	}
+
+	created, _, diags := applyPool(t, m, nil, config("PRO2-XXS", 0))
+	require.False(t, diags.HasError(), diags)
+
+	// The replacement is planned, the ID of the new pool is not known yet
+	replaced, diff, diags := applyPool(t, m, created, config("PRO2-XS", 0))
+	require.False(t, diags.HasError(), diags)
+	assert.True(t, diff.Attributes["node_type"].RequiresNew)
+	assert.True(t, diff.RequiresNew())
+	assert.Equal(t, created.ID, diff.Attributes["replaced_pool_id"].New)
+
+	// The new pool is created next to the old one, which is destroyed by Terraform afterwards
+	assert.NotEqual(t, created.ID, replaced.ID)
+	assert.Equal(t, "workers", replaced.Attributes["name"])
+	assert.Equal(t, []string{"workers", "workers-green"}, pools())
+
+	_, err = api.DeletePool(&k8sSDK.DeletePoolRequest{Region: scw.RegionFrPar, PoolID: locality.ExpandID(created.ID)})
+	require.NoError(t, err)
+
+	_, err = api.WaitForPool(&k8sSDK.WaitForPoolRequest{Region: scw.RegionFrPar, PoolID: locality.ExpandID(created.ID), RetryInterval: new(time.Duration(0))})
+	require.True(t, httperrors.Is404(err), err)
+
+	// The nodes of the old pool cannot be drained, the new pool is left for the next apply
+	resized, _, diags := applyPool(t, m, replaced, config("PRO2-XS", 1))
+	require.False(t, diags.HasError(), diags)
+
+	_, _, diags = applyPool(t, m, resized, config("PRO2-XXS", 1))
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "Failed to drain the nodes of pool workers-green")
+	assert.Equal(t, []string{"workers-green", "workers"}, pools())
+
+	// The next apply resumes with the same new pool
+	_, _, diags = applyPool(t, m, resized, config("PRO2-XXS", 1))
+	require.True(t, diags.HasError())
+	assert.Equal(t, []string{"workers-green", "workers"}, pools())
+}
+
+func TestPoolBlueGreenReplacementWithoutCreateBeforeDestroy(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+	api := k8sSDK.NewAPI(m.ScwClient())
+	r := k8s.ResourcePool()
+
+	cluster, err := api.CreateCluster(&k8sSDK.CreateClusterRequest{
+		Region:  scw.RegionFrPar,
+		Name:    "test-pool-blue-green-destroy",
+		Version: "1.32.3",
+		Cni:     k8sSDK.CNICilium,
+	})
+	require.NoError(t, err)
+
+	config := func(name string, nodeType string, size int, strategy string) map[string]any {
+		return map[string]any{
+			"cluster_id":           "fr-par/" + cluster.ID,
+			"name":                 name,
+			"node_type":            nodeType,
+			"size":                 size,
+			"replacement_strategy": strategy,
+		}
+	}
+
+	pools := func() []string {
+		res, err := api.ListPools(&k8sSDK.ListPoolsRequest{Region: scw.RegionFrPar, ClusterID: cluster.ID}, scw.WithAllPages())
+		require.NoError(t, err)
+
+		names := []string(nil)
+		for _, pool := range res.Pools {
+			names = append(names, pool.Name)
+		}
+
+		return names
+	}
+
+	destroy := func(state *sdkterraform.InstanceState) diag.Diagnostics {
+		_, diags := r.Apply(t.Context(), state, &sdkterraform.InstanceDiff{Destroy: true}, m)
+
+		return diags
+	}
+
+	// The old pool is destroyed first, its nodes are drained before it is deleted
+	workers, _, diags := applyPool(t, m, nil, config("workers", "PRO2-XXS", 1, "blue_green"))
+	require.False(t, diags.HasError(), diags)
+
+	diags = destroy(workers)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "Failed to drain the nodes of pool workers")
+	assert.Contains(t, diags[0].Detail, "The pool is not deleted")
+	assert.Equal(t, []string{"workers"}, pools())
+
+	// A recreated pool is deleted without draining its nodes
+	diags = destroy(workers.MergeDiff(&sdkterraform.InstanceDiff{Attributes: map[string]*sdkterraform.ResourceAttrDiff{
+		"replacement_strategy": {Old: "blue_green", New: "recreate"},
+	}}))
+	require.False(t, diags.HasError(), diags)
+	assert.Empty(t, pools())
+
+	// Without nodes to drain, the new pool is created once the old one is gone
+	empty, _, diags := applyPool(t, m, nil, config("empty", "PRO2-XXS", 0, "blue_green"))
+	require.False(t, diags.HasError(), diags)
+
+	diags = destroy(empty)
+	require.False(t, diags.HasError(), diags)
+	assert.Empty(t, pools())
+
+	replaced, diff, diags := applyPool(t, m, empty, config("empty", "PRO2-XS", 0, "blue_green"))
+	require.False(t, diags.HasError(), diags)
+	assert.True(t, diff.RequiresNew())
+	assert.NotEqual(t, empty.ID, replaced.ID)
+	assert.Equal(t, []string{"empty"}, pools())
+}
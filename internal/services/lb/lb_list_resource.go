@@ -0,0 +1,45 @@
+package lb
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/listresource"
+)
+
+//go:embed descriptions/lb_list_resource.md
+var lbListResourceDescription string
+
+func NewLbListResource() list.ListResource {
+	return listresource.NewZonal(listresource.Config[scw.Zone, *lbSDK.LB]{
+		TypeName:    "lb",
+		Description: lbListResourceDescription,
+		Resource:    ResourceLb,
+		List: func(ctx context.Context, client *scw.Client, zone scw.Zone, filters listresource.Filters) ([]*lbSDK.LB, error) {
+			res, err := lbSDK.NewZonedAPI(client).ListLBs(&lbSDK.ZonedAPIListLBsRequest{
+				Zone:      zone,
+				ProjectID: filters.ProjectID,
+				Name:      filters.Name,
+				Tags:      filters.Tags,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			return res.LBs, nil
+		},
+		Flatten: func(lb *lbSDK.LB) listresource.Item {
+			return listresource.Item{
+				DisplayName: lb.Name,
+				SetIdentity: func(d *sdkschema.ResourceData) error {
+					return identity.SetZonalIdentity(d, lb.Zone, lb.ID)
+				},
+			}
+		},
+	})
+}
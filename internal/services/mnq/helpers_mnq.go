@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	mnq "github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 )
@@ -92,8 +93,36 @@ func NewSNSAPIWithRegionAndID(m any, regionalID string) (*mnq.SnsAPI, scw.Region
 	return api, region, ID, nil
 }
 
-func composeMNQID(region scw.Region, projectID string, queueName string) string {
-	return fmt.Sprintf("%s/%s/%s", region, projectID, queueName)
+// projectIdentity returns the identity of services activated on a project, with a {region}/{project_id} ID
+func projectIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":     identity.DefaultRegionAttribute(),
+		"project_id": identity.DefaultProjectIDAttribute(),
+	})
+}
+
+func setProjectIdentity(d *schema.ResourceData, region scw.Region, projectID string) error {
+	return identity.SetMultiPartIdentity(d, map[string]string{
+		"region":     region.String(),
+		"project_id": projectID,
+	}, "region", "project_id")
+}
+
+// namedIdentity returns the identity of topics and queues, with a {region}/{project_id}/{name} ID
+func namedIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":     identity.DefaultRegionAttribute(),
+		"project_id": identity.DefaultProjectIDAttribute(),
+		"name":       {Type: schema.TypeString, Description: "The name of the resource", RequiredForImport: true},
+	})
+}
+
+func setNamedIdentity(d *schema.ResourceData, region scw.Region, projectID string, name string) error {
+	return identity.SetMultiPartIdentity(d, map[string]string{
+		"region":     region.String(),
+		"project_id": projectID,
+		"name":       name,
+	}, "region", "project_id", "name")
 }
 
 func DecomposeMNQID(id string) (region scw.Region, projectID string, name string, err error) {
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
 )
@@ -84,8 +85,22 @@ func NewSNSClient(ctx context.Context, httpClient *http.Client, region string, e
 	return sns.NewFromConfig(customConfig), nil
 }
 
-func composeMNQSubscriptionID(region scw.Region, projectID string, topicName string, subscriptionID string) string {
-	return fmt.Sprintf("%s/%s/%s/%s", region, projectID, topicName, subscriptionID)
+func subscriptionIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":          identity.DefaultRegionAttribute(),
+		"project_id":      identity.DefaultProjectIDAttribute(),
+		"topic_name":      {Type: schema.TypeString, Description: "The name of the topic", RequiredForImport: true},
+		"subscription_id": {Type: schema.TypeString, Description: "The ID of the subscription", RequiredForImport: true},
+	})
+}
+
+func setSubscriptionIdentity(d *schema.ResourceData, arn *ARN) error {
+	return identity.SetMultiPartIdentity(d, map[string]string{
+		"region":          arn.Region.String(),
+		"project_id":      arn.ProjectID,
+		"topic_name":      arn.ResourceName,
+		"subscription_id": arn.ExtraResourceID,
+	}, "region", "project_id", "topic_name", "subscription_id")
 }
 
 func DecomposeMNQSubscriptionID(id string) (arn *ARN, err error) {
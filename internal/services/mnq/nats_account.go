@@ -8,6 +8,7 @@ import (
 	mnq "github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -20,10 +21,11 @@ func ResourceNatsAccount() *schema.Resource {
 		UpdateContext: ResourceMNQNatsAccountUpdate,
 		DeleteContext: ResourceMNQNatsAccountDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthroughRegional(),
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    natsAccountSchema,
+		Identity:      identity.DefaultRegional(),
 	}
 }
 
@@ -60,7 +62,10 @@ func ResourceMNQNatsAccountCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	d.SetId(regional.NewIDString(region, account.ID))
+	err = identity.SetRegionalIdentity(d, region, account.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ResourceMNQNatsAccountRead(ctx, d, m)
 }
@@ -85,6 +90,11 @@ func ResourceMNQNatsAccountRead(ctx context.Context, d *schema.ResourceData, m a
 		return diag.FromErr(err)
 	}
 
+	err = identity.SetRegionalIdentity(d, region, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_ = d.Set("name", account.Name)
 	_ = d.Set("region", account.Region)
 	_ = d.Set("project_id", account.ProjectID)
@@ -9,6 +9,7 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -20,10 +21,11 @@ func ResourceNatsCredentials() *schema.Resource {
 		ReadContext:   ResourceMNQNatsCredentialsRead,
 		DeleteContext: ResourceMNQNatsCredentialsDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthroughRegional(),
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    natsCredentialsSchema,
+		Identity:      identity.DefaultRegional(),
 	}
 }
 
@@ -70,7 +72,10 @@ func ResourceMNQNatsCredentialsCreate(ctx context.Context, d *schema.ResourceDat
 
 	_ = d.Set("file", credentials.Credentials.Content)
 
-	d.SetId(regional.NewIDString(region, credentials.ID))
+	err = identity.SetRegionalIdentity(d, region, credentials.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ResourceMNQNatsCredentialsRead(ctx, d, m)
 }
@@ -95,6 +100,11 @@ func ResourceMNQNatsCredentialsRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	err = identity.SetRegionalIdentity(d, region, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_ = d.Set("account_id", credentials.NatsAccountID)
 	_ = d.Set("name", credentials.Name)
 	_ = d.Set("region", region)
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	mnq "github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 )
@@ -17,10 +18,11 @@ func ResourceSNS() *schema.Resource {
 		ReadContext:   ResourceMNQSNSRead,
 		DeleteContext: ResourceMNQSNSDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "project_id"),
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    snsSchema,
+		Identity:      projectIdentity(),
 	}
 }
 
@@ -50,7 +52,10 @@ func ResourceMNQSNSCreate(ctx context.Context, d *schema.ResourceData, m any) di
 		return diag.FromErr(err)
 	}
 
-	d.SetId(regional.NewIDString(region, sns.ProjectID))
+	err = setProjectIdentity(d, region, sns.ProjectID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ResourceMNQSNSRead(ctx, d, m)
 }
@@ -73,7 +78,7 @@ func ResourceMNQSNSRead(ctx context.Context, d *schema.ResourceData, m any) diag
 	_ = d.Set("region", sns.Region)
 	_ = d.Set("project_id", sns.ProjectID)
 
-	return nil
+	return diag.FromErr(setProjectIdentity(d, region, sns.ProjectID))
 }
 
 func ResourceMNQSNSDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -8,6 +8,7 @@ import (
 	mnq "github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -20,10 +21,11 @@ func ResourceSNSCredentials() *schema.Resource {
 		UpdateContext: ResourceMNQSNSCredentialsUpdate,
 		DeleteContext: ResourceMNQSNSCredentialsDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthroughRegional(),
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    snsCredentialsSchema,
+		Identity:      identity.DefaultRegional(),
 	}
 }
 
@@ -105,7 +107,10 @@ func ResourceMNQSNSCredentialsCreate(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	d.SetId(regional.NewIDString(region, credentials.ID))
+	err = identity.SetRegionalIdentity(d, region, credentials.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	_ = d.Set("access_key", credentials.AccessKey)
 	_ = d.Set("secret_key", credentials.SecretKey)
@@ -133,6 +138,11 @@ func ResourceMNQSNSCredentialsRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	err = identity.SetRegionalIdentity(d, region, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_ = d.Set("name", credentials.Name)
 	_ = d.Set("region", credentials.Region)
 	_ = d.Set("project_id", credentials.ProjectID)
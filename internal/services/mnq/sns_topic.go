@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	mnq "github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
@@ -20,10 +21,11 @@ func ResourceSNSTopic() *schema.Resource {
 		UpdateContext: ResourceMNQSNSTopicUpdate,
 		DeleteContext: ResourceMNQSNSTopicDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "project_id", "name"),
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    snsTopicSchema,
+		Identity:      namedIdentity(),
 		CustomizeDiff: resourceMNQSSNSTopicCustomizeDiff,
 	}
 }
@@ -137,7 +139,10 @@ func ResourceMNQSNSTopicCreate(ctx context.Context, d *schema.ResourceData, m an
 		return diag.Errorf("topic id is nil on creation")
 	}
 
-	d.SetId(composeMNQID(region, projectID, topicName))
+	err = setNamedIdentity(d, region, projectID, topicName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ResourceMNQSNSTopicRead(ctx, d, m)
 }
@@ -172,7 +177,7 @@ func ResourceMNQSNSTopicRead(ctx context.Context, d *schema.ResourceData, m any)
 		_ = d.Set(k, v) // lintignore: R001
 	}
 
-	return nil
+	return diag.FromErr(setNamedIdentity(d, region, projectID, topicName))
 }
 
 func ResourceMNQSNSTopicUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	mnq "github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
@@ -21,10 +22,11 @@ func ResourceSNSTopicSubscription() *schema.Resource {
 		ReadContext:   ResourceMNQSNSTopicSubscriptionRead,
 		DeleteContext: ResourceMNQSNSTopicSubscriptionDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "project_id", "topic_name", "subscription_id"),
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    snsTopicSubscriptionSchema,
+		Identity:      subscriptionIdentity(),
 	}
 }
 
@@ -163,7 +165,10 @@ func ResourceMNQSNSTopicSubscriptionCreate(ctx context.Context, d *schema.Resour
 		return diag.FromErr(fmt.Errorf("failed to parse arn: %w", err))
 	}
 
-	d.SetId(composeMNQSubscriptionID(arn.Region, arn.ProjectID, arn.ResourceName, arn.ExtraResourceID))
+	err = setSubscriptionIdentity(d, arn)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ResourceMNQSNSTopicSubscriptionRead(ctx, d, m)
 }
@@ -198,7 +203,7 @@ func ResourceMNQSNSTopicSubscriptionRead(ctx context.Context, d *schema.Resource
 		_ = d.Set(k, v) // lintignore: R001
 	}
 
-	return nil
+	return diag.FromErr(setSubscriptionIdentity(d, arn))
 }
 
 func ResourceMNQSNSTopicSubscriptionDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	mnq "github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 )
@@ -17,10 +18,11 @@ func ResourceSQS() *schema.Resource {
 		ReadContext:   ResourceMNQSQSRead,
 		DeleteContext: ResourceMNQSQSDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "project_id"),
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    sqsSchema,
+		Identity:      projectIdentity(),
 	}
 }
 
@@ -50,7 +52,10 @@ func ResourceMNQSQSCreate(ctx context.Context, d *schema.ResourceData, m any) di
 		return diag.FromErr(err)
 	}
 
-	d.SetId(regional.NewIDString(region, sqs.ProjectID))
+	err = setProjectIdentity(d, region, sqs.ProjectID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ResourceMNQSQSRead(ctx, d, m)
 }
@@ -73,7 +78,7 @@ func ResourceMNQSQSRead(ctx context.Context, d *schema.ResourceData, m any) diag
 	_ = d.Set("region", sqs.Region)
 	_ = d.Set("project_id", sqs.ProjectID)
 
-	return nil
+	return diag.FromErr(setProjectIdentity(d, region, sqs.ProjectID))
 }
 
 func ResourceMNQSQSDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	mnq "github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
@@ -23,7 +24,7 @@ func ResourceSQSQueue() *schema.Resource {
 		UpdateContext: ResourceMNQSQSQueueUpdate,
 		DeleteContext: ResourceMNQSQSQueueDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "project_id", "name"),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create:  schema.DefaultTimeout(defaultMNQQueueTimeout),
@@ -33,6 +34,7 @@ func ResourceSQSQueue() *schema.Resource {
 		},
 		SchemaVersion: 1,
 		SchemaFunc:    sqsQueueSchema,
+		Identity:      namedIdentity(),
 		CustomizeDiff: resourceMNQQueueCustomizeDiff,
 		StateUpgraders: []schema.StateUpgrader{
 			{
@@ -212,7 +214,10 @@ func ResourceMNQSQSQueueCreate(ctx context.Context, d *schema.ResourceData, m an
 		return diag.Errorf("failed to create SQS Queue: %s", err)
 	}
 
-	d.SetId(composeMNQID(region, projectID, queueName))
+	err = setNamedIdentity(d, region, projectID, queueName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ResourceMNQSQSQueueRead(ctx, d, m)
 }
@@ -263,7 +268,7 @@ func ResourceMNQSQSQueueRead(ctx context.Context, d *schema.ResourceData, m any)
 		_ = d.Set(k, v) // lintignore: R001
 	}
 
-	return nil
+	return diag.FromErr(setNamedIdentity(d, region, projectID, queueName))
 }
 
 func ResourceMNQSQSQueueUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 )
@@ -28,9 +29,10 @@ func ResourceBucket() *schema.Resource {
 			Default: schema.DefaultTimeout(defaultObjectBucketTimeout),
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "name"),
 		},
 		SchemaFunc: bucketSchema,
+		Identity:   bucketIdentity("name"),
 		CustomizeDiff: func(_ context.Context, diff *schema.ResourceDiff, _ any) error {
 			if diff.Get("object_lock_enabled").(bool) {
 				if diff.HasChange("versioning") && !diff.Get("versioning.0.enabled").(bool) {
@@ -263,7 +265,10 @@ func resourceObjectBucketCreate(ctx context.Context, d *schema.ResourceData, m a
 		return diag.FromErr(err)
 	}
 
-	d.SetId(regional.NewIDString(region, bucketName))
+	err = setBucketIdentity(d, "name", region, bucketName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	tagsSet := ExpandObjectBucketTags(d.Get("tags"))
 
@@ -484,6 +489,11 @@ func resourceObjectBucketRead(ctx context.Context, d *schema.ResourceData, m any
 
 	var diags diag.Diagnostics
 
+	err = setBucketIdentity(d, "name", region, bucketName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_ = d.Set("name", bucketName)
 	_ = d.Set("region", region)
 
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
@@ -36,9 +37,10 @@ func ResourceBucketACL() *schema.Resource {
 		UpdateContext: resourceBucketACLUpdate,
 		DeleteContext: resourceBucketACLDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "bucket"),
 		},
 		SchemaFunc: bucketAclSchema,
+		Identity:   bucketIdentity("bucket"),
 	}
 }
 
@@ -468,7 +470,11 @@ func resourceBucketACLRead(ctx context.Context, d *schema.ResourceData, m any) d
 	_ = d.Set("project_id", NormalizeOwnerID(output.Owner.ID))
 	_ = d.Set("bucket", locality.ExpandID(bucket))
 
-	return nil
+	// The ACL may be part of the ID but is not part of the identity as it can be updated
+	return diag.FromErr(identity.SetIdentityAttributes(d, map[string]string{
+		"region": region.String(),
+		"bucket": locality.ExpandID(bucket),
+	}))
 }
 
 // BucketACLCreateResourceID is a method for creating an ID string
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 )
@@ -24,10 +25,11 @@ func ResourceLockConfiguration() *schema.Resource {
 		UpdateContext: resourceObjectLockConfigurationUpdate,
 		DeleteContext: resourceObjectLockConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "bucket"),
 		},
 
 		SchemaFunc: lockConfigurationSchema,
+		Identity:   bucketIdentity("bucket"),
 	}
 }
 
@@ -118,13 +120,16 @@ func resourceObjectLockConfigurationCreate(ctx context.Context, d *schema.Resour
 		return diag.FromErr(fmt.Errorf("error creating object bucket (%s) lock configuration: %w", bucket, err))
 	}
 
-	d.SetId(regional.NewIDString(region, bucket))
+	err = setBucketIdentity(d, "bucket", region, bucket)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceObjectLockConfigurationRead(ctx, d, m)
 }
 
 func resourceObjectLockConfigurationRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	conn, _, bucket, err := s3ClientWithRegionAndName(ctx, d, m, d.Id())
+	conn, region, bucket, err := s3ClientWithRegionAndName(ctx, d, m, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -168,7 +173,7 @@ func resourceObjectLockConfigurationRead(ctx context.Context, d *schema.Resource
 		_ = d.Set("rule", flattenBucketLockConfigurationRule(nil))
 	}
 
-	return nil
+	return diag.FromErr(setBucketIdentity(d, "bucket", region, bucket))
 }
 
 func resourceObjectLockConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
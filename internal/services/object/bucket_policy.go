@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 )
@@ -28,9 +29,10 @@ func ResourceBucketPolicy() *schema.Resource {
 			Default: schema.DefaultTimeout(defaultObjectBucketTimeout),
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "bucket"),
 		},
 		SchemaFunc: bucketPolicySchema,
+		Identity:   bucketIdentity("bucket"),
 	}
 }
 
@@ -106,7 +108,10 @@ func resourceObjectBucketPolicyCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(fmt.Errorf("error putting SCW bucket policy: %w", err))
 	}
 
-	d.SetId(regional.NewIDString(region, bucket))
+	err = setBucketIdentity(d, "bucket", region, bucket)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceObjectBucketPolicyRead(ctx, d, m)
 }
@@ -158,6 +163,10 @@ func resourceObjectBucketPolicyRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if err := setBucketIdentity(d, "bucket", region, bucket); err != nil {
+		return diag.FromErr(err)
+	}
+
 	var diags diag.Diagnostics
 
 	acl, err := s3Client.GetBucketAcl(ctx, &s3.GetBucketAclInput{
@@ -11,6 +11,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3Types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	sdkacctest "github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -21,6 +22,8 @@ import (
 	objectchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/object/testfuncs"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccObjectBucket_Basic(t *testing.T) {
@@ -819,3 +822,29 @@ func testAccCheckObjectBucketLifecycleConfigurationExists(tt *acctest.TestTools,
 		return nil
 	}
 }
+
+func TestObjectBucketReadSetsIdentityOfPreIdentityState(t *testing.T) {
+	// A custom CA bundle cannot be added to the HTTP client of the mock API
+	t.Setenv("AWS_CA_BUNDLE", "")
+
+	m := acctest.NewMockedMeta(t)
+
+	s3Client, err := object.NewS3ClientFromMeta(t.Context(), m, scw.RegionFrPar.String())
+	require.NoError(t, err)
+
+	_, err = s3Client.CreateBucket(t.Context(), &s3.CreateBucketInput{Bucket: new("test-bucket-identity")})
+	require.NoError(t, err)
+
+	// State written by a provider version without resource identity
+	d := object.ResourceBucket().Data(&sdkterraform.InstanceState{ID: "fr-par/test-bucket-identity"})
+
+	diags := object.ResourceBucket().ReadContext(t.Context(), d, m)
+	require.False(t, diags.HasError(), diags)
+
+	identity, err := d.Identity()
+	require.NoError(t, err)
+	assert.Equal(t, "fr-par", identity.Get("region"))
+	assert.Equal(t, "test-bucket-identity", identity.Get("name"))
+	assert.Equal(t, "fr-par/test-bucket-identity", d.Id())
+	assert.Equal(t, "test-bucket-identity", d.Get("name"))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 )
@@ -23,10 +24,11 @@ func ResourceBucketWebsiteConfiguration() *schema.Resource {
 		UpdateContext: resourceBucketWebsiteConfigurationUpdate,
 		DeleteContext: resourceBucketWebsiteConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "bucket"),
 		},
 
 		SchemaFunc: bucketWebsiteConfigurationSchema,
+		Identity:   bucketIdentity("bucket"),
 	}
 }
 
@@ -130,7 +132,10 @@ func resourceBucketWebsiteConfigurationCreate(ctx context.Context, d *schema.Res
 		return diag.FromErr(fmt.Errorf("error creating object bucket (%s) website configuration: %w", bucket, err))
 	}
 
-	d.SetId(regional.NewIDString(region, bucket))
+	err = setBucketIdentity(d, "bucket", region, bucket)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceBucketWebsiteConfigurationRead(ctx, d, m)
 }
@@ -203,7 +208,7 @@ func resourceBucketWebsiteConfigurationRead(ctx context.Context, d *schema.Resou
 
 	_ = d.Set("project_id", NormalizeOwnerID(acl.Owner.ID))
 
-	return nil
+	return diag.FromErr(setBucketIdentity(d, "bucket", region, bucket))
 }
 
 func resourceBucketWebsiteConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -24,6 +24,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
@@ -105,6 +106,22 @@ func s3ClientWithRegion(ctx context.Context, d *schema.ResourceData, m any) (*s3
 	return s3Client, region, err
 }
 
+// bucketIdentity returns the identity of resources identified by their bucket, bucketKey being the attribute holding the bucket name
+func bucketIdentity(bucketKey string) *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":  identity.DefaultRegionAttribute(),
+		bucketKey: {Type: schema.TypeString, Description: "The name of the bucket", RequiredForImport: true},
+	})
+}
+
+// setBucketIdentity sets the identity and the {region}/{bucketName} ID of resources identified by their bucket
+func setBucketIdentity(d *schema.ResourceData, bucketKey string, region scw.Region, bucketName string) error {
+	return identity.SetMultiPartIdentity(d, map[string]string{
+		"region":  region.String(),
+		bucketKey: bucketName,
+	}, "region", bucketKey)
+}
+
 func s3ClientWithRegionAndName(ctx context.Context, d *schema.ResourceData, m any, id string) (*s3.Client, scw.Region, string, error) {
 	region, name, err := regional.ParseID(id)
 	if err != nil {
@@ -16,12 +16,22 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
 )
 
+func objectIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region": identity.DefaultRegionAttribute(),
+		"bucket": {Type: schema.TypeString, Description: "The name of the bucket", RequiredForImport: true},
+		"key":    {Type: schema.TypeString, Description: "The key of the object", RequiredForImport: true},
+	})
+}
+
 func ResourceObject() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceObjectCreate,
@@ -36,9 +46,14 @@ func ResourceObject() *schema.Resource {
 			Delete:  schema.DefaultTimeout(defaultObjectBucketTimeout),
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "bucket", "key"),
 		},
 		SchemaFunc: objectSchema,
+		// Moving an object to another bucket or key is done in place
+		ResourceBehavior: schema.ResourceBehavior{
+			MutableIdentity: true,
+		},
+		Identity: objectIdentity(),
 	}
 }
 
@@ -224,7 +239,10 @@ func resourceObjectCreate(ctx context.Context, d *schema.ResourceData, m any) di
 		}
 	}
 
-	d.SetId(regional.NewIDString(region, objectID(bucket, key)))
+	err = setObjectIdentity(d, region, bucket, key)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceObjectRead(ctx, d, m)
 }
@@ -348,7 +366,10 @@ func resourceObjectUpdate(ctx context.Context, d *schema.ResourceData, m any) di
 		}
 	}
 
-	d.SetId(regional.NewIDString(region, objectID(bucketUpdated, keyUpdated)))
+	err = setObjectIdentity(d, region, bucketUpdated, keyUpdated)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceObjectCreate(ctx, d, m)
 }
@@ -378,6 +399,11 @@ func resourceObjectRead(ctx context.Context, d *schema.ResourceData, m any) diag
 	}
 
 	_ = d.Set("region", region)
+	err = setObjectIdentity(d, region, bucket, key)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_ = d.Set("bucket", regional.NewIDString(region, bucket))
 	_ = d.Set("key", key)
 
@@ -440,6 +466,15 @@ func resourceObjectDelete(ctx context.Context, d *schema.ResourceData, m any) di
 	return nil
 }
 
+// setObjectIdentity sets the identity and the {region}/{bucketName}/{objectKey} ID of an object
+func setObjectIdentity(d *schema.ResourceData, region scw.Region, bucket, key string) error {
+	return identity.SetMultiPartIdentity(d, map[string]string{
+		"region": region.String(),
+		"bucket": bucket,
+		"key":    key,
+	}, "region", "bucket", "key")
+}
+
 func objectID(bucket, key string) string {
 	return fmt.Sprintf("%s/%s", bucket, key)
 }
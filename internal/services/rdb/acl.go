@@ -14,12 +14,20 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
 )
 
+func aclIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":      identity.DefaultRegionAttribute(),
+		"instance_id": {Type: schema.TypeString, Description: "The ID of the Database Instance (UUID format)", RequiredForImport: true},
+	})
+}
+
 func ResourceACL() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: ResourceACLCreate,
@@ -27,7 +35,7 @@ func ResourceACL() *schema.Resource {
 		UpdateContext: ResourceACLUpdate,
 		DeleteContext: ResourceACLDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "instance_id"),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create:  schema.DefaultTimeout(defaultInstanceTimeout),
@@ -38,6 +46,7 @@ func ResourceACL() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    aclSchema,
+		Identity:      aclIdentity(),
 		CustomizeDiff: cdf.LocalityCheck("instance_id"),
 	}
 }
@@ -136,9 +145,15 @@ func ResourceRdbACLRead(ctx context.Context, d *schema.ResourceData, m any) diag
 		return diag.FromErr(err)
 	}
 
-	id := regional.NewID(region, instanceID).String()
-	d.SetId(id)
-	_ = d.Set("instance_id", id)
+	err = identity.SetMultiPartIdentity(d, map[string]string{
+		"region":      region.String(),
+		"instance_id": instanceID,
+	}, "region", "instance_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("instance_id", d.Id())
 
 	diags := diag.Diagnostics{}
 
@@ -14,17 +14,26 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
 )
 
+func databaseIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":      identity.DefaultRegionAttribute(),
+		"instance_id": {Type: schema.TypeString, Description: "The ID of the Database Instance (UUID format)", RequiredForImport: true},
+		"name":        {Type: schema.TypeString, Description: "The name of the database", RequiredForImport: true},
+	})
+}
+
 func ResourceDatabase() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: ResourceRdbDatabaseCreate,
 		ReadContext:   ResourceRdbDatabaseRead,
 		DeleteContext: ResourceRdbDatabaseDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "instance_id", "name"),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create:  schema.DefaultTimeout(defaultInstanceTimeout),
@@ -33,6 +42,7 @@ func ResourceDatabase() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    databaseSchema,
+		Identity:      databaseIdentity(),
 		CustomizeDiff: cdf.LocalityCheck("instance_id"),
 	}
 }
@@ -131,7 +141,15 @@ func ResourceRdbDatabaseCreate(ctx context.Context, d *schema.ResourceData, m an
 		return diag.FromErr(err)
 	}
 
-	d.SetId(ResourceRdbDatabaseID(region, instanceID, db.Name))
+	err = identity.SetMultiPartIdentity(d, map[string]string{
+		"region":      region.String(),
+		"instance_id": instanceID,
+		"name":        db.Name,
+	}, "region", "instance_id", "name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_ = d.Set("region", region)
 
 	return ResourceRdbDatabaseRead(ctx, d, m)
@@ -175,7 +193,15 @@ func ResourceRdbDatabaseRead(ctx context.Context, d *schema.ResourceData, m any)
 		return diag.FromErr(err)
 	}
 
-	d.SetId(ResourceRdbDatabaseID(region, instanceID, database.Name))
+	err = identity.SetMultiPartIdentity(d, map[string]string{
+		"region":      region.String(),
+		"instance_id": instanceID,
+		"name":        database.Name,
+	}, "region", "instance_id", "name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_ = d.Set("instance_id", regional.NewID(region, instanceID).String())
 	_ = d.Set("name", database.Name)
 	_ = d.Set("owner", database.Owner)
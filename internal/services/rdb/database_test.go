@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	rdbSDK "github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
@@ -159,3 +161,35 @@ func TestDatabaseParseID(t *testing.T) {
 	assert.Equal(t, "instanceid", instanceID)
 	assert.Equal(t, "dbname", dbname)
 }
+
+func TestDatabaseReadSetsIdentityOfPreIdentityState(t *testing.T) {
+	m := acctest.NewMockedMeta(t)
+	api := rdbSDK.NewAPI(m.ScwClient())
+
+	instance, err := api.CreateInstance(&rdbSDK.CreateInstanceRequest{
+		Region:   scw.RegionFrPar,
+		Name:     "test-database-identity",
+		Engine:   "PostgreSQL-15",
+		NodeType: "db-dev-s",
+	})
+	require.NoError(t, err)
+
+	_, err = api.WaitForInstance(&rdbSDK.WaitForInstanceRequest{Region: scw.RegionFrPar, InstanceID: instance.ID, RetryInterval: new(time.Duration(0))})
+	require.NoError(t, err)
+
+	_, err = api.CreateDatabase(&rdbSDK.CreateDatabaseRequest{Region: scw.RegionFrPar, InstanceID: instance.ID, Name: "foo"})
+	require.NoError(t, err)
+
+	// State written by a provider version without resource identity
+	d := rdb.ResourceDatabase().Data(&sdkterraform.InstanceState{ID: "fr-par/" + instance.ID + "/foo"})
+
+	diags := rdb.ResourceDatabase().ReadContext(t.Context(), d, m)
+	require.False(t, diags.HasError(), diags)
+
+	identity, err := d.Identity()
+	require.NoError(t, err)
+	assert.Equal(t, "fr-par", identity.Get("region"))
+	assert.Equal(t, instance.ID, identity.Get("instance_id"))
+	assert.Equal(t, "foo", identity.Get("name"))
+	assert.Equal(t, "fr-par/"+instance.ID+"/foo", d.Id())
+}
@@ -12,11 +12,21 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
 )
 
+func privilegeIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":        identity.DefaultRegionAttribute(),
+		"instance_id":   {Type: schema.TypeString, Description: "The ID of the Database Instance (UUID format)", RequiredForImport: true},
+		"database_name": {Type: schema.TypeString, Description: "The name of the database", RequiredForImport: true},
+		"user_name":     {Type: schema.TypeString, Description: "The name of the user", RequiredForImport: true},
+	})
+}
+
 func ResourcePrivilege() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: ResourceRdbPrivilegeCreate,
@@ -24,7 +34,7 @@ func ResourcePrivilege() *schema.Resource {
 		DeleteContext: ResourceRdbPrivilegeDelete,
 		UpdateContext: ResourceRdbPrivilegeUpdate,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "instance_id", "database_name", "user_name"),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create:  schema.DefaultTimeout(defaultInstanceTimeout),
@@ -38,6 +48,7 @@ func ResourcePrivilege() *schema.Resource {
 			{Version: 0, Type: rdbPrivilegeUpgradeV1SchemaType(), Upgrade: PrivilegeV1SchemaUpgradeFunc},
 		},
 		SchemaFunc:    privilegeSchema,
+		Identity:      privilegeIdentity(),
 		CustomizeDiff: cdf.LocalityCheck("instance_id"),
 	}
 }
@@ -122,7 +133,15 @@ func ResourceRdbPrivilegeCreate(ctx context.Context, d *schema.ResourceData, m a
 		return diag.FromErr(err)
 	}
 
-	d.SetId(ResourceRdbUserPrivilegeID(region, locality.ExpandID(instanceID), databaseName, userName))
+	err = identity.SetMultiPartIdentity(d, map[string]string{
+		"region":        region.String(),
+		"instance_id":   locality.ExpandID(instanceID),
+		"database_name": databaseName,
+		"user_name":     userName,
+	}, "region", "instance_id", "database_name", "user_name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ResourceRdbPrivilegeRead(ctx, d, m)
 }
@@ -194,7 +213,12 @@ func ResourceRdbPrivilegeRead(ctx context.Context, d *schema.ResourceData, m any
 	_ = d.Set("instance_id", regional.NewIDString(region, instanceID))
 	_ = d.Set("region", region)
 
-	return nil
+	return diag.FromErr(identity.SetMultiPartIdentity(d, map[string]string{
+		"region":        region.String(),
+		"instance_id":   instanceID,
+		"database_name": privilege.DatabaseName,
+		"user_name":     privilege.UserName,
+	}, "region", "instance_id", "database_name", "user_name"))
 }
 
 func ResourceRdbPrivilegeUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -13,6 +13,7 @@ import (
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -33,10 +34,11 @@ func ResourceReadReplica() *schema.Resource {
 			Default: schema.DefaultTimeout(defaultInstanceTimeout),
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthroughRegional(),
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    readReplicaSchema,
+		Identity:      identity.DefaultRegional(),
 		CustomizeDiff: cdf.LocalityCheck("instance_id", "private_network.#.private_network_id"),
 	}
 }
@@ -198,7 +200,10 @@ func ResourceRdbReadReplicaCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(fmt.Errorf("failed to create read-replica: %w", err))
 	}
 
-	d.SetId(regional.NewIDString(region, rr.ID))
+	err = identity.SetRegionalIdentity(d, region, rr.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	_, err = waitForRDBReadReplica(ctx, rdbAPI, region, rr.ID, d.Timeout(schema.TimeoutRead))
 	if err != nil {
@@ -225,6 +230,11 @@ func ResourceRdbReadReplicaRead(ctx context.Context, d *schema.ResourceData, m a
 		return diag.FromErr(err)
 	}
 
+	err = identity.SetRegionalIdentity(d, region, rr.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	directAccess, privateNetwork := flattenReadReplicaEndpoints(rr.Endpoints)
 	_ = d.Set("direct_access", directAccess)
 	_ = d.Set("private_network", privateNetwork)
@@ -14,6 +14,7 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -23,6 +24,14 @@ import (
 //go:embed descriptions/user.md
 var userDescription string
 
+func userIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":      identity.DefaultRegionAttribute(),
+		"instance_id": {Type: schema.TypeString, Description: "The ID of the Database Instance (UUID format)", RequiredForImport: true},
+		"name":        {Type: schema.TypeString, Description: "The name of the user", RequiredForImport: true},
+	})
+}
+
 func ResourceUser() *schema.Resource {
 	return &schema.Resource{
 		Description:   userDescription,
@@ -31,7 +40,7 @@ func ResourceUser() *schema.Resource {
 		UpdateContext: ResourceUserUpdate,
 		DeleteContext: ResourceUserDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "instance_id", "name"),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create:  schema.DefaultTimeout(defaultInstanceTimeout),
@@ -42,6 +51,7 @@ func ResourceUser() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    userSchema,
+		Identity:      userIdentity(),
 		CustomizeDiff: cdf.LocalityCheck("instance_id"),
 	}
 }
@@ -148,7 +158,14 @@ func ResourceUserCreate(ctx context.Context, d *schema.ResourceData, m any) diag
 		return diag.FromErr(err)
 	}
 
-	d.SetId(ResourceUserID(region, locality.ExpandID(instanceID), user.Name))
+	err = identity.SetMultiPartIdentity(d, map[string]string{
+		"region":      region.String(),
+		"instance_id": locality.ExpandID(instanceID),
+		"name":        user.Name,
+	}, "region", "instance_id", "name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ResourceUserRead(ctx, d, m)
 }
@@ -200,9 +217,11 @@ func ResourceUserRead(ctx context.Context, d *schema.ResourceData, m any) diag.D
 	_ = d.Set("is_admin", user.IsAdmin)
 	_ = d.Set("region", string(region))
 
-	d.SetId(ResourceUserID(region, instanceID, user.Name))
-
-	return nil
+	return diag.FromErr(identity.SetMultiPartIdentity(d, map[string]string{
+		"region":      region.String(),
+		"instance_id": instanceID,
+		"name":        user.Name,
+	}, "region", "instance_id", "name"))
 }
 
 func ResourceUserUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -12,6 +12,7 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -25,13 +26,14 @@ func ResourceSecret() *schema.Resource {
 		UpdateContext: ResourceSecretUpdate,
 		DeleteContext: ResourceSecretDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthroughRegional(),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultSecretTimeout),
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    secretSchema,
+		Identity:      identity.DefaultRegional(),
 	}
 }
 
@@ -221,7 +223,10 @@ func ResourceSecretCreate(ctx context.Context, d *schema.ResourceData, m any) di
 		return diag.FromErr(err)
 	}
 
-	d.SetId(regional.NewIDString(region, secretResponse.ID))
+	err = identity.SetRegionalIdentity(d, region, secretResponse.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ResourceSecretRead(ctx, d, m)
 }
@@ -246,6 +251,11 @@ func ResourceSecretRead(ctx context.Context, d *schema.ResourceData, m any) diag
 		return diag.FromErr(err)
 	}
 
+	err = identity.SetRegionalIdentity(d, region, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	if len(secretResponse.Tags) > 0 {
 		_ = d.Set("tags", types.FlattenSliceString(secretResponse.Tags))
 	}
@@ -2,7 +2,6 @@ package secret
 
 import (
 	"context"
-	"fmt"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -11,11 +10,20 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
 )
 
+func versionIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":    identity.DefaultRegionAttribute(),
+		"secret_id": {Type: schema.TypeString, Description: "The ID of the secret (UUID format)", RequiredForImport: true},
+		"revision":  {Type: schema.TypeString, Description: "The revision of the secret version", RequiredForImport: true},
+	})
+}
+
 func ResourceVersion() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: ResourceVersionCreate,
@@ -23,13 +31,14 @@ func ResourceVersion() *schema.Resource {
 		UpdateContext: ResourceVersionUpdate,
 		DeleteContext: ResourceVersionDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: identity.ImportStatePassthrough("region", "secret_id", "revision"),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultSecretTimeout),
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    versionSchema,
+		Identity:      versionIdentity(),
 	}
 }
 
@@ -137,7 +146,14 @@ func ResourceVersionCreate(ctx context.Context, d *schema.ResourceData, m any) d
 		_ = d.Set("data", Base64Encoded(payloadSecretRaw))
 	}
 
-	d.SetId(regional.NewIDString(region, fmt.Sprintf("%s/%d", secretResponse.SecretID, secretResponse.Revision)))
+	err = identity.SetMultiPartIdentity(d, map[string]string{
+		"region":    region.String(),
+		"secret_id": secretResponse.SecretID,
+		"revision":  strconv.Itoa(int(secretResponse.Revision)),
+	}, "region", "secret_id", "revision")
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ResourceVersionRead(ctx, d, m)
 }
@@ -172,7 +188,11 @@ func ResourceVersionRead(ctx context.Context, d *schema.ResourceData, m any) dia
 	_ = d.Set("status", secretResponse.Status.String())
 	_ = d.Set("region", string(region))
 
-	return nil
+	return diag.FromErr(identity.SetMultiPartIdentity(d, map[string]string{
+		"region":    region.String(),
+		"secret_id": id,
+		"revision":  revisionStr,
+	}, "region", "secret_id", "revision"))
 }
 
 func ResourceVersionUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -0,0 +1,45 @@
+package vpc
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/listresource"
+)
+
+//go:embed descriptions/private_network_list_resource.md
+var privateNetworkListResourceDescription string
+
+func NewPrivateNetworkListResource() list.ListResource {
+	return listresource.NewRegional(listresource.Config[scw.Region, *vpc.PrivateNetwork]{
+		TypeName:    "vpc_private_network",
+		Description: privateNetworkListResourceDescription,
+		Resource:    ResourcePrivateNetwork,
+		List: func(ctx context.Context, client *scw.Client, region scw.Region, filters listresource.Filters) ([]*vpc.PrivateNetwork, error) {
+			res, err := vpc.NewAPI(client).ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+				Region:    region,
+				ProjectID: filters.ProjectID,
+				Name:      filters.Name,
+				Tags:      filters.Tags,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			return res.PrivateNetworks, nil
+		},
+		Flatten: func(privateNetwork *vpc.PrivateNetwork) listresource.Item {
+			return listresource.Item{
+				DisplayName: privateNetwork.Name,
+				SetIdentity: func(d *sdkschema.ResourceData) error {
+					return identity.SetRegionalIdentity(d, privateNetwork.Region, privateNetwork.ID)
+				},
+			}
+		},
+	})
+}
@@ -0,0 +1,172 @@
+package transport
+
+import (
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/logging"
+)
+
+// apiLimiter is a token bucket limiting the requests sent to one API.
+// It also holds back every request to the API once it asked to retry later.
+type apiLimiter struct {
+	mu sync.Mutex
+
+	// requestsPerSecond is the rate at which tokens are added to the bucket, the bucket is disabled when 0
+	requestsPerSecond float64
+	burst             float64
+	tokens            float64
+	last              time.Time
+	blockedUntil      time.Time
+}
+
+func newAPILimiter(requestsPerSecond float64) *apiLimiter {
+	burst := math.Max(1, math.Ceil(requestsPerSecond))
+
+	return &apiLimiter{
+		requestsPerSecond: requestsPerSecond,
+		burst:             burst,
+		tokens:            burst,
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before sending its request
+func (l *apiLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delay := time.Duration(0)
+
+	if l.requestsPerSecond > 0 {
+		if !l.last.IsZero() {
+			l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.requestsPerSecond)
+		}
+
+		l.last = now
+		l.tokens--
+
+		if l.tokens < 0 {
+			delay = time.Duration(-l.tokens / l.requestsPerSecond * float64(time.Second))
+		}
+	}
+
+	if l.blockedUntil.After(now.Add(delay)) {
+		delay = l.blockedUntil.Sub(now)
+	}
+
+	return delay
+}
+
+// block holds back the requests to the API until the given time
+func (l *apiLimiter) block(until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// RateLimitedTransport throttles the requests sent to each API.
+// Requests are limited by a token bucket per API and, once an API answered with a Retry-After header,
+// every request to this API waits for the given delay, capped by retryWaitMax.
+type RateLimitedTransport struct {
+	next              http.RoundTripper
+	requestsPerSecond float64
+	retryWaitMax      time.Duration
+
+	mu       sync.Mutex
+	limiters map[string]*apiLimiter
+}
+
+// NewRateLimitedTransport creates a transport sending at most requestsPerSecond requests per second to each API,
+// requests are not limited if requestsPerSecond is 0.
+func NewRateLimitedTransport(next http.RoundTripper, requestsPerSecond float64, retryWaitMax time.Duration) *RateLimitedTransport {
+	return &RateLimitedTransport{
+		next:              next,
+		requestsPerSecond: requestsPerSecond,
+		retryWaitMax:      retryWaitMax,
+		limiters:          map[string]*apiLimiter{},
+	}
+}
+
+// limiterKey identifies the API targeted by a request by its host and the product in its path, e.g. api.scaleway.com/iam
+func limiterKey(u *url.URL) string {
+	product, _, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+
+	return u.Host + "/" + product
+}
+
+func (t *RateLimitedTransport) limiter(u *url.URL) *apiLimiter {
+	key := limiterKey(u)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	limiter, exists := t.limiters[key]
+	if !exists {
+		limiter = newAPILimiter(t.requestsPerSecond)
+		t.limiters[key] = limiter
+	}
+
+	return limiter
+}
+
+// RoundTrip waits for the limiter of the targeted API before sending the request
+func (t *RateLimitedTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	limiter := t.limiter(r.URL)
+
+	if delay := limiter.reserve(time.Now()); delay > 0 {
+		logging.L.Debugf("throttling %s %s for %s", r.Method, r.URL.String(), delay)
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			timer.Stop()
+
+			return nil, r.Context().Err()
+		}
+	}
+
+	resp, err := t.next.RoundTrip(r)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+
+	if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		wait = min(wait, t.retryWaitMax)
+		logging.L.Debugf("rate limited by %s, holding back its requests for %s", limiterKey(r.URL), wait)
+		limiter.block(time.Now().Add(wait))
+	}
+
+	return resp, nil
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(header, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(header)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(now), 0), true
+}
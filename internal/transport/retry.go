@@ -20,17 +20,19 @@ type RetryableTransportOptions struct {
 	RetryMax     *int
 	RetryWaitMax *time.Duration
 	RetryWaitMin *time.Duration
+	// MaxRequestsPerSecond limits the requests sent to each API host, requests are not limited if nil or 0
+	MaxRequestsPerSecond *float64
 }
 
 func NewRetryableTransportWithOptions(defaultTransport http.RoundTripper, options RetryableTransportOptions) http.RoundTripper {
 	c := retryablehttp.NewClient()
-	c.HTTPClient = &http.Client{Transport: defaultTransport}
 
 	// Defaults
 	c.RetryMax = 3
 	c.RetryWaitMax = 2 * time.Minute
 	c.Logger = logging.L
 	c.RetryWaitMin = time.Second * 2
+	c.Backoff = retryAfterBackoff
 	c.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
 		if resp == nil || resp.StatusCode == http.StatusTooManyRequests {
 			return true, err
@@ -63,9 +65,31 @@ func NewRetryableTransportWithOptions(defaultTransport http.RoundTripper, option
 		c.RetryWaitMin = *options.RetryWaitMin
 	}
 
+	requestsPerSecond := 0.0
+	if options.MaxRequestsPerSecond != nil {
+		requestsPerSecond = *options.MaxRequestsPerSecond
+	}
+
+	c.HTTPClient = &http.Client{Transport: NewRateLimitedTransport(defaultTransport, requestsPerSecond, c.RetryWaitMax)}
+
 	return &RetryableTransport{c}
 }
 
+// retryAfterBackoff waits for the delay given by the Retry-After header if any, capped by the maximum wait.
+// It falls back to an exponential backoff.
+func retryAfterBackoff(minWait, maxWait time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			wait = min(wait, maxWait)
+			logging.L.Debugf("got status %d with a Retry-After header, retrying in %s", resp.StatusCode, wait)
+
+			return wait
+		}
+	}
+
+	return retryablehttp.DefaultBackoff(minWait, maxWait, attemptNum, nil)
+}
+
 // NewRetryableTransport creates a http transport with retry capability.
 // TODO Retry logic should be moved in the SDK
 func NewRetryableTransport(defaultTransport http.RoundTripper) http.RoundTripper {
@@ -2,7 +2,9 @@ package verify
 
 import (
 	"context"
+	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -25,6 +27,32 @@ func IsStringUUIDOrUUIDWithLocality() validator.String {
 	)
 }
 
+// IsStringDuration validates that the string is a duration parsed by time.ParseDuration, e.g. "30s"
+func IsStringDuration() validator.String {
+	return durationValidator{}
+}
+
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a valid duration, e.g. 30s"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || duration < 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value", fmt.Sprintf("%q is not a valid positive duration, e.g. 30s", req.ConfigValue.ValueString()))
+	}
+}
+
 // IsStringOneOfWithWarning only raises a warning if the string is not oneOf validValues
 func IsStringOneOfWithWarning(validValues []string) validator.String {
 	return ErrorToWarningValidator(
@@ -268,3 +268,54 @@ func TestStringValidatorRegionWithWarning(t *testing.T) {
 		})
 	}
 }
+
+func TestStringValidatorDuration(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	testCases := map[string]struct {
+		value   string
+		wantErr bool
+	}{
+		"seconds": {
+			value: "30s",
+		},
+		"minutes and seconds": {
+			value: "2m30s",
+		},
+		"zero": {
+			value: "0s",
+		},
+		"negative": {
+			value:   "-1s",
+			wantErr: true,
+		},
+		"missing unit": {
+			value:   "30",
+			wantErr: true,
+		},
+		"empty string": {
+			value:   "",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				ConfigValue: types.StringValue(tc.value),
+			}
+
+			resp := validator.StringResponse{}
+
+			verify.IsStringDuration().ValidateString(ctx, req, &resp)
+
+			if tc.wantErr != resp.Diagnostics.HasError() {
+				t.Fatalf("expected error: %t, got diagnostics: %v", tc.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+)
+
+const credentialsProfileKey = "credentials_profile"
+
+func credentialsProfileSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The name of a profile of the Scaleway config file whose credentials are used instead of the provider ones",
+	}
+}
+
+// addCredentialsProfile lets every resource and data source use the credentials of another profile of the Scaleway config file
+func addCredentialsProfile(provider *schema.Provider) {
+	for _, resource := range provider.ResourcesMap {
+		withCredentialsProfile(resource)
+	}
+
+	for _, dataSource := range provider.DataSourcesMap {
+		withCredentialsProfile(dataSource)
+	}
+}
+
+// withCredentialsProfile adds the credentials_profile argument to a resource.
+// Its functions are called with a meta using the credentials of the selected profile.
+func withCredentialsProfile(r *schema.Resource) {
+	if r.SchemaFunc != nil {
+		schemaFunc := r.SchemaFunc
+		r.SchemaFunc = func() map[string]*schema.Schema {
+			s := schemaFunc()
+			s[credentialsProfileKey] = credentialsProfileSchema()
+
+			return s
+		}
+	} else {
+		r.Schema[credentialsProfileKey] = credentialsProfileSchema()
+	}
+
+	read := r.ReadContext
+	if read == nil {
+		read = r.ReadWithoutTimeout
+	}
+
+	r.CreateContext = withCredentialsProfileMeta(r.CreateContext)
+	r.ReadContext = withCredentialsProfileMeta(r.ReadContext)
+	r.ReadWithoutTimeout = withCredentialsProfileMeta(r.ReadWithoutTimeout)
+	r.UpdateContext = withCredentialsProfileMeta(r.UpdateContext)
+	r.DeleteContext = withCredentialsProfileMeta(r.DeleteContext)
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importer := *r.Importer
+		importer.StateContext = withCredentialsProfileImporter(importer.StateContext)
+		r.Importer = &importer
+	}
+
+	if r.CreateContext == nil && r.CreateWithoutTimeout == nil {
+		// Data sources have nothing to plan
+		return
+	}
+
+	// Every other argument of a resource without update forces a new resource, only the new profile has to be recorded
+	if r.UpdateContext == nil && r.UpdateWithoutTimeout == nil {
+		r.UpdateContext = updateCredentialsProfile
+	}
+
+	checkProfile := checkCredentialsProfileChange(r, read)
+
+	if r.CustomizeDiff == nil {
+		r.CustomizeDiff = checkProfile
+
+		return
+	}
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+		if err := checkProfile(ctx, diff, m); err != nil {
+			return err
+		}
+
+		scoped, _, err := credentialsProfileMeta(diff, m)
+		if err != nil {
+			return err
+		}
+
+		return customizeDiff(ctx, diff, scoped)
+	}
+}
+
+// updateCredentialsProfile records the new credentials profile of a resource, its change has been checked when planned
+func updateCredentialsProfile(_ context.Context, _ *schema.ResourceData, _ any) diag.Diagnostics {
+	return nil
+}
+
+// checkCredentialsProfileChange fails the plan when the resource cannot be read with the credentials of its new profile.
+// Changing the profile does not move the resource, it must already be visible to the new credentials.
+func checkCredentialsProfileChange(r *schema.Resource, read func(context.Context, *schema.ResourceData, any) diag.Diagnostics) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+		if diff.Id() == "" || read == nil || !diff.HasChange(credentialsProfileKey) || !diff.NewValueKnown(credentialsProfileKey) {
+			return nil
+		}
+
+		profile, _ := diff.Get(credentialsProfileKey).(string)
+
+		scoped, _, err := credentialsProfileMeta(diff, m)
+		if err != nil {
+			return err
+		}
+
+		state, err := r.ShimInstanceStateFromValue(diff.GetRawState())
+		if err != nil {
+			return err
+		}
+
+		state.ID = diff.Id()
+		state.RawState = diff.GetRawState()
+
+		d := r.Data(state)
+
+		for _, diagnostic := range read(ctx, d, scoped) {
+			if diagnostic.Severity == diag.Error {
+				return fmt.Errorf("cannot read resource %s with credentials profile %q: %s %s", diff.Id(), profile, diagnostic.Summary, diagnostic.Detail)
+			}
+		}
+
+		if d.Id() == "" {
+			return fmt.Errorf("resource %s cannot be found with credentials profile %q, changing the profile of a resource does not move it to another project", diff.Id(), profile)
+		}
+
+		return nil
+	}
+}
+
+// withCredentialsProfileImporter wraps an importer so that it is called with the meta of the credentials profile.
+// The configuration is not sent when importing, so imports use the provider credentials unless the state already has a profile.
+func withCredentialsProfileImporter(f schema.StateContextFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+		scoped, _, err := credentialsProfileMeta(d, m)
+		if err != nil {
+			return nil, err
+		}
+
+		return f(ctx, d, scoped)
+	}
+}
+
+// withCredentialsProfileMeta wraps a resource function so that it is called with the meta of the credentials profile
+func withCredentialsProfileMeta[F ~func(context.Context, *schema.ResourceData, any) diag.Diagnostics](f F) F {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		scoped, loaded, err := credentialsProfileMeta(d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		diags := f(ctx, d, scoped)
+
+		if loaded {
+			diags = append(diags, credentialsProfileSourcesDiagnostics(scoped)...)
+		}
+
+		return diags
+	}
+}
+
+// credentialsProfileMeta returns the meta of the profile selected by the credentials_profile of the resource
+func credentialsProfileMeta(d interface{ Get(key string) any }, m any) (*meta.Meta, bool, error) {
+	profile, _ := d.Get(credentialsProfileKey).(string)
+
+	return m.(*meta.Meta).WithCredentialsProfile(profile)
+}
+
+// credentialsProfileSourcesDiagnostics warns when a credentials profile overrides variables set elsewhere, the same way
+// the provider does on configure
+func credentialsProfileSourcesDiagnostics(m *meta.Meta) diag.Diagnostics {
+	multiple, message, err := m.HasMultipleVariableSources()
+	if err != nil || !multiple {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Credentials profile overrides other variable sources, please make sure the right credentials are used",
+		Detail:   message,
+	}}
+}
@@ -2,16 +2,21 @@ package provider
 
 import (
 	"context"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/functions"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
@@ -22,18 +27,23 @@ import (
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/iam"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/jobs"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/k8s"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/keymanager"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/lb"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/mongodb"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/s2svpn"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/scwconfig"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/secret"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpcgw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
 )
 
 var (
-	_ provider.Provider            = &ScalewayProvider{}
-	_ provider.ProviderWithActions = (*ScalewayProvider)(nil)
+	_ provider.Provider                  = &ScalewayProvider{}
+	_ provider.ProviderWithActions       = (*ScalewayProvider)(nil)
+	_ provider.ProviderWithListResources = (*ScalewayProvider)(nil)
 )
 
 type ScalewayProvider struct {
@@ -59,6 +69,12 @@ type ScalewayProviderModel struct {
 	APIURL         types.String `tfsdk:"api_url"`
 	Region         types.String `tfsdk:"region"`
 	Zone           types.String `tfsdk:"zone"`
+	DefaultTags    types.List   `tfsdk:"default_tags"`
+	IgnoreTags     types.List   `tfsdk:"ignore_tags"`
+
+	MaxRequestsPerSecond types.Float64 `tfsdk:"max_requests_per_second"`
+	MaxRetries           types.Int64   `tfsdk:"max_retries"`
+	RetryWaitMax         types.String  `tfsdk:"retry_wait_max"`
 }
 
 func (p *ScalewayProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
@@ -96,6 +112,37 @@ func (p *ScalewayProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Description: "The zone you want to attach the resource to",
 				Optional:    true,
 			},
+			"default_tags": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Tags added to every resource supporting tags.",
+			},
+			"ignore_tags": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Prefixes of the tags managed outside of Terraform. Matching tags are kept on resources and never show up in a diff.",
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The maximum number of requests per second sent to each Scaleway API. Requests are not limited by default.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of retries of a request failing with a network error, a server error or a rate limit error. Defaults to 3.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_max": schema.StringAttribute{
+				Optional:    true,
+				Description: "The maximum time to wait between two retries of a request, e.g. `30s`. Defaults to `2m`.",
+				Validators: []validator.String{
+					verify.IsStringDuration(),
+				},
+			},
 		},
 	}
 }
@@ -122,6 +169,30 @@ func (p *ScalewayProvider) Configure(ctx context.Context, req provider.Configure
 			TerraformVersion: req.TerraformVersion,
 		}
 
+		resp.Diagnostics.Append(data.DefaultTags.ElementsAs(ctx, &config.DefaultTags, false)...)
+		resp.Diagnostics.Append(data.IgnoreTags.ElementsAs(ctx, &config.IgnoreTags, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		config.MaxRequestsPerSecond = data.MaxRequestsPerSecond.ValueFloat64Pointer()
+
+		if !data.MaxRetries.IsNull() {
+			config.MaxRetries = new(int(data.MaxRetries.ValueInt64()))
+		}
+
+		if !data.RetryWaitMax.IsNull() {
+			retryWaitMax, err := time.ParseDuration(data.RetryWaitMax.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("retry_wait_max"), "invalid retry_wait_max", err.Error())
+
+				return
+			}
+
+			config.RetryWaitMax = &retryWaitMax
+		}
+
 		var err error
 
 		m, err = meta.NewMeta(ctx, config)
@@ -136,6 +207,7 @@ func (p *ScalewayProvider) Configure(ctx context.Context, req provider.Configure
 	resp.DataSourceData = m
 	resp.ActionData = m
 	resp.EphemeralResourceData = m
+	resp.ListResourceData = m
 }
 
 func (p *ScalewayProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -143,15 +215,16 @@ func (p *ScalewayProvider) Resources(_ context.Context) []func() resource.Resour
 }
 
 func (p *ScalewayProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
-	return []func() ephemeral.EphemeralResource{
+	return ephemeralResourcesWithCredentialsProfile([]func() ephemeral.EphemeralResource{
 		keymanager.NewDecryptEphemeralResource,
 		keymanager.NewEncryptEphemeralResource,
 		keymanager.NewGenerateDataKeyEphemeralResource,
 		keymanager.NewSignEphemeralResource,
 		iam.NewApiKeyEphemeralResource,
+		k8s.NewClusterKubeconfigEphemeralResource,
 		secret.NewVersionEphemeralResource,
 		scwconfig.NewScwConfigEphemeralResource,
-	}
+	})
 }
 
 func (p *ScalewayProvider) DataSources(_ context.Context) []func() datasource.DataSource {
@@ -159,7 +232,7 @@ func (p *ScalewayProvider) DataSources(_ context.Context) []func() datasource.Da
 }
 
 func (p *ScalewayProvider) Actions(_ context.Context) []func() action.Action {
-	return []func() action.Action{
+	return actionsWithCredentialsProfile([]func() action.Action{
 		applesilicon.NewRebootServerAction,
 		baremetal.NewBaremetalServerAction,
 		block.NewExportSnapshot,
@@ -168,6 +241,7 @@ func (p *ScalewayProvider) Actions(_ context.Context) []func() action.Action {
 		instance.NewExportSnapshot,
 		instance.NewServerAction,
 		jobs.NewStartJobDefinitionAction,
+		k8s.NewNodeOperationAction,
 		keymanager.NewRotateKeyAction,
 		mongodb.NewInstanceSnapshotAction,
 		rdb.NewDatabaseBackupExportAction,
@@ -181,15 +255,31 @@ func (p *ScalewayProvider) Actions(_ context.Context) []func() action.Action {
 		s2svpn.NewConnectionEnableRoutePropagationAction,
 		s2svpn.NewConnectionDisableRoutePropagationAction,
 		vpcgw.NewRefreshSSHKeysAction,
-	}
+	})
 }
 
 func (p *ScalewayProvider) ListResources(_ context.Context) []func() list.ListResource {
-	return []func() list.ListResource{}
+	return []func() list.ListResource{
+		block.NewVolumeListResource,
+		instance.NewServerListResource,
+		instance.NewVolumeListResource,
+		k8s.NewClusterListResource,
+		lb.NewLbListResource,
+		vpc.NewPrivateNetworkListResource,
+	}
 }
 
 func (p *ScalewayProvider) Functions(_ context.Context) []func() function.Function {
 	return []func() function.Function{
+		functions.NewBuildRegionalID,
+		functions.NewBuildZonalID,
+		functions.NewCIDROverlaps,
+		functions.NewCIDRReservedAddresses,
+		functions.NewNextFreeSubnet,
+		functions.NewParseNestedID,
 		functions.NewRegionFromID,
+		functions.NewUUIDFromID,
+		functions.NewZoneFromID,
+		functions.NewZoneToRegion,
 	}
 }
@@ -0,0 +1,347 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	actionschema "github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	ephemeralschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+)
+
+const credentialsProfileDescription = "The name of a profile of the Scaleway config file whose credentials are used instead of the provider ones"
+
+var (
+	_ action.ActionWithConfigure               = (*credentialsProfileAction)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*credentialsProfileEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithClose     = (*credentialsProfileEphemeralResource)(nil)
+)
+
+// actionsWithCredentialsProfile adds the credentials_profile argument to every action
+func actionsWithCredentialsProfile(actions []func() action.Action) []func() action.Action {
+	wrapped := make([]func() action.Action, 0, len(actions))
+
+	for _, newAction := range actions {
+		wrapped = append(wrapped, func() action.Action {
+			return &credentialsProfileAction{
+				Action:    newAction(),
+				newAction: newAction,
+			}
+		})
+	}
+
+	return wrapped
+}
+
+// ephemeralResourcesWithCredentialsProfile adds the credentials_profile argument to every ephemeral resource
+func ephemeralResourcesWithCredentialsProfile(resources []func() ephemeral.EphemeralResource) []func() ephemeral.EphemeralResource {
+	wrapped := make([]func() ephemeral.EphemeralResource, 0, len(resources))
+
+	for _, newResource := range resources {
+		wrapped = append(wrapped, func() ephemeral.EphemeralResource {
+			return &credentialsProfileEphemeralResource{
+				EphemeralResource: newResource(),
+				newResource:       newResource,
+			}
+		})
+	}
+
+	return wrapped
+}
+
+// credentialsProfileAction invokes an action configured with the meta of the credentials profile of its configuration.
+// The wrapped action never sees the credentials_profile argument.
+type credentialsProfileAction struct {
+	action.Action
+
+	newAction func() action.Action
+	meta      *meta.Meta
+}
+
+func (a *credentialsProfileAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	m, ok := req.ProviderData.(*meta.Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *meta.Meta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.meta = m
+}
+
+func (a *credentialsProfileAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	a.Action.Schema(ctx, req, resp)
+
+	if resp.Schema.Attributes == nil {
+		resp.Schema.Attributes = map[string]actionschema.Attribute{}
+	}
+
+	resp.Schema.Attributes[credentialsProfileKey] = actionschema.StringAttribute{
+		Optional:    true,
+		Description: credentialsProfileDescription,
+	}
+}
+
+func (a *credentialsProfileAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	scoped, diags := frameworkCredentialsProfileMeta(ctx, req.Config, a.meta)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inner := a.newAction()
+
+	if configurable, ok := inner.(action.ActionWithConfigure); ok && scoped != nil {
+		configureResp := &action.ConfigureResponse{}
+		configurable.Configure(ctx, action.ConfigureRequest{ProviderData: scoped}, configureResp)
+		resp.Diagnostics.Append(configureResp.Diagnostics...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	schemaResp := &action.SchemaResponse{}
+	inner.Schema(ctx, action.SchemaRequest{}, schemaResp)
+	resp.Diagnostics.Append(schemaResp.Diagnostics...)
+
+	config, err := withoutCredentialsProfile(req.Config.Raw, schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading the action configuration", err.Error())
+
+		return
+	}
+
+	inner.Invoke(ctx, action.InvokeRequest{
+		Config: tfsdk.Config{Raw: config, Schema: schemaResp.Schema},
+	}, resp)
+}
+
+// credentialsProfileEphemeralResource opens an ephemeral resource configured with the meta of the credentials profile
+// of its configuration. The wrapped ephemeral resource never sees the credentials_profile argument.
+type credentialsProfileEphemeralResource struct {
+	ephemeral.EphemeralResource
+
+	newResource func() ephemeral.EphemeralResource
+	meta        *meta.Meta
+}
+
+func (r *credentialsProfileEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	m, ok := req.ProviderData.(*meta.Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *meta.Meta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.meta = m
+}
+
+func (r *credentialsProfileEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	r.EphemeralResource.Schema(ctx, req, resp)
+
+	if resp.Schema.Attributes == nil {
+		resp.Schema.Attributes = map[string]ephemeralschema.Attribute{}
+	}
+
+	resp.Schema.Attributes[credentialsProfileKey] = ephemeralschema.StringAttribute{
+		Optional:    true,
+		Description: credentialsProfileDescription,
+	}
+}
+
+// scoped returns the wrapped ephemeral resource configured with the meta of the given profile, and its schema
+func (r *credentialsProfileEphemeralResource) scoped(ctx context.Context, profile types.String) (ephemeral.EphemeralResource, ephemeralschema.Schema, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	inner := r.newResource()
+
+	schemaResp := &ephemeral.SchemaResponse{}
+	inner.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+	diags.Append(schemaResp.Diagnostics...)
+
+	if r.meta == nil {
+		return inner, schemaResp.Schema, diags
+	}
+
+	scoped, _, err := r.meta.WithCredentialsProfile(profile.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root(credentialsProfileKey), "Invalid credentials profile", err.Error())
+
+		return nil, schemaResp.Schema, diags
+	}
+
+	if configurable, ok := inner.(ephemeral.EphemeralResourceWithConfigure); ok {
+		configureResp := &ephemeral.ConfigureResponse{}
+		configurable.Configure(ctx, ephemeral.ConfigureRequest{ProviderData: scoped}, configureResp)
+		diags.Append(configureResp.Diagnostics...)
+	}
+
+	return inner, schemaResp.Schema, diags
+}
+
+func (r *credentialsProfileEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var profile types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(credentialsProfileKey), &profile)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inner, innerSchema, diags := r.scoped(ctx, profile)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerType := innerSchema.Type().TerraformType(ctx)
+
+	config, err := withoutCredentialsProfile(req.Config.Raw, innerType)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading the ephemeral resource configuration", err.Error())
+
+		return
+	}
+
+	innerResp := &ephemeral.OpenResponse{
+		Result:  tfsdk.EphemeralResultData{Raw: tftypes.NewValue(innerType, nil), Schema: innerSchema},
+		Private: resp.Private,
+	}
+
+	inner.Open(ctx, ephemeral.OpenRequest{
+		Config:             tfsdk.Config{Raw: config, Schema: innerSchema},
+		ClientCapabilities: req.ClientCapabilities,
+	}, innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.RenewAt = innerResp.RenewAt
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := withCredentialsProfileValue(innerResp.Result.Raw, resp.Result.Schema.Type().TerraformType(ctx), profile.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError("Error setting the ephemeral resource result", err.Error())
+
+		return
+	}
+
+	resp.Result.Raw = result
+
+	// Close has no configuration, the profile is kept to close the resource with the same credentials
+	if resp.Private != nil {
+		rawProfile, _ := json.Marshal(profile.ValueString())
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, credentialsProfileKey, rawProfile)...)
+	}
+}
+
+func (r *credentialsProfileEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	if _, ok := r.EphemeralResource.(ephemeral.EphemeralResourceWithClose); !ok {
+		return
+	}
+
+	var profile string
+
+	if req.Private != nil {
+		rawProfile, diags := req.Private.GetKey(ctx, credentialsProfileKey)
+		resp.Diagnostics.Append(diags...)
+
+		if rawProfile != nil {
+			if err := json.Unmarshal(rawProfile, &profile); err != nil {
+				resp.Diagnostics.AddError("Error reading the credentials profile of the ephemeral resource", err.Error())
+			}
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inner, _, diags := r.scoped(ctx, types.StringValue(profile))
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inner.(ephemeral.EphemeralResourceWithClose).Close(ctx, req, resp)
+}
+
+// frameworkCredentialsProfileMeta returns the meta of the profile selected by the credentials_profile of a configuration
+func frameworkCredentialsProfileMeta(ctx context.Context, config tfsdk.Config, m *meta.Meta) (*meta.Meta, diag.Diagnostics) {
+	var profile types.String
+
+	diags := config.GetAttribute(ctx, path.Root(credentialsProfileKey), &profile)
+	if diags.HasError() || m == nil {
+		return m, diags
+	}
+
+	scoped, _, err := m.WithCredentialsProfile(profile.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root(credentialsProfileKey), "Invalid credentials profile", err.Error())
+	}
+
+	return scoped, diags
+}
+
+// withoutCredentialsProfile returns the given object without its credentials_profile attribute
+func withoutCredentialsProfile(raw tftypes.Value, objectType tftypes.Type) (tftypes.Value, error) {
+	if raw.IsNull() {
+		return tftypes.NewValue(objectType, nil), nil
+	}
+
+	if !raw.IsKnown() {
+		return tftypes.NewValue(objectType, tftypes.UnknownValue), nil
+	}
+
+	attributes := map[string]tftypes.Value{}
+	if err := raw.As(&attributes); err != nil {
+		return tftypes.Value{}, err
+	}
+
+	delete(attributes, credentialsProfileKey)
+
+	return tftypes.NewValue(objectType, attributes), nil
+}
+
+// withCredentialsProfileValue returns the given object with its credentials_profile attribute set to the profile
+func withCredentialsProfileValue(raw tftypes.Value, objectType tftypes.Type, profile *string) (tftypes.Value, error) {
+	if raw.IsNull() {
+		return tftypes.NewValue(objectType, nil), nil
+	}
+
+	attributes := map[string]tftypes.Value{}
+	if err := raw.As(&attributes); err != nil {
+		return tftypes.Value{}, err
+	}
+
+	attributes[credentialsProfileKey] = tftypes.NewValue(tftypes.String, profile)
+
+	return tftypes.NewValue(objectType, attributes), nil
+}
@@ -2,16 +2,27 @@ package provider_test
 
 import (
 	"context"
+	"os"
+	"path"
 	"reflect"
 	"runtime"
 	"slices"
 	"testing"
+	"time"
 
 	actionFramework "github.com/hashicorp/terraform-plugin-framework/action"
 	ephemeralFramework "github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	listFramework "github.com/hashicorp/terraform-plugin-framework/list"
 	providerFramework "github.com/hashicorp/terraform-plugin-framework/provider"
+	resourceFramework "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
 	"github.com/scaleway/terraform-provider-scaleway/v2/provider"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func extractActionDescriptions(ctx context.Context, a actionFramework.Action) (string, string) {
@@ -93,3 +104,134 @@ func TestProviderActionNameDoesNotContainAction(t *testing.T) {
 		}
 	}
 }
+
+func TestProviderListResourceDescriptionsAreNotEmpty(t *testing.T) {
+	p := provider.NewFrameworkProvider(nil)().(providerFramework.ProviderWithListResources)
+	for _, listResource := range p.ListResources(t.Context()) {
+		resp := &listFramework.ListResourceSchemaResponse{}
+		listResource().ListResourceConfigSchema(t.Context(), listFramework.ListResourceSchemaRequest{}, resp)
+
+		listResourceType := reflect.TypeOf(listResource())
+		assert.NotEmpty(t, resp.Schema.Description, "Please fill up Description field in %s schema", listResourceType)
+		assert.NotEmpty(t, resp.Schema.MarkdownDescription, "Please fill up MarkdownDescription field in %s schema", listResourceType)
+	}
+}
+
+func TestProviderListResourcesHaveIdentity(t *testing.T) {
+	sdkProvider := provider.SDKProvider(provider.DefaultConfig())()
+
+	p := provider.NewFrameworkProvider(nil)().(providerFramework.ProviderWithListResources)
+	for _, listResource := range p.ListResources(t.Context()) {
+		resp := &resourceFramework.MetadataResponse{}
+		listResource().Metadata(t.Context(), resourceFramework.MetadataRequest{ProviderTypeName: "scaleway"}, resp)
+
+		managedResource, exists := sdkProvider.ResourcesMap[resp.TypeName]
+		if !assert.True(t, exists, "list resource %s does not match any managed resource", resp.TypeName) {
+			continue
+		}
+
+		assert.NotNil(t, managedResource.Identity, "managed resource %s must define an identity to be listed", resp.TypeName)
+	}
+}
+
+func TestProviderFrameworkComponentsHaveCredentialsProfile(t *testing.T) {
+	ctx := t.Context()
+	p := provider.NewFrameworkProvider(nil)()
+
+	for _, action := range p.(providerFramework.ProviderWithActions).Actions(ctx) {
+		resp := &actionFramework.SchemaResponse{}
+		action().Schema(ctx, actionFramework.SchemaRequest{}, resp)
+		assert.Contains(t, resp.Schema.Attributes, "credentials_profile", "action %s has no credentials_profile", resp.Schema.Description)
+	}
+
+	for _, ephemeral := range p.(providerFramework.ProviderWithEphemeralResources).EphemeralResources(ctx) {
+		resp := &ephemeralFramework.SchemaResponse{}
+		ephemeral().Schema(ctx, ephemeralFramework.SchemaRequest{}, resp)
+		assert.Contains(t, resp.Schema.Attributes, "credentials_profile", "ephemeral resource %s has no credentials_profile", resp.Schema.Description)
+	}
+
+	for _, listResource := range p.(providerFramework.ProviderWithListResources).ListResources(ctx) {
+		resp := &listFramework.ListResourceSchemaResponse{}
+		listResource().ListResourceConfigSchema(ctx, listFramework.ListResourceSchemaRequest{}, resp)
+		assert.Contains(t, resp.Schema.Attributes, "credentials_profile", "list resource %s has no credentials_profile", resp.Schema.Description)
+	}
+}
+
+func TestProviderActionCredentialsProfile(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(path.Join(dir, "config.yaml"), []byte(`profiles:
+  team:
+    access_key: SCWYYYYYYYYYYYYYYYYY
+    secret_key: 11111111-1111-1111-1111-111111111111
+`), 0o644)
+	require.NoError(t, err)
+
+	t.Setenv("SCW_CONFIG_PATH", path.Join(dir, "config.yaml"))
+
+	ctx := t.Context()
+	m := acctest.NewMockedMeta(t)
+	k8sAPI := k8s.NewAPI(m.ScwClient())
+
+	cluster, err := k8sAPI.CreateCluster(&k8s.CreateClusterRequest{Name: "test", Version: "1.32.3", Cni: k8s.CNICilium}, scw.WithContext(ctx))
+	require.NoError(t, err)
+
+	pool, err := k8sAPI.CreatePool(&k8s.CreatePoolRequest{ClusterID: cluster.ID, Name: "default", NodeType: "PRO2-XXS", Size: 1}, scw.WithContext(ctx))
+	require.NoError(t, err)
+
+	_, err = k8sAPI.WaitForPool(&k8s.WaitForPoolRequest{PoolID: pool.ID, RetryInterval: new(time.Duration(0))}, scw.WithContext(ctx))
+	require.NoError(t, err)
+
+	nodes, err := k8sAPI.ListNodes(&k8s.ListNodesRequest{ClusterID: cluster.ID}, scw.WithContext(ctx), scw.WithAllPages())
+	require.NoError(t, err)
+	require.Len(t, nodes.Nodes, 1)
+
+	var nodeOperation actionFramework.Action
+
+	for _, newAction := range provider.NewFrameworkProvider(m)().(providerFramework.ProviderWithActions).Actions(ctx) {
+		resp := &actionFramework.MetadataResponse{}
+		newAction().Metadata(ctx, actionFramework.MetadataRequest{ProviderTypeName: "scaleway"}, resp)
+
+		if resp.TypeName == "scaleway_k8s_node_operation" {
+			nodeOperation = newAction()
+		}
+	}
+
+	require.NotNil(t, nodeOperation)
+
+	configureResp := &actionFramework.ConfigureResponse{}
+	nodeOperation.(actionFramework.ActionWithConfigure).Configure(ctx, actionFramework.ConfigureRequest{ProviderData: m}, configureResp)
+	require.False(t, configureResp.Diagnostics.HasError())
+
+	schemaResp := &actionFramework.SchemaResponse{}
+	nodeOperation.Schema(ctx, actionFramework.SchemaRequest{}, schemaResp)
+
+	invoke := func(profile string) *actionFramework.InvokeResponse {
+		resp := &actionFramework.InvokeResponse{}
+		nodeOperation.Invoke(ctx, actionFramework.InvokeRequest{
+			Config: tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+					"action":              tftypes.NewValue(tftypes.String, "reboot"),
+					"node_id":             tftypes.NewValue(tftypes.String, nodes.Nodes[0].ID),
+					"region":              tftypes.NewValue(tftypes.String, nil),
+					"wait":                tftypes.NewValue(tftypes.Bool, nil),
+					"credentials_profile": tftypes.NewValue(tftypes.String, profile),
+				}),
+			},
+		}, resp)
+
+		return resp
+	}
+
+	resp := invoke("unknown")
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), `cannot load credentials profile "unknown"`)
+
+	resp = invoke("team")
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	node, err := k8sAPI.GetNode(&k8s.GetNodeRequest{NodeID: nodes.Nodes[0].ID}, scw.WithContext(ctx))
+	require.NoError(t, err)
+	assert.Equal(t, k8s.NodeStatusRebooting, node.Status)
+}
@@ -7,8 +7,10 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
@@ -84,6 +86,13 @@ func addBetaResources(provider *schema.Provider) {
 	maps.Copy(provider.DataSourcesMap, betaDataSources)
 }
 
+// addProviderTags applies the provider default_tags and ignore_tags to every resource with a tags list
+func addProviderTags(provider *schema.Provider) {
+	for _, resource := range provider.ResourcesMap {
+		cdf.WithProviderTags(resource)
+	}
+}
+
 // SDKProvider returns a terraform.ResourceProvider.
 func SDKProvider(config *Config) plugin.ProviderFunc {
 	return func() *schema.Provider {
@@ -124,6 +133,36 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 					Optional:    true,
 					Description: "The Scaleway API URL to use.",
 				},
+				"default_tags": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Tags added to every resource supporting tags.",
+				},
+				"ignore_tags": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Prefixes of the tags managed outside of Terraform. Matching tags are kept on resources and never show up in a diff.",
+				},
+				"max_requests_per_second": {
+					Type:             schema.TypeFloat,
+					Optional:         true,
+					Description:      "The maximum number of requests per second sent to each Scaleway API. Requests are not limited by default.",
+					ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
+				},
+				"max_retries": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					Description:      "The maximum number of retries of a request failing with a network error, a server error or a rate limit error. Defaults to 3.",
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				},
+				"retry_wait_max": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Description:      "The maximum time to wait between two retries of a request, e.g. `30s`. Defaults to `2m`.",
+					ValidateDiagFunc: verify.IsDuration(),
+				},
 			},
 
 			ResourcesMap: map[string]*schema.Resource{
@@ -136,6 +175,7 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_autoscaling_instance_template":       autoscaling.ResourceInstanceTemplate(),
 				"scaleway_baremetal_server":                    baremetal.ResourceServer(),
 				"scaleway_block_snapshot":                      block.ResourceSnapshot(),
+				"scaleway_block_snapshot_policy":               block.ResourceSnapshotPolicy(),
 				"scaleway_block_volume":                        block.ResourceVolume(),
 				"scaleway_cockpit":                             cockpit.ResourceCockpit(),
 				"scaleway_cockpit_source":                      cockpit.ResourceCockpitSource(),
@@ -183,12 +223,16 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_inference_deployment":                inference.ResourceDeployment(),
 				"scaleway_inference_model":                     inference.ResourceModel(),
 				"scaleway_instance_image":                      instance.ResourceImage(),
+				"scaleway_instance_image_build":                instance.ResourceImageBuild(),
 				"scaleway_instance_ip":                         instance.ResourceIP(),
+				"scaleway_instance_ip_pool":                    instance.ResourceIPPool(),
 				"scaleway_instance_ip_reverse_dns":             instance.ResourceIPReverseDNS(),
 				"scaleway_instance_placement_group":            instance.ResourcePlacementGroup(),
+				"scaleway_instance_placement_group_membership": instance.ResourcePlacementGroupMembership(),
 				"scaleway_instance_private_nic":                instance.ResourcePrivateNIC(),
 				"scaleway_instance_security_group":             instance.ResourceSecurityGroup(),
 				"scaleway_instance_security_group_rules":       instance.ResourceSecurityGroupRules(),
+				"scaleway_instance_security_group_rule":        instance.ResourceSecurityGroupRule(),
 				"scaleway_instance_server":                     instance.ResourceServer(),
 				"scaleway_instance_snapshot":                   instance.ResourceSnapshot(),
 				"scaleway_instance_user_data":                  instance.ResourceUserData(),
@@ -309,8 +353,10 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_instance_private_nic":                instance.DataSourcePrivateNIC(),
 				"scaleway_instance_security_group":             instance.DataSourceSecurityGroup(),
 				"scaleway_instance_server":                     instance.DataSourceServer(),
+				"scaleway_instance_server_diagnostic":          instance.DataSourceServerDiagnostic(),
 				"scaleway_instance_servers":                    instance.DataSourceServers(),
 				"scaleway_instance_server_type":                instance.DataSourceServerType(),
+				"scaleway_instance_server_types":               instance.DataSourceServerTypes(),
 				"scaleway_instance_snapshot":                   instance.DataSourceSnapshot(),
 				"scaleway_instance_volume":                     instance.DataSourceVolume(),
 				"scaleway_iot_device":                          iot.DataSourceDevice(),
@@ -319,6 +365,7 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_ipam_ips":                            ipam.DataSourceIPs(),
 				"scaleway_kafka_cluster":                       kafka.DataSourceCluster(),
 				"scaleway_k8s_cluster":                         k8s.DataSourceCluster(),
+				"scaleway_k8s_nodes":                           k8s.DataSourceNodes(),
 				"scaleway_k8s_pool":                            k8s.DataSourcePool(),
 				"scaleway_k8s_version":                         k8s.DataSourceVersion(),
 				"scaleway_key_manager_key":                     keymanager.DataSourceKey(),
@@ -375,6 +422,8 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 		}
 
 		addBetaResources(p)
+		addProviderTags(p)
+		addCredentialsProfile(p)
 
 		p.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
 			terraformVersion := p.TerraformVersion
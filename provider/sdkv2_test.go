@@ -2,17 +2,22 @@ package provider_test
 
 import (
 	"fmt"
+	"os"
+	"path"
 	"slices"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	iamchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/iam/testfuncs"
 	instancechecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/testfuncs"
 	"github.com/scaleway/terraform-provider-scaleway/v2/provider"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -148,8 +153,6 @@ func TestSDKProvider_ResourceIdentityNotEmpty(t *testing.T) {
 		"scaleway_autoscaling_instance_policy",
 		"scaleway_autoscaling_instance_template",
 		"scaleway_baremetal_server",
-		"scaleway_block_snapshot",
-		"scaleway_block_volume",
 		"scaleway_cockpit",
 		"scaleway_cockpit_grafana_user",
 		"scaleway_cockpit_alert_manager",
@@ -159,9 +162,6 @@ func TestSDKProvider_ResourceIdentityNotEmpty(t *testing.T) {
 		"scaleway_container_namespace",
 		"scaleway_container_token",
 		"scaleway_container_trigger",
-		"scaleway_datawarehouse_deployment",
-		"scaleway_datawarehouse_user",
-		"scaleway_datawarehouse_database",
 		"scaleway_domain_record",
 		"scaleway_domain_registration",
 		"scaleway_domain_zone",
@@ -183,11 +183,8 @@ func TestSDKProvider_ResourceIdentityNotEmpty(t *testing.T) {
 		"scaleway_iam_policy",
 		"scaleway_iam_ssh_key",
 		"scaleway_iam_user",
-		"scaleway_inference_deployment",
-		"scaleway_inference_model",
 		"scaleway_instance_security_group",
 		"scaleway_instance_security_group_rules",
-		"scaleway_instance_server",
 		"scaleway_iot_device",
 		"scaleway_iot_hub",
 		"scaleway_iot_network",
@@ -195,41 +192,13 @@ func TestSDKProvider_ResourceIdentityNotEmpty(t *testing.T) {
 		"scaleway_ipam_ip",
 		"scaleway_ipam_ip_reverse_dns",
 		"scaleway_job_definition",
-		"scaleway_k8s_acl",
-		"scaleway_k8s_cluster",
-		"scaleway_k8s_pool",
 		"scaleway_key_manager_key",
-		"scaleway_mnq_nats_account",
-		"scaleway_mnq_nats_credentials",
-		"scaleway_mnq_sns",
-		"scaleway_mnq_sns_credentials",
-		"scaleway_mnq_sns_topic",
-		"scaleway_mnq_sns_topic_subscription",
-		"scaleway_mnq_sqs",
-		"scaleway_mnq_sqs_credentials",
-		"scaleway_mnq_sqs_queue",
-		"scaleway_object",
-		"scaleway_object_bucket",
-		"scaleway_object_bucket_acl",
-		"scaleway_object_bucket_lock_configuration",
-		"scaleway_object_bucket_policy",
-		"scaleway_object_bucket_website_configuration",
-		"scaleway_rdb_acl",
-		"scaleway_rdb_database",
-		"scaleway_rdb_database_backup",
-		"scaleway_rdb_instance",
-		"scaleway_rdb_privilege",
-		"scaleway_rdb_read_replica",
-		"scaleway_rdb_user",
-		"scaleway_rdb_snapshot",
 		"scaleway_registry_namespace",
 		"scaleway_s2s_vpn_gateway",
 		"scaleway_s2s_vpn_customer_gateway",
 		"scaleway_s2s_vpn_connection",
 		"scaleway_s2s_vpn_routing_policy",
 		"scaleway_sdb_sql_database",
-		"scaleway_secret",
-		"scaleway_secret_version",
 		"scaleway_tem_domain",
 		"scaleway_tem_domain_validation",
 		"scaleway_tem_blocked_list",
@@ -260,3 +229,75 @@ func TestSDKProvider_ResourceImporterNotEmpty(t *testing.T) {
 		}
 	}
 }
+
+func TestSDKProvider_CredentialsProfile(t *testing.T) {
+	p := provider.SDKProvider(nil)()
+	require.NoError(t, p.InternalValidate())
+
+	for name, r := range p.ResourcesMap {
+		if _, ok := r.SchemaMap()["credentials_profile"]; !ok {
+			t.Errorf("resource %s has no credentials_profile", name)
+		}
+
+		if r.UpdateContext == nil && r.UpdateWithoutTimeout == nil {
+			t.Errorf("resource %s cannot update its credentials_profile", name)
+		}
+	}
+
+	for name, d := range p.DataSourcesMap {
+		if _, ok := d.SchemaMap()["credentials_profile"]; !ok {
+			t.Errorf("data source %s has no credentials_profile", name)
+		}
+	}
+}
+
+func TestSDKProvider_CredentialsProfileChangeIsChecked(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(path.Join(dir, "config.yaml"), []byte(`profiles:
+  team:
+    access_key: SCWYYYYYYYYYYYYYYYYY
+    secret_key: 11111111-1111-1111-1111-111111111111
+`), 0o644)
+	require.NoError(t, err)
+
+	t.Setenv("SCW_CONFIG_PATH", path.Join(dir, "config.yaml"))
+
+	ctx := t.Context()
+	m := acctest.NewMockedMeta(t)
+	r := provider.SDKProvider(&provider.Config{Meta: m})().ResourcesMap["scaleway_instance_ip"]
+
+	instanceAPI := instanceSDK.NewAPI(m.ScwClient())
+
+	ip, err := instanceAPI.CreateIP(&instanceSDK.CreateIPRequest{
+		Zone: scw.ZoneFrPar1,
+		Type: instanceSDK.IPTypeRoutedIPv4,
+	}, scw.WithContext(ctx))
+	require.NoError(t, err)
+
+	d := r.Data(&terraform.InstanceState{ID: "fr-par-1/" + ip.IP.ID})
+	require.False(t, r.ReadContext(ctx, d, m).HasError())
+
+	state := d.State()
+	state.RawState, err = state.AttrsAsObjectValue(r.CoreConfigSchema().ImpliedType())
+	require.NoError(t, err)
+
+	config := terraform.NewResourceConfigRaw(map[string]any{"credentials_profile": "team"})
+
+	// The profile change is updated in place when the IP is visible with the credentials of the profile
+	diff, err := r.Diff(ctx, state, config, m)
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+	assert.Equal(t, "team", diff.Attributes["credentials_profile"].New)
+
+	require.NoError(t, instanceAPI.DeleteIP(&instanceSDK.DeleteIPRequest{
+		Zone: scw.ZoneFrPar1,
+		IP:   ip.IP.ID,
+	}, scw.WithContext(ctx)))
+
+	_, err = r.Diff(ctx, state, config, m)
+	require.ErrorContains(t, err, `cannot be found with credentials profile "team"`)
+
+	_, err = r.Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]any{"credentials_profile": "unknown"}), m)
+	require.ErrorContains(t, err, `cannot load credentials profile "unknown"`)
+}